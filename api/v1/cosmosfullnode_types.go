@@ -34,6 +34,7 @@ const CosmosFullNodeController = "CosmosFullNode"
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // FullNodeSpec defines the desired state of CosmosFullNode
+// +kubebuilder:validation:XValidation:rule="!has(self.selfHeal) || !has(self.selfHeal.pruningSpec) || !has(self.selfHeal.pruningSpec.strategy) || self.selfHeal.pruningSpec.strategy != 'PruneAppState' || !has(self.chainSpec.chainType) || self.chainSpec.chainType != 'namada' || (has(self.selfHeal.pruningSpec.pruningCommand) && size(self.selfHeal.pruningSpec.pruningCommand) > 0)",message="selfHeal.pruningSpec.strategy PruneAppState is not supported for chainType namada"
 type FullNodeSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -144,6 +145,18 @@ type FullNodeStatus struct {
 	// Latest Height information. collected when node starts up and when RPC is successfully queried.
 	// +optional
 	Height map[string]uint64 `json:"height,omitempty"`
+
+	// Version upgrades in progress keyed by pod name. Set by the version check sidecar once a pod approaches the
+	// upgrade height of its next spec.chain.versions entry.
+	// +mapType:=granular
+	// +optional
+	Upgrades map[string]*UpgradeStatus `json:"upgrades,omitempty"`
+
+	// The maximum height of in-sync pods and the trusted nodes in spec.chain.reference.
+	// The healthcheck sidecar compares the node's height against it if spec.podTemplate.probes.readiness.maxHeightLag
+	// is set.
+	// +optional
+	ReferenceHeight *uint64 `json:"referenceHeight,omitempty"`
 }
 
 type SyncInfoPodStatus struct {
@@ -165,6 +178,89 @@ type SyncInfoPodStatus struct {
 
 	// +optional
 	HeightRetainTime *metav1.Duration `json:"heightRetainTime,omitempty"`
+
+	// Earliest height the node has blocks for. Greater than 1 if the node is pruned or was restored from a snapshot.
+	// +optional
+	EarliestHeight *uint64 `json:"earliestHeight,omitempty"`
+
+	// Node and validator info reported by the pod's CometBFT status.
+	// +optional
+	Node *NodeInfoPodStatus `json:"node,omitempty"`
+
+	// Namada-specific health reported by the pod's healthcheck sidecar. Only set for Namada chains.
+	// +optional
+	Namada *NamadaPodStatus `json:"namada,omitempty"`
+}
+
+// NodeInfoPodStatus is the node_info and validator_info reported by the pod's CometBFT status.
+type NodeInfoPodStatus struct {
+	// The node ID, derived from the node key.
+	ID string `json:"id"`
+	// The chain ID the node is on.
+	// +optional
+	Network string `json:"network,omitempty"`
+	// +optional
+	Moniker string `json:"moniker,omitempty"`
+	// The CometBFT version of the running binary.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// The application's protocol version.
+	// +optional
+	AppVersion string `json:"appVersion,omitempty"`
+	// The address of the node's validator key.
+	// +optional
+	ValidatorAddress string `json:"validatorAddress,omitempty"`
+	// The voting power of the node's validator key. Zero unless the node is in the active validator set.
+	// +optional
+	VotingPower *int64 `json:"votingPower,omitempty"`
+	// Set if the node info does not match what the operator expects, e.g. the node ID does not match the
+	// node key Secret or the network does not match spec.chain.chainID.
+	// +optional
+	Mismatches []string `json:"mismatches,omitempty"`
+}
+
+// NamadaPodStatus is the health of the Namada ledger, which can be unhealthy while CometBFT's status looks fine.
+type NamadaPodStatus struct {
+	// The ledger shell's last block height.
+	// +optional
+	ShellHeight *uint64 `json:"shellHeight,omitempty"`
+	// Set if the ledger shell is unresponsive.
+	// +optional
+	ShellError *string `json:"shellError,omitempty"`
+	// Set if the Ethereum bridge oracle cannot reach its Ethereum RPC endpoint.
+	// Only checked if spec.chain.namada.ledger.ethereumBridge.mode queries an endpoint. Does not affect the pod's readiness.
+	// +optional
+	OracleError *string `json:"oracleError,omitempty"`
+	// Set if unable to fetch the health from the healthcheck sidecar.
+	// +optional
+	Error *string `json:"error,omitempty"`
+}
+
+// UpgradePhase is the progress of a pod's version upgrade.
+type UpgradePhase string
+
+const (
+	// UpgradePhasePending means the pod is approaching the upgrade height.
+	UpgradePhasePending UpgradePhase = "UpgradePending"
+
+	// UpgradePhaseHalted means the pod reached the upgrade height, where it halts until the operator recreates it
+	// with the upgraded image.
+	UpgradePhaseHalted UpgradePhase = "Halted"
+)
+
+// UpgradeStatus is the progress of a pod's upgrade to the next spec.chain.versions entry.
+type UpgradeStatus struct {
+	// The phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// The upgrade height.
+	Height uint64 `json:"height"`
+
+	// The image the pod runs from the upgrade height.
+	Image string `json:"image"`
+
+	// When the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
 type FullNodeSnapshotStatus struct {
@@ -338,6 +434,48 @@ type FullNodeProbesSpec struct {
 	// +kubebuilder:validation:Enum:=None
 	// +optional
 	Strategy FullNodeProbeStrategy `json:"strategy"`
+
+	// Readiness criteria checked by the healthcheck sidecar in addition to the node not catching up.
+	// Without them, a node stuck at an old height which does not report itself as catching up stays ready.
+	// +optional
+	Readiness *ReadinessCriteria `json:"readiness"`
+
+	// APIs which must serve the latest block for the pod to be ready, in addition to CometBFT RPC.
+	// Either API can be broken while RPC is fine, e.g. the API is disabled in app.toml or the gRPC port conflicts.
+	// The healthcheck sidecar also serves /grpc and /rest to check them individually.
+	// Ignored for Namada.
+	// +optional
+	APIs []FullNodeProbeAPI `json:"apis"`
+}
+
+// FullNodeProbeAPI is a Cosmos SDK API checked by the healthcheck sidecar.
+// GRPC = cosmos.base.tendermint.v1beta1.Service/GetLatestBlock on the gRPC port.
+// REST = /cosmos/base/tendermint/v1beta1/blocks/latest on the API port.
+// +kubebuilder:validation:Enum:=GRPC;REST
+type FullNodeProbeAPI string
+
+const (
+	FullNodeProbeAPIGRPC FullNodeProbeAPI = "GRPC"
+	FullNodeProbeAPIREST FullNodeProbeAPI = "REST"
+)
+
+// ReadinessCriteria are the conditions a pod must meet to be ready. Unset criteria are not checked.
+type ReadinessCriteria struct {
+	// Maximum age of the latest block.
+	// Set well above the chain's block time.
+	// +optional
+	MaxBlockAge *metav1.Duration `json:"maxBlockAge"`
+
+	// Minimum number of peers the node is connected to.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MinPeers int32 `json:"minPeers"`
+
+	// Maximum number of blocks the node may lag behind status.referenceHeight.
+	// The reference height is updated about every 60s, so allow for the blocks produced in that time.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxHeightLag uint32 `json:"maxHeightLag"`
 }
 
 // PersistentVolumeClaimSpec describes the common attributes of storage devices
@@ -406,7 +544,7 @@ const (
 type AutoDataSource struct {
 	// If set, chooses the most recent VolumeSnapshot matching the selector to use as the PVC dataSource.
 	// See ScheduledVolumeSnapshot for a means of creating periodic VolumeSnapshots.
-	// The VolumeSnapshots must be in the same namespace as the CosmosFullNode.
+	// The VolumeSnapshots must be in the same namespace as the CosmosFullNode unless namespace is set.
 	// If no VolumeSnapshots found, controller logs error and still creates PVC.
 	// +optional
 	VolumeSnapshotSelector map[string]string `json:"volumeSnapshotSelector"`
@@ -415,6 +553,26 @@ type AutoDataSource struct {
 	// is restored from a VolumeSnapshot on the same node.
 	// This is useful if the VolumeSnapshots are local to the node, e.g. for topolvm.
 	MatchInstance bool `json:"matchInstance"`
+
+	// If set, only chooses VolumeSnapshots recorded at or above this block height.
+	// The height is recorded by the ScheduledVolumeSnapshot controller. VolumeSnapshots without a recorded height
+	// are ignored.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MinHeight *uint64 `json:"minHeight"`
+
+	// If set, only chooses VolumeSnapshots taken from a pod running this image.
+	// The image is recorded by the ScheduledVolumeSnapshot controller.
+	// +optional
+	MatchImage string `json:"matchImage"`
+
+	// If set, searches for VolumeSnapshots in this namespace instead of the CosmosFullNode's namespace.
+	// Only VolumeSnapshots whose cosmos.bharvest/allowed-namespaces annotation lists the CosmosFullNode's namespace
+	// (or "*") are chosen. See ScheduledVolumeSnapshot's allowedNamespaces.
+	// The PVC references the VolumeSnapshot via dataSourceRef, which requires the CrossNamespaceVolumeDataSource
+	// feature gate and a ReferenceGrant in the source namespace.
+	// +optional
+	Namespace string `json:"namespace"`
 }
 
 // RolloutStrategy is an update strategy that can be shared between several Cosmos CRDs.
@@ -507,6 +665,12 @@ type ChainSpec struct {
 	// +optional
 	AddrbookScript *string `json:"addrbookScript"`
 
+	// URLs tried in order if downloading from AddrbookURL fails.
+	// If spec.selfHeal.initContainerWatchdog is set, a stuck download is retried starting from the next URL.
+	// Ignored if AddrbookScript is set.
+	// +optional
+	AddrbookFallbackURLs []string `json:"addrbookFallbackURLs"`
+
 	// URL to genesis file to download from the internet.
 	// Although this field is optional, you will almost always want to set it.
 	// If not set, uses the genesis file created from the init subcommand. (This behavior may be desirable for new chains or testing.)
@@ -530,6 +694,26 @@ type ChainSpec struct {
 	// +optional
 	GenesisScript *string `json:"genesisScript"`
 
+	// URLs tried in order if downloading from GenesisURL fails.
+	// If spec.selfHeal.initContainerWatchdog is set, a stuck download is retried starting from the next URL.
+	// Ignored if GenesisScript is set.
+	// +optional
+	GenesisFallbackURLs []string `json:"genesisFallbackURLs"`
+
+	// Expected SHA-256 checksum of the genesis file, hex encoded.
+	// If set, the genesis init container fails if the downloaded genesis file does not match.
+	// Regardless, the genesis init container fails if the genesis chain_id does not match spec.chain.chainID.
+	// Ignored for Namada chains.
+	// +kubebuilder:validation:Pattern:=`^[a-fA-F0-9]{64}$`
+	// +optional
+	GenesisSHA256 *string `json:"genesisSHA256"`
+
+	// URLs tried in order if downloading from cosmos.snapshotURL or namada.snapshotURL fails.
+	// If spec.selfHeal.initContainerWatchdog is set, a stuck download is retried starting from the next URL.
+	// Ignored if a snapshotScript is set.
+	// +optional
+	SnapshotFallbackURLs []string `json:"snapshotFallbackURLs"`
+
 	// If configured as a Sentry, invokes sleep command with this value before running chain start command.
 	// Currently, requires the privval laddr to be available immediately without any retry.
 	// This workaround gives time for the connection to be made to a remote signer.
@@ -560,6 +744,24 @@ type ChainSpec struct {
 	// Additional arguments to pass to the chain start command.
 	// +optional
 	AdditionalStartArgs []string `json:"additionalStartArgs"`
+
+	// Trusted nodes whose height the pods are compared against in addition to each other.
+	// Without a reference, pods which fall behind together are not detected.
+	// +optional
+	Reference *ReferenceSpec `json:"reference"`
+}
+
+// ReferenceSpec configures trusted nodes for the same chain. Nodes reporting a different chain ID or catching up
+// are ignored. The maximum height of the trusted nodes is the reference height.
+type ReferenceSpec struct {
+	// CometBFT RPC endpoints, e.g. https://rpc.example.com:443.
+	// Polled about every 30s.
+	// +optional
+	RPCEndpoints []string `json:"rpcEndpoints"`
+
+	// Name of another CosmosFullNode in the same namespace.
+	// +optional
+	FullNode string `json:"fullNode"`
 }
 
 type ChainVersion struct {
@@ -1005,6 +1207,25 @@ type NamadaConfig struct {
 
 	// +optional
 	Ledger *NamadaLedger `json:"ledger" toml:"ledger"`
+
+	// URL for a snapshot archive to download from the internet.
+	// The archive is extracted into the chain directory, $CHAIN_HOME/<chain-id>, so it should contain the "db" and
+	// "cometbft/data" directories.
+	// The operator detects and properly handles the following file extensions:
+	// .tar, .tar.gz, .tar.gzip, .tar.lz4
+	// Use SnapshotScript if the snapshot archive is unconventional or requires special handling.
+	// +optional
+	SnapshotURL *string `json:"snapshotURL" toml:"-"`
+
+	// Specify shell (sh) script commands to properly download and process a snapshot archive.
+	// Prefer SnapshotURL if possible.
+	// Takes precedence over SnapshotURL.
+	// Available env vars:
+	// $CHAIN_HOME: The base directory for the chain, aka: --base-dir flag
+	// $CHAIN_ID: The chain ID. Chain data lives in $CHAIN_HOME/$CHAIN_ID.
+	// $DATA_DIR: The directory for the CometBFT database files.
+	// +optional
+	SnapshotScript *string `json:"snapshotScript" toml:"-"`
 }
 
 func (c *NamadaConfig) ToNamadaConfig() blockchain_toml.NamadaConfigFile {
@@ -14,6 +14,13 @@ const PruningController = "Pruning"
 // The controller only modifies the CosmosFullNode's status subresource relying on the CosmosFullNodeReconciler
 // to reconcile appropriately.
 type SelfHealSpec struct {
+	// If true, self-healing and pruning compute the actions they would take and record them in
+	// status.selfHealing.dryRun and as events, without deleting pods, resizing or regenerating PVCs, or pruning.
+	// Use to gain confidence in a configuration before enabling it.
+	// A feature's dryRun takes precedence.
+	// +optional
+	DryRun bool `json:"dryRun"`
+
 	// Automatically increases PVC storage as they approach capacity.
 	//
 	// Your cluster must support and use the ExpandInUsePersistentVolumes feature gate. This allows volumes to
@@ -28,6 +35,26 @@ type SelfHealSpec struct {
 	// +optional
 	HeightDriftMitigation *HeightDriftMitigationSpec `json:"heightDriftMitigation"`
 
+	// Take action when a pod crash loops from a known fatal error, such as a wrong AppHash or a consensus failure.
+	// Height drift mitigation cannot detect these failures because the pod's RPC never comes up.
+	//
+	// +optional
+	CrashLoopHealing *CrashLoopHealingSpec `json:"crashLoopHealing"`
+
+	// Restart pods whose init containers, such as the genesis, address book, or snapshot downloads, run past a
+	// deadline or fail repeatedly. Downloads are retried starting from the next of the chain's fallback URLs.
+	//
+	// +optional
+	InitContainerWatchdog *InitContainerWatchdogSpec `json:"initContainerWatchdog"`
+
+	// Take action when a pod stays connected to too few peers, before it falls far enough behind for height drift
+	// mitigation to detect it.
+	// Remediation escalates each time the pod remains starved after the backoff: refresh the pod's address book, then
+	// add peers connected to healthy pods as persistent peers, then restart the pod.
+	//
+	// +optional
+	PeerHealth *PeerHealthSpec `json:"peerHealth"`
+
 	// PruningSpec configures strategy of pruning.
 	//
 	// In node operating, the most important is reliable service.
@@ -63,11 +90,18 @@ type PVCAutoScaleSpec struct {
 	// Safeguards against storage quotas and costs.
 	// +optional
 	MaxSize resource.Quantity `json:"maxSize"`
+
+	// If true, only records the actions this feature would take in status.selfHealing.dryRun and as events.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
 }
 
 type HeightDriftMitigationSpec struct {
 	// If pod's height falls behind the max height of all pods by this value or more AND the pod's RPC /status endpoint
-	// reports itself as in-sync, the pod is deleted. The CosmosFullNodeController creates a new pod to replace it
+	// reports itself as in-sync, the pod is deleted.
+	// The max height includes the trusted nodes in spec.chain.reference.
+	// The CosmosFullNodeController creates a new pod to replace it
 	// Pod deletion respects the CosmosFullNode.Spec.RolloutStrategy and will not delete more pods than set
 	// by the strategy to prevent downtime.
 	// This workaround is necessary to mitigate a bug in the Cosmos SDK and/or CometBFT where pods report themselves as
@@ -86,10 +120,187 @@ type HeightDriftMitigationSpec struct {
 	//
 	// Through this field, you could regenerate pvc using spec.volumeClaimTemplate when the starting failure count of pod has over threshold.
 	// If not set, deletion of pvc will be no.
+	// Ignored if remediation is set.
 	// +optional
 	RegeneratePVC *RegeneratePVCSpec `json:"regeneratePVC"`
+
+	// Remediation steps tried in order for each lagging pod, escalating to the next step once a step's attempts are
+	// exhausted and the pod still lags. A pod's progress resets once it is in-sync and no longer lagging.
+	// Once all steps are exhausted, the last step repeats.
+	// Example: restart twice, then refresh peers, then restore from the latest VolumeSnapshot, then state sync, then
+	// regenerate the PVC.
+	// If not set, lagging pods are restarted.
+	// +optional
+	Remediation []DriftRemediationStep `json:"remediation"`
+
+	// If true, only records the actions this feature would take in status.selfHealing.dryRun and as events.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
+}
+
+// DriftRemediationAction is how a lagging pod is remediated.
+type DriftRemediationAction string
+
+const (
+	// DriftRemediationRestart deletes the pod so it is recreated.
+	DriftRemediationRestart DriftRemediationAction = "Restart"
+
+	// DriftRemediationRefreshPeers removes the pod's address book before restarting it, so the node discovers peers
+	// from the downloaded address book, seeds, and persistent peers.
+	DriftRemediationRefreshPeers DriftRemediationAction = "RefreshPeers"
+
+	// DriftRemediationRestoreSnapshot regenerates the PVC from the latest VolumeSnapshot.
+	// Requires spec.volumeClaimTemplate.autoDataSource. Skipped otherwise.
+	DriftRemediationRestoreSnapshot DriftRemediationAction = "RestoreSnapshot"
+
+	// DriftRemediationStateSync deletes the chain data, keeping the validator's last signed state, before restarting
+	// the pod. The node restores from the snapshotURL if set, otherwise it must be configured to state sync.
+	DriftRemediationStateSync DriftRemediationAction = "StateSync"
+
+	// DriftRemediationRegeneratePVC deletes the PVC so it is recreated from spec.volumeClaimTemplate.
+	DriftRemediationRegeneratePVC DriftRemediationAction = "RegeneratePVC"
+)
+
+type DriftRemediationStep struct {
+	// The remediation to apply.
+	// +kubebuilder:validation:Enum:=Restart;RefreshPeers;RestoreSnapshot;StateSync;RegeneratePVC
+	Action DriftRemediationAction `json:"action"`
+
+	// How many times to apply the action before escalating to the next step.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Attempts int32 `json:"attempts"`
+
+	// How long to wait after applying the action before the pod is remediated again.
+	// Gives the pod time to catch up.
+	// Defaults to 5m.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff"`
+}
+
+type CrashLoopHealingSpec struct {
+	// Minimum restarts of the node container before a crash loop is classified.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	RestartThreshold int32 `json:"restartThreshold"`
+
+	// The remedy applied once a crash loop is classified.
+	// "RestoreSnapshot" regenerates the PVC from the latest VolumeSnapshot, rolling the chain data back.
+	// Requires spec.volumeClaimTemplate.autoDataSource. Falls back to "AlertOnly" otherwise.
+	// "DisableInstance" deletes the pod and does not recreate it until the CosmosFullNode's spec changes, e.g. an
+	// image upgrade.
+	// "AlertOnly" records the failure in status and as an event.
+	// Defaults to "AlertOnly".
+	// +kubebuilder:validation:Enum:=RestoreSnapshot;DisableInstance;AlertOnly
+	// +optional
+	Remedy CrashLoopRemedy `json:"remedy"`
+
+	// Additional regular expressions matched against the node container's last termination message.
+	// Matches are classified as "Custom".
+	// The built-in patterns detect a wrong AppHash and a consensus failure.
+	// +optional
+	Patterns []string `json:"patterns"`
+
+	// If true, only records the actions this feature would take in status.selfHealing.dryRun and as events.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
+}
+
+type InitContainerWatchdogSpec struct {
+	// How long an init container may run before it is considered stuck.
+	// Defaults to 15m.
+	// +optional
+	Deadline *metav1.Duration `json:"deadline"`
+
+	// How long the snapshot-restore init container may run before it is considered stuck.
+	// Downloading and extracting a snapshot may take hours.
+	// Defaults to 12h.
+	// +optional
+	SnapshotRestoreDeadline *metav1.Duration `json:"snapshotRestoreDeadline"`
+
+	// Restarts of an init container before it is considered stuck.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxFailures int32 `json:"maxFailures"`
+
+	// If true, only records the actions this feature would take in status.selfHealing.dryRun and as events.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
 }
 
+type PeerHealthSpec struct {
+	// A pod is starved if connected to fewer peers than this.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MinPeers int32 `json:"minPeers"`
+
+	// How long a pod must be starved before it is remediated.
+	// Defaults to 5m.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod"`
+
+	// How long to wait after a remediation before escalating to the next one.
+	// Defaults to 10m.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff"`
+
+	// Maximum number of peers of healthy pods added as persistent peers to a starved pod.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxInjectedPeers int32 `json:"maxInjectedPeers"`
+
+	// If true, only records the actions this feature would take in status.selfHealing.dryRun and as events.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
+}
+
+// PeerHealthStage is a remediation of a pod starved of peers.
+type PeerHealthStage string
+
+const (
+	// PeerHealthRefreshAddrbook removes the pod's address book before restarting it.
+	PeerHealthRefreshAddrbook PeerHealthStage = "RefreshAddrbook"
+	// PeerHealthInjectPeers adds peers connected to healthy pods as persistent peers of the pod.
+	PeerHealthInjectPeers PeerHealthStage = "InjectPeers"
+	// PeerHealthRestart deletes the pod so it is recreated.
+	PeerHealthRestart PeerHealthStage = "Restart"
+)
+
+// InitContainerStuckReason is why an init container is considered stuck.
+type InitContainerStuckReason string
+
+const (
+	InitContainerDeadlineExceeded InitContainerStuckReason = "DeadlineExceeded"
+	InitContainerRepeatedFailure  InitContainerStuckReason = "RepeatedFailure"
+)
+
+// CrashLoopRemedy is how a pod crash looping from a known fatal error is healed.
+type CrashLoopRemedy string
+
+const (
+	CrashLoopRemedyRestoreSnapshot CrashLoopRemedy = "RestoreSnapshot"
+	CrashLoopRemedyDisableInstance CrashLoopRemedy = "DisableInstance"
+	CrashLoopRemedyAlertOnly       CrashLoopRemedy = "AlertOnly"
+)
+
+// CrashLoopReason classifies the fatal error of a crash looping pod.
+type CrashLoopReason string
+
+const (
+	CrashLoopReasonAppHashMismatch  CrashLoopReason = "AppHashMismatch"
+	CrashLoopReasonConsensusFailure CrashLoopReason = "ConsensusFailure"
+	CrashLoopReasonCustom           CrashLoopReason = "Custom"
+)
+
 type RegeneratePVCSpec struct {
 
 	// FailedCountCollectionDuration specifies how long self-healing controller will sum failure counts.
@@ -108,9 +319,31 @@ type PruningSpec struct {
 
 	// The percentage of used disk space required to trigger pruning.
 	// Example, if set to 80, autoscaling will not trigger until used space reaches >=80% of capacity.
+	// Pruning also triggers once the percentage of used inodes reaches this value.
+	// If not set, pruning is only triggered by schedule.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:MaxSize=100
-	UsedSpacePercentage int32 `json:"usedSpacePercentage"`
+	// +optional
+	UsedSpacePercentage int32 `json:"usedSpacePercentage,omitempty"`
+
+	// A crontab schedule using the standard as described in https://en.wikipedia.org/wiki/Cron.
+	// An instance is due for pruning once the schedule fires after the instance's last prune time, regardless of
+	// disk usage. Instances never pruned are measured from the CosmosFullNode's creation.
+	// Due instances are pruned least recently pruned first, up to maxConcurrent at a time while at least minAvailable
+	// pods remain in sync.
+	// Example: "0 3 * * 0" prunes every instance once a week, starting Sunday at 03:00.
+	// +optional
+	Schedule string `json:"schedule"`
+
+	// If set, pruning only starts within one of these windows, whether triggered by disk usage or schedule.
+	// Pruning that started within a window is allowed to finish after the window closes.
+	// +optional
+	MaintenanceWindows []PruningWindow `json:"maintenanceWindows"`
+
+	// Minimum duration between prunes of the same instance, measured from the instance's last prune time.
+	// Prevents an instance whose disk remains above usedSpacePercentage from being pruned repeatedly.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval"`
 
 	// Minimum number of CosmosFullNode pods that must be ready before pruning pod.
 	// Defaults to 2.
@@ -119,9 +352,48 @@ type PruningSpec struct {
 	// +kubebuilder:validation:Minimum:=1
 	MinAvailable int32 `json:"minAvailable"`
 
+	// Maximum number of pods pruning at the same time.
+	// Candidates are further limited so at least minAvailable pods remain in sync.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	MaxConcurrent int32 `json:"maxConcurrent"`
+
+	// If true, restores a pod whose pruning failed.
+	// Otherwise, the failed pruner pod is kept for inspection and the pod is not restored until this is set to true.
+	// Failed pods count toward maxConcurrent.
+	// +optional
+	RestoreOnFailure bool `json:"restoreOnFailure"`
+
+	// How to reclaim disk space. The image, arguments, and data paths are chosen from the chain's
+	// chainType and databaseBackend.
+	// "Compact" compacts the databases without deleting data.
+	// "PruneAppState" deletes application state older than keepRecent versions, then compacts.
+	// Not supported for chainType "namada".
+	// "PruneBlocks" deletes blocks and transaction indexes older than keepBlocks, then compacts.
+	// For chainType "namada", "Compact" and "PruneBlocks" only reclaim space from the CometBFT stores; the ledger's
+	// RocksDB state is left as is.
+	// "Resync" deletes all chain data so the pod restores from its snapshotURL or state syncs on restart.
+	// Ignored if pruningCommand is set.
+	// Defaults to "Compact".
+	// +kubebuilder:validation:Enum:=Compact;PruneAppState;PruneBlocks;Resync
+	// +optional
+	Strategy PrunerStrategy `json:"strategy"`
+
+	// Number of recent application state versions to keep for the "PruneAppState" strategy.
+	// Defaults to 100.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepRecent *int64 `json:"keepRecent"`
+
+	// Number of recent blocks to keep for the "PruneBlocks" strategy.
+	// Defaults to 100000.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	KeepBlocks *int64 `json:"keepBlocks"`
+
 	// The image url of you'll use for pruning.
-	// If not set, defaults to "ghcr.io/bharvest-devops/cosmos-pruner:latest"
-	// command
+	// If not set, defaults to the image for the strategy.
 	// +optional
 	Image string `json:"image"`
 
@@ -132,15 +404,36 @@ type PruningSpec struct {
 	//    - "-c"
 	//    - "cosmos-pruner prune /home/operator/cosmos/data/ -b=0 ....
 	//
-	// If not set, it'll be set as default options.
-	//   -b=0 # every blocks in tendermint data (also tx_index if you set true --tx_index) will be pruned.
-	//   -v=0 # every versions in cosmos app will be pruned.
-	//   --tx_index=true # prune also tx_index
-	//   --compact=true # compact golevelDB after pruning
-	//   --cosmos-sdk=true # pruning also cosmos app data
-	//
+	// Overrides strategy. If not set, the command is built from strategy.
 	// +optional
 	PruningCommand string `json:"pruningCommand"`
+
+	// If true, only records the instances which would be pruned in status.selfHealing.dryRun and as events.
+	// Pruning already in progress completes.
+	// Defaults to spec.selfHeal.dryRun.
+	// +optional
+	DryRun *bool `json:"dryRun"`
+}
+
+// PrunerStrategy is how a pruner pod reclaims disk space.
+type PrunerStrategy string
+
+const (
+	PrunerStrategyCompact       PrunerStrategy = "Compact"
+	PrunerStrategyPruneAppState PrunerStrategy = "PruneAppState"
+	PrunerStrategyPruneBlocks   PrunerStrategy = "PruneBlocks"
+	PrunerStrategyResync        PrunerStrategy = "Resync"
+)
+
+// PruningWindow is a recurring period during which pruning may start.
+type PruningWindow struct {
+	// A crontab schedule for when the window opens.
+	// Example: "0 2 * * 1-5" opens the window at 02:00 on weekdays.
+	// Use the "CRON_TZ=" prefix to specify a time zone, e.g. "CRON_TZ=Asia/Seoul 0 2 * * *". Defaults to UTC.
+	Start string `json:"start"`
+
+	// How long the window stays open.
+	Duration metav1.Duration `json:"duration"`
 }
 
 type DBBackend string
@@ -148,19 +441,29 @@ type DBBackend string
 // CosmosPruningStatus shows status of process for pruning.
 type CosmosPruningStatus struct {
 
-	// Candidates describes what pod is currently pruning.
+	// Candidates describes what pods are currently pruning and the phase of each.
 	// +mapType:=granular
 	// +optional
-	Candidates map[string]SelfHealingCandidate `json:"candidate"`
+	Candidates map[string]PruningCandidate `json:"candidate"`
 
 	// +mapType:=granular
 	// +optional
 	PodPruningStatus map[string]PodPruningStatus `json:"podPruningStatus"`
 
 	// The phase of the pruning.
+	// FindingCandidate if no pods are pruning, otherwise the phase of the least advanced candidate.
 	CosmosPruningPhase CosmosPruningPhase `json:"cosmosPruningPhase"`
 }
 
+// PruningCandidate is a pod being pruned.
+type PruningCandidate struct {
+	SelfHealingCandidate `json:",inline"`
+
+	// The phase of pruning for this pod.
+	// +optional
+	Phase CosmosPruningPhase `json:"phase"`
+}
+
 type SelfHealingCandidate struct {
 	PodName   string `json:"podName"`
 	Namespace string `json:"namespace"`
@@ -170,6 +473,35 @@ type PodPruningStatus struct {
 	// LastPruned shows when does pod pruned.
 	// +optional
 	LastPruneTime *metav1.Time `json:"lastPruned"`
+
+	// When the pruner container finished.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt"`
+
+	// How long the pruner container ran.
+	// +optional
+	Duration *metav1.Duration `json:"duration"`
+
+	// The exit code of the pruner container. Zero indicates success.
+	// +optional
+	ExitCode *int32 `json:"exitCode"`
+
+	// The last lines of the pruner container's output.
+	// +optional
+	Logs string `json:"logs"`
+
+	// Bytes used on the PVC before pruning.
+	// +optional
+	UsedBytesBefore *int64 `json:"usedBytesBefore"`
+
+	// Bytes used on the PVC once the pod is restored after pruning.
+	// +optional
+	UsedBytesAfter *int64 `json:"usedBytesAfter"`
+
+	// Bytes reclaimed by pruning.
+	// May be negative if the chain grew more than pruning reclaimed.
+	// +optional
+	ReclaimedBytes *int64 `json:"reclaimedBytes"`
 }
 
 type CosmosPruningPhase string
@@ -184,6 +516,10 @@ const (
 	// CosmosPruningPhaseWaitingForComplete indicates controller is waiting for complete pruning.
 	CosmosPruningPhaseWaitingForComplete CosmosPruningPhase = "WaitingForComplete"
 
+	// CosmosPruningPhasePruningFailed indicates the pruner container exited with a non-zero exit code.
+	// The pod is not restored unless restoreOnFailure is set.
+	CosmosPruningPhasePruningFailed CosmosPruningPhase = "PruningFailed"
+
 	// CosmosPruningPhaseRestorePod signals the fullNodeRef it can recreate the temporarily deleted pod.
 	CosmosPruningPhaseRestorePod CosmosPruningPhase = "RestoringPod"
 
@@ -204,6 +540,143 @@ type SelfHealingStatus struct {
 	// CosmosPruning status.
 	// +optional
 	CosmosPruningStatus *CosmosPruningStatus `json:"cosmosPruningStatus"`
+
+	// Height drift remediation progress keyed by pod name.
+	// +mapType:=granular
+	// +optional
+	DriftRemediation map[string]*DriftRemediationStatus `json:"driftRemediation"`
+
+	// Classified crash loops keyed by pod name.
+	// +mapType:=granular
+	// +optional
+	CrashLoop map[string]*CrashLoopStatus `json:"crashLoop"`
+
+	// Stuck init containers keyed by pod name.
+	// +mapType:=granular
+	// +optional
+	InitContainers map[string]*InitContainerWatchdogStatus `json:"initContainers"`
+
+	// Peer health keyed by pod name.
+	// +mapType:=granular
+	// +optional
+	PeerHealth map[string]*PeerHealthStatus `json:"peerHealth"`
+
+	// Disk usage keyed by PVC name.
+	// +mapType:=granular
+	// +optional
+	DiskUsage map[string]*DiskUsageStatus `json:"diskUsage"`
+
+	// Actions self-healing and pruning would have taken if not in dry-run mode, oldest first.
+	// Only the most recent actions are kept.
+	// +optional
+	DryRun []DryRunAction `json:"dryRun"`
+}
+
+// DryRunAction is an action a self-healing feature would have taken if not in dry-run mode.
+type DryRunAction struct {
+	// The feature planning the action, e.g. HeightDriftMitigation.
+	Feature string `json:"feature"`
+
+	// The pod or PVC the action applies to.
+	Target string `json:"target"`
+
+	// The action the feature would take, e.g. DeletePod.
+	Action string `json:"action"`
+
+	// Details of the action.
+	// +optional
+	Message string `json:"message"`
+
+	// When the action was last planned.
+	PlannedAt metav1.Time `json:"plannedAt"`
+}
+
+type CrashLoopStatus struct {
+	// The classification of the fatal error.
+	Reason CrashLoopReason `json:"reason"`
+
+	// The line of the node container's termination message matching the fatal error.
+	Message string `json:"message"`
+
+	// The node container's restart count when the crash loop was classified.
+	RestartCount int32 `json:"restartCount"`
+
+	// When the crash loop was classified.
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// The remedy applied.
+	Remedy CrashLoopRemedy `json:"remedy"`
+
+	// The CosmosFullNode's generation when the crash loop was classified.
+	// The entry is removed once the generation changes.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+type PeerHealthStatus struct {
+	// The number of peers the pod was last connected to.
+	Peers int32 `json:"peers"`
+
+	// When the pod became starved of peers. Not set if the pod has enough peers.
+	// +optional
+	StarvedSince *metav1.Time `json:"starvedSince"`
+
+	// The last remediation applied while the pod was starved.
+	// +optional
+	Stage PeerHealthStage `json:"stage"`
+
+	// When the last remediation was applied.
+	// +optional
+	LastRemediationTime *metav1.Time `json:"lastRemediationTime"`
+
+	// True once the pod has removed its address book for the "RefreshAddrbook" stage.
+	// +optional
+	Applied bool `json:"applied"`
+
+	// Peers added to the pod's persistent peers.
+	// Kept once the pod recovers so the pod is not restarted with a different config.
+	// +optional
+	InjectedPeers []string `json:"injectedPeers"`
+}
+
+type InitContainerWatchdogStatus struct {
+	// The init container last found stuck.
+	Container string `json:"container"`
+
+	// Why the init container was considered stuck.
+	Reason InitContainerStuckReason `json:"reason"`
+
+	// When the init container was last found stuck.
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// How many times the pod was restarted because an init container was stuck.
+	// Reset once the pod's init containers complete.
+	Retries int32 `json:"retries"`
+
+	// Index of the URL a download init container tries first, keyed by container name.
+	// URLs are the primary URL followed by the chain's fallback URLs.
+	// Kept once the download succeeds so the pod is not recreated with a different URL.
+	// +mapType:=granular
+	// +optional
+	URLOffsets map[string]int32 `json:"urlOffsets"`
+}
+
+type DriftRemediationStatus struct {
+	// Index of the current step in spec.selfHeal.heightDriftMitigation.remediation.
+	Step int32 `json:"step"`
+
+	// The action of the current step.
+	Action DriftRemediationAction `json:"action"`
+
+	// How many times the current step's action was applied.
+	Attempts int32 `json:"attempts"`
+
+	// When the action was last applied.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime"`
+
+	// True once the pod has applied the action's changes to its chain data.
+	// Only used by actions which modify chain data when the pod restarts.
+	// +optional
+	Applied bool `json:"applied"`
 }
 
 type RegenPVCStatus struct {
@@ -235,3 +708,37 @@ type PVCAutoScaleStatus struct {
 	// The timestamp the SelfHealing controller requested a PVC increase.
 	RequestedAt metav1.Time `json:"requestedAt"`
 }
+
+// DiskUsageStatus is the disk usage of a PVC as reported by the healthcheck sidecar.
+type DiskUsageStatus struct {
+	// The pod using the PVC.
+	PodName string `json:"podName"`
+
+	// Percentage of disk space used.
+	PercentUsed int32 `json:"percentUsed"`
+
+	// Percentage of inodes used. Absent if the filesystem does not report inodes.
+	// +optional
+	PercentInodesUsed *int32 `json:"percentInodesUsed,omitempty"`
+
+	// Sizes of the directories in the chain home directory, such as data/application.db.
+	// Absent until the healthcheck sidecar has computed them.
+	// +optional
+	Directories []DirectoryUsage `json:"directories,omitempty"`
+
+	// When the healthcheck sidecar last computed the directory sizes.
+	// +optional
+	DirectoriesUpdatedAt *metav1.Time `json:"directoriesUpdatedAt,omitempty"`
+}
+
+// DirectoryUsage is the size of a directory.
+type DirectoryUsage struct {
+	// Path relative to the chain home directory.
+	Path string `json:"path"`
+
+	// Total size of the files in the directory.
+	Size resource.Quantity `json:"size"`
+
+	// Number of files and directories in the directory.
+	Inodes int64 `json:"inodes"`
+}
@@ -37,6 +37,11 @@ func (in *AutoDataSource) DeepCopyInto(out *AutoDataSource) {
 			(*out)[key] = val
 		}
 	}
+	if in.MinHeight != nil {
+		in, out := &in.MinHeight, &out.MinHeight
+		*out = new(uint64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoDataSource.
@@ -87,6 +92,11 @@ func (in *ChainSpec) DeepCopyInto(out *ChainSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AddrbookFallbackURLs != nil {
+		in, out := &in.AddrbookFallbackURLs, &out.AddrbookFallbackURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.GenesisURL != nil {
 		in, out := &in.GenesisURL, &out.GenesisURL
 		*out = new(string)
@@ -97,6 +107,21 @@ func (in *ChainSpec) DeepCopyInto(out *ChainSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GenesisFallbackURLs != nil {
+		in, out := &in.GenesisFallbackURLs, &out.GenesisFallbackURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GenesisSHA256 != nil {
+		in, out := &in.GenesisSHA256, &out.GenesisSHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.SnapshotFallbackURLs != nil {
+		in, out := &in.SnapshotFallbackURLs, &out.SnapshotFallbackURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PrivvalSleepSeconds != nil {
 		in, out := &in.PrivvalSleepSeconds, &out.PrivvalSleepSeconds
 		*out = new(int32)
@@ -122,6 +147,11 @@ func (in *ChainSpec) DeepCopyInto(out *ChainSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Reference != nil {
+		in, out := &in.Reference, &out.Reference
+		*out = new(ReferenceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainSpec.
@@ -308,7 +338,7 @@ func (in *CosmosPruningStatus) DeepCopyInto(out *CosmosPruningStatus) {
 	*out = *in
 	if in.Candidates != nil {
 		in, out := &in.Candidates, &out.Candidates
-		*out = make(map[string]SelfHealingCandidate, len(*in))
+		*out = make(map[string]PruningCandidate, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
@@ -332,9 +362,159 @@ func (in *CosmosPruningStatus) DeepCopy() *CosmosPruningStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopHealingSpec) DeepCopyInto(out *CrashLoopHealingSpec) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopHealingSpec.
+func (in *CrashLoopHealingSpec) DeepCopy() *CrashLoopHealingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopHealingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopStatus) DeepCopyInto(out *CrashLoopStatus) {
+	*out = *in
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopStatus.
+func (in *CrashLoopStatus) DeepCopy() *CrashLoopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectoryUsage) DeepCopyInto(out *DirectoryUsage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectoryUsage.
+func (in *DirectoryUsage) DeepCopy() *DirectoryUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectoryUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsageStatus) DeepCopyInto(out *DiskUsageStatus) {
+	*out = *in
+	if in.PercentInodesUsed != nil {
+		in, out := &in.PercentInodesUsed, &out.PercentInodesUsed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]DirectoryUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DirectoriesUpdatedAt != nil {
+		in, out := &in.DirectoriesUpdatedAt, &out.DirectoriesUpdatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsageStatus.
+func (in *DiskUsageStatus) DeepCopy() *DiskUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediationStatus) DeepCopyInto(out *DriftRemediationStatus) {
+	*out = *in
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediationStatus.
+func (in *DriftRemediationStatus) DeepCopy() *DriftRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRemediationStep) DeepCopyInto(out *DriftRemediationStep) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRemediationStep.
+func (in *DriftRemediationStep) DeepCopy() *DriftRemediationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRemediationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunAction) DeepCopyInto(out *DryRunAction) {
+	*out = *in
+	in.PlannedAt.DeepCopyInto(&out.PlannedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunAction.
+func (in *DryRunAction) DeepCopy() *DryRunAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FullNodeProbesSpec) DeepCopyInto(out *FullNodeProbesSpec) {
 	*out = *in
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ReadinessCriteria)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIs != nil {
+		in, out := &in.APIs, &out.APIs
+		*out = make([]FullNodeProbeAPI, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeProbesSpec.
@@ -443,6 +623,27 @@ func (in *FullNodeStatus) DeepCopyInto(out *FullNodeStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = make(map[string]*UpgradeStatus, len(*in))
+		for key, val := range *in {
+			var outVal *UpgradeStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(UpgradeStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ReferenceHeight != nil {
+		in, out := &in.ReferenceHeight, &out.ReferenceHeight
+		*out = new(uint64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeStatus.
@@ -464,6 +665,18 @@ func (in *HeightDriftMitigationSpec) DeepCopyInto(out *HeightDriftMitigationSpec
 		*out = new(RegeneratePVCSpec)
 		**out = **in
 	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = make([]DriftRemediationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeightDriftMitigationSpec.
@@ -476,6 +689,59 @@ func (in *HeightDriftMitigationSpec) DeepCopy() *HeightDriftMitigationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitContainerWatchdogSpec) DeepCopyInto(out *InitContainerWatchdogSpec) {
+	*out = *in
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SnapshotRestoreDeadline != nil {
+		in, out := &in.SnapshotRestoreDeadline, &out.SnapshotRestoreDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerWatchdogSpec.
+func (in *InitContainerWatchdogSpec) DeepCopy() *InitContainerWatchdogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainerWatchdogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitContainerWatchdogStatus) DeepCopyInto(out *InitContainerWatchdogStatus) {
+	*out = *in
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+	if in.URLOffsets != nil {
+		in, out := &in.URLOffsets, &out.URLOffsets
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerWatchdogStatus.
+func (in *InitContainerWatchdogStatus) DeepCopy() *InitContainerWatchdogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainerWatchdogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceOverridesSpec) DeepCopyInto(out *InstanceOverridesSpec) {
 	*out = *in
@@ -573,6 +839,16 @@ func (in *NamadaConfig) DeepCopyInto(out *NamadaConfig) {
 		*out = new(NamadaLedger)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SnapshotURL != nil {
+		in, out := &in.SnapshotURL, &out.SnapshotURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.SnapshotScript != nil {
+		in, out := &in.SnapshotScript, &out.SnapshotScript
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamadaConfig.
@@ -640,6 +916,41 @@ func (in *NamadaLedger) DeepCopy() *NamadaLedger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamadaPodStatus) DeepCopyInto(out *NamadaPodStatus) {
+	*out = *in
+	if in.ShellHeight != nil {
+		in, out := &in.ShellHeight, &out.ShellHeight
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.ShellError != nil {
+		in, out := &in.ShellError, &out.ShellError
+		*out = new(string)
+		**out = **in
+	}
+	if in.OracleError != nil {
+		in, out := &in.OracleError, &out.OracleError
+		*out = new(string)
+		**out = **in
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamadaPodStatus.
+func (in *NamadaPodStatus) DeepCopy() *NamadaPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamadaPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamadaShell) DeepCopyInto(out *NamadaShell) {
 	*out = *in
@@ -675,6 +986,31 @@ func (in *NamadaShell) DeepCopy() *NamadaShell {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInfoPodStatus) DeepCopyInto(out *NodeInfoPodStatus) {
+	*out = *in
+	if in.VotingPower != nil {
+		in, out := &in.VotingPower, &out.VotingPower
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Mismatches != nil {
+		in, out := &in.Mismatches, &out.Mismatches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfoPodStatus.
+func (in *NodeInfoPodStatus) DeepCopy() *NodeInfoPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInfoPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *P2P) DeepCopyInto(out *P2P) {
 	*out = *in
@@ -775,6 +1111,11 @@ func (in *P2PServiceSpec) DeepCopy() *P2PServiceSpec {
 func (in *PVCAutoScaleSpec) DeepCopyInto(out *PVCAutoScaleSpec) {
 	*out = *in
 	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCAutoScaleSpec.
@@ -804,6 +1145,64 @@ func (in *PVCAutoScaleStatus) DeepCopy() *PVCAutoScaleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerHealthSpec) DeepCopyInto(out *PeerHealthSpec) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerHealthSpec.
+func (in *PeerHealthSpec) DeepCopy() *PeerHealthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerHealthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerHealthStatus) DeepCopyInto(out *PeerHealthStatus) {
+	*out = *in
+	if in.StarvedSince != nil {
+		in, out := &in.StarvedSince, &out.StarvedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRemediationTime != nil {
+		in, out := &in.LastRemediationTime, &out.LastRemediationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.InjectedPeers != nil {
+		in, out := &in.InjectedPeers, &out.InjectedPeers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerHealthStatus.
+func (in *PeerHealthStatus) DeepCopy() *PeerHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaimSpec) DeepCopyInto(out *PersistentVolumeClaimSpec) {
 	*out = *in
@@ -848,6 +1247,35 @@ func (in *PodPruningStatus) DeepCopyInto(out *PodPruningStatus) {
 		in, out := &in.LastPruneTime, &out.LastPruneTime
 		*out = (*in).DeepCopy()
 	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UsedBytesBefore != nil {
+		in, out := &in.UsedBytesBefore, &out.UsedBytesBefore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UsedBytesAfter != nil {
+		in, out := &in.UsedBytesAfter, &out.UsedBytesAfter
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReclaimedBytes != nil {
+		in, out := &in.ReclaimedBytes, &out.ReclaimedBytes
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPruningStatus.
@@ -912,7 +1340,7 @@ func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 		*out = new(int64)
 		**out = **in
 	}
-	out.Probes = in.Probes
+	in.Probes.DeepCopyInto(&out.Probes)
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]corev1.Volume, len(*in))
@@ -981,9 +1409,50 @@ func (in *Pruning) DeepCopy() *Pruning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruningCandidate) DeepCopyInto(out *PruningCandidate) {
+	*out = *in
+	out.SelfHealingCandidate = in.SelfHealingCandidate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningCandidate.
+func (in *PruningCandidate) DeepCopy() *PruningCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(PruningCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PruningSpec) DeepCopyInto(out *PruningSpec) {
 	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]PruningWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeepRecent != nil {
+		in, out := &in.KeepRecent, &out.KeepRecent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KeepBlocks != nil {
+		in, out := &in.KeepBlocks, &out.KeepBlocks
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningSpec.
@@ -996,6 +1465,22 @@ func (in *PruningSpec) DeepCopy() *PruningSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruningWindow) DeepCopyInto(out *PruningWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruningWindow.
+func (in *PruningWindow) DeepCopy() *PruningWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PruningWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RPC) DeepCopyInto(out *RPC) {
 	*out = *in
@@ -1039,6 +1524,46 @@ func (in *RPC) DeepCopy() *RPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessCriteria) DeepCopyInto(out *ReadinessCriteria) {
+	*out = *in
+	if in.MaxBlockAge != nil {
+		in, out := &in.MaxBlockAge, &out.MaxBlockAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessCriteria.
+func (in *ReadinessCriteria) DeepCopy() *ReadinessCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceSpec) DeepCopyInto(out *ReferenceSpec) {
+	*out = *in
+	if in.RPCEndpoints != nil {
+		in, out := &in.RPCEndpoints, &out.RPCEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceSpec.
+func (in *ReferenceSpec) DeepCopy() *ReferenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegenPVCStatus) DeepCopyInto(out *RegenPVCStatus) {
 	*out = *in
@@ -1166,10 +1691,25 @@ func (in *SelfHealSpec) DeepCopyInto(out *SelfHealSpec) {
 		*out = new(HeightDriftMitigationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CrashLoopHealing != nil {
+		in, out := &in.CrashLoopHealing, &out.CrashLoopHealing
+		*out = new(CrashLoopHealingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainerWatchdog != nil {
+		in, out := &in.InitContainerWatchdog, &out.InitContainerWatchdog
+		*out = new(InitContainerWatchdogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeerHealth != nil {
+		in, out := &in.PeerHealth, &out.PeerHealth
+		*out = new(PeerHealthSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PruningSpec != nil {
 		in, out := &in.PruningSpec, &out.PruningSpec
 		*out = new(PruningSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1227,6 +1767,93 @@ func (in *SelfHealingStatus) DeepCopyInto(out *SelfHealingStatus) {
 		*out = new(CosmosPruningStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DriftRemediation != nil {
+		in, out := &in.DriftRemediation, &out.DriftRemediation
+		*out = make(map[string]*DriftRemediationStatus, len(*in))
+		for key, val := range *in {
+			var outVal *DriftRemediationStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(DriftRemediationStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.CrashLoop != nil {
+		in, out := &in.CrashLoop, &out.CrashLoop
+		*out = make(map[string]*CrashLoopStatus, len(*in))
+		for key, val := range *in {
+			var outVal *CrashLoopStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(CrashLoopStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make(map[string]*InitContainerWatchdogStatus, len(*in))
+		for key, val := range *in {
+			var outVal *InitContainerWatchdogStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(InitContainerWatchdogStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PeerHealth != nil {
+		in, out := &in.PeerHealth, &out.PeerHealth
+		*out = make(map[string]*PeerHealthStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PeerHealthStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(PeerHealthStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = make(map[string]*DiskUsageStatus, len(*in))
+		for key, val := range *in {
+			var outVal *DiskUsageStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(DiskUsageStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]DryRunAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHealingStatus.
@@ -1390,6 +2017,21 @@ func (in *SyncInfoPodStatus) DeepCopyInto(out *SyncInfoPodStatus) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.EarliestHeight != nil {
+		in, out := &in.EarliestHeight, &out.EarliestHeight
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(NodeInfoPodStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namada != nil {
+		in, out := &in.Namada, &out.Namada
+		*out = new(NamadaPodStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncInfoPodStatus.
@@ -1421,3 +2063,19 @@ func (in *TxIndex) DeepCopy() *TxIndex {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -80,17 +81,157 @@ type ScheduledVolumeSnapshotSpec struct {
 	MinAvailable int32 `json:"minAvailable"`
 
 	// The number of recent VolumeSnapshots to keep.
+	// Ignored if retention.hourly, retention.daily, or retention.weekly is set.
 	// Defaults to 3.
 	// +optional
 	// +kubebuilder:validation:Minimum:=1
 	Limit int32 `json:"limit"`
 
+	// Retention policy for VolumeSnapshots beyond a simple count.
+	// VolumeSnapshots in use as a PVC dataSource, such as by a StatefulJob or a CosmosFullNode restoring from
+	// a snapshot, are never deleted.
+	// +optional
+	Retention *SnapshotRetention `json:"retention"`
+
+	// Namespaces allowed to restore PVCs from the VolumeSnapshots. Use "*" to allow all namespaces.
+	// The list is recorded on each VolumeSnapshot as the cosmos.bharvest/allowed-namespaces annotation. A CosmosFullNode
+	// in another namespace may only choose the VolumeSnapshot via autoDataSource.namespace if its namespace is listed.
+	// Cross-namespace restores require the CrossNamespaceVolumeDataSource feature gate and a ReferenceGrant in this
+	// namespace permitting PersistentVolumeClaims from the consuming namespace to reference VolumeSnapshots.
+	// VolumeSnapshots in use by PVCs in allowed namespaces are never deleted.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces"`
+
 	// If true, the controller will not create any VolumeSnapshots.
 	// This allows you to disable creation of VolumeSnapshots without deleting the ScheduledVolumeSnapshot resource.
 	// This pattern works better when using tools such as Kustomzie.
 	// If a pod is temporarily deleted, it will be restored.
 	// +optional
 	Suspend bool `json:"suspend"`
+
+	// If set, exports each VolumeSnapshot to S3-compatible object storage as a compressed tarball once the
+	// VolumeSnapshot is ready.
+	// The controller restores the VolumeSnapshot into a temporary PVC and runs a Job which archives, compresses,
+	// and uploads the data. The temporary PVC and Job are deleted once the export finishes.
+	// The chain's config directory is excluded from the archive to prevent leaking private keys.
+	// +optional
+	Export *SnapshotExportSpec `json:"export"`
+
+	// If set, verifies each VolumeSnapshot once it is ready.
+	// The controller restores the VolumeSnapshot into a temporary PVC and runs a Job which opens CometBFT's
+	// state.db, or blockstore.db if missing, and confirms it reports a sane height.
+	// The VolumeSnapshot is then labeled cosmos.bharvest/verified=true or false. Use the label in a CosmosFullNode's
+	// autoDataSource.volumeSnapshotSelector to only restore from verified VolumeSnapshots.
+	// If verification fails, the VolumeSnapshot is not exported.
+	// +optional
+	Verify *SnapshotVerifySpec `json:"verify"`
+}
+
+// SnapshotVerifySpec configures verification of VolumeSnapshots.
+type SnapshotVerifySpec struct {
+	// The StorageClass to use when restoring the VolumeSnapshot into a temporary PVC.
+	// On GKE, the StorageClass must be the same as the PVC's StorageClass from which the
+	// VolumeSnapshot was created.
+	StorageClassName string `json:"storageClassName"`
+
+	// Specification of the desired behavior of the verification job.
+	// +optional
+	JobTemplate JobTemplateSpec `json:"jobTemplate"`
+
+	// Compute resources for the verification container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// SnapshotRetention is a grandfather-father-son style retention policy.
+// For each period, the most recent VolumeSnapshot within each of the N most recent periods is kept.
+// A VolumeSnapshot is kept if any period keeps it. Periods are calculated in UTC.
+type SnapshotRetention struct {
+	// Number of hourly VolumeSnapshots to keep.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Hourly int32 `json:"hourly"`
+
+	// Number of daily VolumeSnapshots to keep.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Daily int32 `json:"daily"`
+
+	// Number of weekly VolumeSnapshots to keep. Weeks start on Monday.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Weekly int32 `json:"weekly"`
+
+	// VolumeSnapshots older than this duration are deleted, even if otherwise kept by hourly, daily, weekly,
+	// or spec.limit.
+	// Expressed as a duration string, e.g. 72h, 720h.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge"`
+}
+
+// SnapshotExportSpec configures exporting VolumeSnapshots to object storage.
+type SnapshotExportSpec struct {
+	// The image used to archive, compress, and upload data.
+	// The image must include sh, tar, lz4 or zstd, sha256sum, wc, mkfifo, and rclone.
+	// See the compressor directory for a suitable image.
+	// If not set, defaults to "ghcr.io/bharvest-devops/compressor:latest".
+	// +optional
+	Image string `json:"image"`
+
+	// The compression format of the archive.
+	// Defaults to lz4.
+	// +kubebuilder:validation:Enum:=lz4;zstd
+	// +optional
+	Compression SnapshotCompression `json:"compression"`
+
+	// The StorageClass to use when restoring the VolumeSnapshot into a temporary PVC.
+	// On GKE, the StorageClass must be the same as the PVC's StorageClass from which the
+	// VolumeSnapshot was created.
+	StorageClassName string `json:"storageClassName"`
+
+	// The object storage destination.
+	Destination ObjectStorageDestination `json:"destination"`
+
+	// Specification of the desired behavior of the export job.
+	// +optional
+	JobTemplate JobTemplateSpec `json:"jobTemplate"`
+
+	// Compute resources for the export container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+type SnapshotCompression string
+
+const (
+	SnapshotCompressionLZ4  SnapshotCompression = "lz4"
+	SnapshotCompressionZstd SnapshotCompression = "zstd"
+)
+
+// ObjectStorageDestination is an S3-compatible bucket, such as AWS S3, GCS (interoperability mode), or MinIO.
+type ObjectStorageDestination struct {
+	// The S3-compatible endpoint. E.g. https://s3.us-east-1.amazonaws.com or http://minio.minio.svc:9000
+	Endpoint string `json:"endpoint"`
+
+	// The bucket name.
+	Bucket string `json:"bucket"`
+
+	// An optional key prefix (i.e. directory) within the bucket.
+	// +optional
+	Prefix string `json:"prefix"`
+
+	// The bucket's region. Some providers, such as MinIO, ignore this field.
+	// +optional
+	Region string `json:"region"`
+
+	// Reference to a Secret in the same namespace with keys AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// The base URL used when recording the archive's location in status.
+	// Useful when archives are served publicly from a CDN or a different hostname.
+	// If not set, defaults to <endpoint>/<bucket>.
+	// +optional
+	PublicURL string `json:"publicURL"`
 }
 
 type LocalFullNodeRef struct {
@@ -132,6 +273,72 @@ type ScheduledVolumeSnapshotStatus struct {
 	// The most recent volume snapshot created by the controller.
 	// +optional
 	LastSnapshot *VolumeSnapshotStatus `json:"lastSnapshot"`
+
+	// The most recent verification of a VolumeSnapshot.
+	// +optional
+	LastVerification *SnapshotVerificationStatus `json:"lastVerification"`
+
+	// The most recent export of a VolumeSnapshot to object storage.
+	// +optional
+	LastExport *SnapshotExportStatus `json:"lastExport"`
+}
+
+type SnapshotVerificationStatus struct {
+	// The name of the verified VolumeSnapshot.
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+
+	// The name of the verification Job.
+	JobName string `json:"jobName"`
+
+	// The time the controller created the verification Job.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// The time the verification finished, successfully or not.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt"`
+
+	// True if the chain data opened successfully and reported a sane height.
+	// +optional
+	Verified bool `json:"verified"`
+
+	// The height reported by CometBFT's state.db or blockstore.db.
+	// +optional
+	Height uint64 `json:"height"`
+}
+
+type SnapshotExportStatus struct {
+	// The name of the exported VolumeSnapshot.
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+
+	// The name of the export Job.
+	JobName string `json:"jobName"`
+
+	// The time the controller created the export Job.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// The time the export finished, successfully or not.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt"`
+
+	// True if the archive was uploaded successfully.
+	// +optional
+	Succeeded bool `json:"succeeded"`
+
+	// The location of the uploaded archive.
+	// +optional
+	URL string `json:"url"`
+
+	// The size of the uploaded archive in bytes.
+	// +optional
+	SizeBytes int64 `json:"sizeBytes"`
+
+	// The sha256 checksum of the uploaded archive.
+	// +optional
+	Checksum string `json:"checksum"`
+
+	// The block height recorded on the VolumeSnapshot, if any.
+	// +optional
+	Height uint64 `json:"height"`
 }
 
 type SnapshotCandidate struct {
@@ -140,6 +347,23 @@ type SnapshotCandidate struct {
 
 	// +optional
 	PodLabels map[string]string `json:"podLabels"`
+
+	// Block height of the pod when it was chosen as the candidate.
+	// Because the pod may keep syncing until the snapshot is taken, this is a lower bound of the snapshot's height.
+	// +optional
+	Height uint64 `json:"height"`
+
+	// Image of the pod's chain container.
+	// +optional
+	Image string `json:"image"`
+
+	// The application's protocol version reported by the pod's RPC /status endpoint.
+	// +optional
+	AppVersion string `json:"appVersion"`
+
+	// Database backend of the chain. E.g. goleveldb, rocksdb, pebbledb.
+	// +optional
+	DatabaseBackend string `json:"databaseBackend"`
 }
 
 type SnapshotPhase string
@@ -169,6 +393,20 @@ const (
 	// SnapshotPhaseRestorePod signals the fullNodeRef it can recreate the temporarily deleted pod.
 	SnapshotPhaseRestorePod SnapshotPhase = "RestoringPod"
 
+	// SnapshotPhaseCreatingVerification restores the VolumeSnapshot into a temporary PVC and creates a Job to verify
+	// the chain data.
+	SnapshotPhaseCreatingVerification SnapshotPhase = "CreatingVerification"
+
+	// SnapshotPhaseWaitingForVerification indicates the controller is waiting for the verification Job to finish.
+	SnapshotPhaseWaitingForVerification SnapshotPhase = "WaitingForVerification"
+
+	// SnapshotPhaseCreatingExport restores the VolumeSnapshot into a temporary PVC and creates a Job to export it
+	// to object storage.
+	SnapshotPhaseCreatingExport SnapshotPhase = "CreatingExport"
+
+	// SnapshotPhaseWaitingForExport indicates the controller is waiting for the export Job to finish.
+	SnapshotPhaseWaitingForExport SnapshotPhase = "WaitingForExport"
+
 	// SnapshotPhaseSuspended means the controller is not creating snapshots. Suspended by the user.
 	SnapshotPhaseSuspended SnapshotPhase = "Suspended"
 
@@ -40,6 +40,18 @@ type StatefulJobSpec struct {
 	// The selector to target VolumeSnapshots.
 	Selector map[string]string `json:"selector"`
 
+	// If set, only targets VolumeSnapshots recorded at or above this block height.
+	// The height is recorded by the ScheduledVolumeSnapshot controller. VolumeSnapshots without a recorded height
+	// are ignored.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	MinHeight *uint64 `json:"minHeight"`
+
+	// If set, only targets VolumeSnapshots taken from a pod running this image.
+	// The image is recorded by the ScheduledVolumeSnapshot controller.
+	// +optional
+	MatchImage string `json:"matchImage"`
+
 	// Interval at which the controller runs snapshot job with pvc.
 	// Expressed as a duration string, e.g. 1.5h, 24h, 12h.
 	// Defaults to 24h.
@@ -21,9 +21,10 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -77,6 +78,22 @@ func (in *LocalFullNodeRef) DeepCopy() *LocalFullNodeRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageDestination) DeepCopyInto(out *ObjectStorageDestination) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageDestination.
+func (in *ObjectStorageDestination) DeepCopy() *ObjectStorageDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledVolumeSnapshot) DeepCopyInto(out *ScheduledVolumeSnapshot) {
 	*out = *in
@@ -140,6 +157,26 @@ func (in *ScheduledVolumeSnapshotList) DeepCopyObject() runtime.Object {
 func (in *ScheduledVolumeSnapshotSpec) DeepCopyInto(out *ScheduledVolumeSnapshotSpec) {
 	*out = *in
 	in.FullNodeRef.DeepCopyInto(&out.FullNodeRef)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(SnapshotRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(SnapshotExportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(SnapshotVerifySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledVolumeSnapshotSpec.
@@ -171,6 +208,16 @@ func (in *ScheduledVolumeSnapshotStatus) DeepCopyInto(out *ScheduledVolumeSnapsh
 		*out = new(VolumeSnapshotStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastVerification != nil {
+		in, out := &in.LastVerification, &out.LastVerification
+		*out = new(SnapshotVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastExport != nil {
+		in, out := &in.LastExport, &out.LastExport
+		*out = new(SnapshotExportStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledVolumeSnapshotStatus.
@@ -205,6 +252,101 @@ func (in *SnapshotCandidate) DeepCopy() *SnapshotCandidate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotExportSpec) DeepCopyInto(out *SnapshotExportSpec) {
+	*out = *in
+	out.Destination = in.Destination
+	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotExportSpec.
+func (in *SnapshotExportSpec) DeepCopy() *SnapshotExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotExportStatus) DeepCopyInto(out *SnapshotExportStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotExportStatus.
+func (in *SnapshotExportStatus) DeepCopy() *SnapshotExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRetention) DeepCopyInto(out *SnapshotRetention) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRetention.
+func (in *SnapshotRetention) DeepCopy() *SnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotVerificationStatus) DeepCopyInto(out *SnapshotVerificationStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotVerificationStatus.
+func (in *SnapshotVerificationStatus) DeepCopy() *SnapshotVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotVerifySpec) DeepCopyInto(out *SnapshotVerifySpec) {
+	*out = *in
+	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotVerifySpec.
+func (in *SnapshotVerifySpec) DeepCopy() *SnapshotVerifySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotVerifySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulJob) DeepCopyInto(out *StatefulJob) {
 	*out = *in
@@ -274,6 +416,11 @@ func (in *StatefulJobSpec) DeepCopyInto(out *StatefulJobSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.MinHeight != nil {
+		in, out := &in.MinHeight, &out.MinHeight
+		*out = new(uint64)
+		**out = **in
+	}
 	out.Interval = in.Interval
 	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
@@ -343,7 +490,7 @@ func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	in.StartedAt.DeepCopyInto(&out.StartedAt)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(v1.VolumeSnapshotStatus)
+		*out = new(volumesnapshotv1.VolumeSnapshotStatus)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const chainTypeNamada = "namada"
+
+// DriftRemediateCmd applies a pending height drift or peer health remediation to this pod's chain data, then marks it
+// applied in the crd status so it is not applied again when the pod restarts.
+// This command is intended to be run as an init container before chain data is restored.
+func DriftRemediateCmd(scheme *runtime.Scheme) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "driftremediate",
+		Short: "Apply a pending height drift or peer health remediation to chain data",
+		Long:  `Remove the address book or chain data of this pod if the self-healing controller requested it to remediate height drift or peer starvation.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nsbz, err := os.ReadFile(namespaceFile)
+			if err != nil {
+				return fmt.Errorf("failed to read namespace from service account: %w", err)
+			}
+
+			config, err := rest.InClusterConfig()
+			if err != nil {
+				return fmt.Errorf("failed to get in cluster config: %w", err)
+			}
+			kClient, err := client.New(config, client.Options{Scheme: scheme})
+			if err != nil {
+				return fmt.Errorf("failed to create kube client: %w", err)
+			}
+
+			ctx := cmd.Context()
+			var thisPod corev1.Pod
+			if err = kClient.Get(ctx, types.NamespacedName{Namespace: string(nsbz), Name: os.Getenv("HOSTNAME")}, &thisPod); err != nil {
+				return fmt.Errorf("failed to get this pod: %w", err)
+			}
+
+			crd := new(cosmosv1.CosmosFullNode)
+			key := types.NamespacedName{Namespace: thisPod.Namespace, Name: thisPod.Labels["app.kubernetes.io/name"]}
+			if err = kClient.Get(ctx, key, crd); err != nil {
+				return fmt.Errorf("failed to get crd: %w", err)
+			}
+
+			var (
+				patch   = crd.DeepCopy()
+				applied bool
+			)
+			if action, ok := fullnode.DriftRemediationPending(crd, thisPod.Name); ok {
+				if err = applyDriftRemediation(action, cmd.OutOrStdout()); err != nil {
+					return err
+				}
+				patch.Status.SelfHealing.DriftRemediation[thisPod.Name].Applied = true
+				applied = true
+			}
+			if fullnode.PeerHealthPending(crd, thisPod.Name) {
+				if err = applyDriftRemediation(cosmosv1.DriftRemediationRefreshPeers, cmd.OutOrStdout()); err != nil {
+					return err
+				}
+				patch.Status.SelfHealing.PeerHealth[thisPod.Name].Applied = true
+				applied = true
+			}
+			if !applied {
+				fmt.Fprintln(cmd.OutOrStdout(), "No pending remediation")
+				return nil
+			}
+
+			if err = kClient.Status().Patch(ctx, patch, client.MergeFrom(crd)); err != nil {
+				return fmt.Errorf("failed to patch status: %w", err)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+func applyDriftRemediation(action cosmosv1.DriftRemediationAction, writer io.Writer) error {
+	switch action {
+	case cosmosv1.DriftRemediationRefreshPeers:
+		addrbook := os.Getenv("ADDRBOOK_FILE")
+		if err := os.Remove(addrbook); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove address book: %w", err)
+		}
+		fmt.Fprintf(writer, "Removed address book %s\n", addrbook)
+	case cosmosv1.DriftRemediationStateSync:
+		dataDir := os.Getenv("DATA_DIR")
+		entries, err := os.ReadDir(dataDir)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", dataDir, err)
+		}
+		for _, entry := range entries {
+			// Keep the validator's last signed state to prevent double signing.
+			if entry.Name() == "priv_validator_state.json" {
+				continue
+			}
+			if err = os.RemoveAll(filepath.Join(dataDir, entry.Name())); err != nil {
+				return fmt.Errorf("failed to remove chain data: %w", err)
+			}
+		}
+		if os.Getenv("CHAIN_TYPE") == chainTypeNamada {
+			// Namada's ledger state lives outside the CometBFT data directory.
+			if err = os.RemoveAll(filepath.Join(os.Getenv("CHAIN_HOME"), os.Getenv("CHAIN_ID"), "db")); err != nil {
+				return fmt.Errorf("failed to remove ledger data: %w", err)
+			}
+		}
+		fmt.Fprintf(writer, "Removed chain data in %s\n", dataDir)
+	}
+	return nil
+}
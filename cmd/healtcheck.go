@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
@@ -12,13 +14,18 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func HealthCheckCmd() *cobra.Command {
+func HealthCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 	hc := &cobra.Command{
-		Short:        "Start health check probe",
-		Use:          "healthcheck",
-		RunE:         startHealthCheckServer,
+		Short: "Start health check probe",
+		Use:   "healthcheck",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return startHealthCheckServer(cmd, scheme)
+		},
 		SilenceUsage: true,
 	}
 
@@ -26,6 +33,18 @@ func HealthCheckCmd() *cobra.Command {
 	hc.Flags().String("log-format", "console", "'console' or 'json'")
 	hc.Flags().Duration("timeout", 5*time.Second, "how long to wait before timing out requests to rpc-host")
 	hc.Flags().String("addr", fmt.Sprintf(":%d", healthcheck.Port), "listen address for server to bind")
+	hc.Flags().Duration("max-block-age", 0, "if set, unhealthy if the latest block is older than this")
+	hc.Flags().Int("min-peers", 0, "if set, unhealthy if connected to fewer peers than this")
+	hc.Flags().Uint64("max-height-lag", 0, "if set, unhealthy if the height lags the CosmosFullNode's status.referenceHeight by more than this")
+	hc.Flags().String("fullnode", "", "name of the CosmosFullNode in this pod's namespace; required if max-height-lag is set")
+	hc.Flags().String("grpc-addr", "localhost:9090", "Cosmos SDK gRPC address checked by /grpc")
+	hc.Flags().String("rest-host", "http://localhost:1317", "Cosmos SDK REST API endpoint checked by /rest")
+	hc.Flags().Bool("namada", false, "if set, also checks the Namada ledger shell")
+	hc.Flags().String("namada-oracle-rpc", "", "if set, also checks the Namada Ethereum bridge oracle can reach this Ethereum RPC endpoint; reported on /namada but does not affect readiness")
+	hc.Flags().String("disk-usage-dir", "", "chain home directory /disk reports directory sizes for; empty disables")
+	hc.Flags().Int("disk-usage-depth", 2, "how many levels of directories below the disk-usage-dir /disk reports sizes for; 0 disables")
+	hc.Flags().Duration("disk-usage-interval", 10*time.Minute, "how often /disk recomputes directory sizes in the background")
+	hc.Flags().StringSlice("require-apis", nil, "'grpc' and/or 'rest'; if set, unhealthy unless these APIs serve the latest block")
 
 	if err := viper.BindPFlags(hc.Flags()); err != nil {
 		panic(err)
@@ -34,11 +53,16 @@ func HealthCheckCmd() *cobra.Command {
 	return hc
 }
 
-func startHealthCheckServer(cmd *cobra.Command, args []string) error {
+func startHealthCheckServer(cmd *cobra.Command, scheme *runtime.Scheme) error {
 	var (
 		listenAddr = viper.GetString("addr")
 		rpcHost    = viper.GetString("rpc-host")
 		timeout    = viper.GetDuration("timeout")
+		criteria   = healthcheck.Criteria{
+			MaxBlockAge:  viper.GetDuration("max-block-age"),
+			MinPeers:     viper.GetInt("min-peers"),
+			MaxHeightLag: viper.GetUint64("max-height-lag"),
+		}
 
 		httpClient  = &http.Client{Timeout: 30 * time.Second}
 		cometClient = cosmos.NewCometClient(httpClient)
@@ -48,9 +72,53 @@ func startHealthCheckServer(cmd *cobra.Command, args []string) error {
 	)
 	defer func() { _ = zlog.Sync() }()
 
+	if criteria.MaxHeightLag > 0 {
+		reference, err := statusReference(scheme, viper.GetString("fullnode"))
+		if err != nil {
+			return err
+		}
+		criteria.Reference = reference
+	}
+
+	grpcAddr := viper.GetString("grpc-addr")
+	grpcClient, err := healthcheck.NewGRPC(grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create grpc client: %w", err)
+	}
+	defer func() { _ = grpcClient.Close() }()
+	restHost := viper.GetString("rest-host")
+	restClient := healthcheck.NewREST(httpClient, restHost)
+
+	apis := map[string]healthcheck.LatestBlocker{"grpc": grpcClient, "rest": restClient}
+	for _, name := range viper.GetStringSlice("require-apis") {
+		api, ok := apis[name]
+		if !ok {
+			return fmt.Errorf("unknown api %q in require-apis", name)
+		}
+		if criteria.APIs == nil {
+			criteria.APIs = make(map[string]healthcheck.LatestBlocker)
+		}
+		criteria.APIs[name] = api
+	}
+
+	var namada *healthcheck.Namada
+	if viper.GetBool("namada") {
+		namada = healthcheck.NewNamada(cometClient, httpClient, rpcHost, viper.GetString("namada-oracle-rpc"), timeout)
+		criteria.Namada = namada
+	}
+
 	mux := http.NewServeMux()
-	mux.Handle("/", healthcheck.NewComet(logger, cometClient, rpcHost, timeout))
-	mux.HandleFunc("/disk", healthcheck.DiskUsage)
+	mux.Handle("/", healthcheck.NewComet(logger, cometClient, rpcHost, timeout, criteria))
+	mux.Handle("/grpc", healthcheck.NewAPI(logger, grpcClient, grpcAddr, timeout))
+	mux.Handle("/rest", healthcheck.NewAPI(logger, restClient, restHost, timeout))
+	if dir, depth := viper.GetString("disk-usage-dir"), viper.GetInt("disk-usage-depth"); dir != "" && depth > 0 {
+		mux.Handle("/disk", healthcheck.NewDiskUsage(healthcheck.NewDirSizer(dir, depth, viper.GetDuration("disk-usage-interval"))))
+	} else {
+		mux.HandleFunc("/disk", healthcheck.DiskUsage)
+	}
+	if namada != nil {
+		mux.Handle("/namada", namada)
+	}
 
 	srv := &http.Server{
 		Addr:         listenAddr,
@@ -74,3 +142,24 @@ func startHealthCheckServer(cmd *cobra.Command, args []string) error {
 
 	return eg.Wait()
 }
+
+// statusReference reads the reference height from the status of the CosmosFullNode in this pod's namespace.
+func statusReference(scheme *runtime.Scheme, name string) (*healthcheck.StatusReference, error) {
+	if name == "" {
+		return nil, errors.New("fullnode is required if max-height-lag is set")
+	}
+	nsbz, err := os.ReadFile(namespaceFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read namespace from service account: %w", err)
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in cluster config: %w", err)
+	}
+	kClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kube client: %w", err)
+	}
+	// The reference height is updated about every 60s.
+	return healthcheck.NewStatusReference(kClient, client.ObjectKey{Namespace: string(nsbz), Name: name}, 15*time.Second), nil
+}
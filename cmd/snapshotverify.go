@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagMinHeight          = "min-height"
+	flagTerminationMessage = "termination-message"
+)
+
+// SnapshotVerifyCmd opens the CometBFT databases restored from a VolumeSnapshot and confirms they report a sane height.
+// The height is written as JSON to the termination message path so the ScheduledVolumeSnapshot controller can
+// record it.
+// This command is intended to be run as a Job against a temporary PVC.
+func SnapshotVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshotverify",
+		Short: "Verify chain data restored from a VolumeSnapshot",
+		Long:  `Open the CometBFT state or block store database, get the height, and fail if neither database can be opened or the height is lower than expected.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir := os.Getenv("DATA_DIR")
+			backend, _ := cmd.Flags().GetString(flagBackend)
+			minHeight, _ := cmd.Flags().GetUint64(flagMinHeight)
+			termPath, _ := cmd.Flags().GetString(flagTerminationMessage)
+
+			height, err := verifySnapshot(dataDir, backend, minHeight)
+			if err != nil {
+				return err
+			}
+
+			msg, err := json.Marshal(struct {
+				Height uint64 `json:"height"`
+			}{height})
+			if err != nil {
+				return err
+			}
+			if err = os.WriteFile(termPath, msg, 0644); err != nil {
+				return fmt.Errorf("write termination message: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Verified snapshot at height %d\n", height)
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP(flagBackend, "b", "goleveldb", "Database backend")
+	cmd.Flags().Uint64(flagMinHeight, 1, "Minimum expected height of the database")
+	cmd.Flags().String(flagTerminationMessage, "/dev/termination-log", "Path to write the verified height")
+
+	return cmd
+}
+
+func verifySnapshot(dataDir, backend string, minHeight uint64) (uint64, error) {
+	s, err := os.Stat(dataDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", dataDir, err)
+	}
+	if !s.IsDir() {
+		return 0, fmt.Errorf("%s is not a directory", dataDir)
+	}
+
+	// Read CometBFT's databases rather than the application database, which is chain specific (e.g. Namada does not
+	// use the Cosmos SDK store) and slow to open for large chains.
+	next, err := cosmos.StateHeight(dataDir, backend)
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, cosmos.ErrStateNotFound) {
+		// BlockStoreHeight treats a missing block store as an empty node, which is not a valid snapshot.
+		if _, err = os.Stat(filepath.Join(dataDir, "blockstore.db")); err != nil {
+			return 0, fmt.Errorf("no state.db or blockstore.db in %s: %w", dataDir, err)
+		}
+		next, err = cosmos.BlockStoreHeight(dataDir, backend)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read height: %w", err)
+	}
+
+	height := uint64(next - 1)
+	if height < minHeight {
+		return height, fmt.Errorf("height %d is less than minimum expected height %d", height, minHeight)
+	}
+	return height, nil
+}
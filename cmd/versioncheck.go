@@ -5,22 +5,26 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"time"
 
-	"cosmossdk.io/log"
-	"cosmossdk.io/store/rootmulti"
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
-	dbm "github.com/cosmos/cosmos-db"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -29,23 +33,35 @@ const (
 
 	flagBackend = "backend"
 	flagDaemon  = "daemon"
+	flagRPCHost = "rpc-host"
+	flagPending = "upgrade-pending-blocks"
 
-	tickTime = 30 * time.Second
+	tickTime        = 30 * time.Second
+	upgradeTickTime = 3 * time.Second
+	rpcTimeout      = 5 * time.Second
 )
 
+var errImageMismatch = errors.New("image mismatch")
+
 // VersionCheckCmd gets the height of this node and updates the status of the crd.
 // It panics if the wrong image is specified for the pod for the height,
 // restarting the pod so that the correct image is used from the patched height.
 // this command is intended to be run as an init container.
+// As an init container, it also panics if CometBFT's state belongs to another chain ID than spec.chain.chainID.
+//
+// As a daemon, it instead marks the pod in status.upgrades as it approaches the next upgrade height and once it halts
+// there, so the operator recreates the pod with the upgraded image without waiting for a crash loop.
 func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "versioncheck",
 		Short: "Confirm correct image used for current node height",
-		Long:  `Open the Cosmos SDK chain database, get the height, update the crd status with the height, then check the image for the height and panic if it is incorrect.`,
+		Long:  `Get the height from the running node or the CometBFT databases, update the crd status with the height, then check the image for the height and panic if it is incorrect.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			dataDir := os.Getenv("DATA_DIR")
 			backend, _ := cmd.Flags().GetString(flagBackend)
 			daemon, _ := cmd.Flags().GetBool(flagDaemon)
+			rpcHost, _ := cmd.Flags().GetString(flagRPCHost)
+			pendingBlocks, _ := cmd.Flags().GetUint64(flagPending)
 
 			nsbz, err := os.ReadFile(namespaceFile)
 			if err != nil {
@@ -89,11 +105,6 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 				panic(fmt.Errorf("failed to get crd: %w", err))
 			}
 
-			if len(crd.Spec.ChainSpec.Versions) == 0 {
-				fmt.Fprintln(cmd.OutOrStdout(), "No versions specified, skipping version check")
-				return
-			}
-
 			s, err := os.Stat(dataDir)
 			if err != nil {
 				panic(fmt.Errorf("failed to stat %s: %w", dataDir, err))
@@ -103,6 +114,34 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 				panic(fmt.Errorf("%s is not a directory", dataDir))
 			}
 
+			if !daemon {
+				// Runs after any snapshot restore, so a snapshot of another network fails before the node starts.
+				if err = cosmos.VerifyStateChainID(dataDir, backend, crd.Spec.ChainSpec.ChainID); err != nil {
+					panic(err)
+				}
+			}
+
+			if len(crd.Spec.ChainSpec.Versions) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No versions specified, skipping version check")
+				return
+			}
+
+			broadcaster := record.NewBroadcaster()
+			defer broadcaster.Shutdown()
+			broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events(ns)})
+
+			check := versionCheck{
+				client:         kClient,
+				namespacedName: namespacedName,
+				pod:            thisPod,
+				heights: cosmos.NewHeightSource(
+					cosmos.NewCometClient(&http.Client{Timeout: rpcTimeout}), rpcHost, dataDir, backend,
+				),
+				recorder:      broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "versioncheck", Host: thisPod.Spec.NodeName}),
+				pendingBlocks: pendingBlocks,
+				writer:        cmd.OutOrStdout(),
+			}
+
 			if daemon {
 				ticker := time.NewTicker(tickTime)
 				defer ticker.Stop()
@@ -111,14 +150,23 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 					case <-cmd.Context().Done():
 						return
 					case <-ticker.C:
-						if err := checkVersion(cmd.Context(), nil, kClient, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
+						upgrade, err := check.Run(cmd.Context(), nil)
+						switch {
+						case errors.Is(err, errImageMismatch):
+							// The node halted at the upgrade height. The operator recreates the pod with the upgraded image.
+							fmt.Fprintf(cmd.OutOrStdout(), "%s. Waiting for the pod to be recreated.\n", err)
+						case err != nil:
 							panic(err)
 						}
-						ticker.Reset(tickTime)
+						if upgrade != nil {
+							ticker.Reset(upgradeTickTime)
+						} else {
+							ticker.Reset(tickTime)
+						}
 					}
 				}
 			}
-			if err := checkVersion(cmd.Context(), crd, kClient, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
+			if _, err := check.Run(cmd.Context(), crd); err != nil {
 				panic(err)
 			}
 		},
@@ -126,76 +174,101 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 
 	cmd.Flags().StringP(flagBackend, "b", "goleveldb", "Database backend")
 	cmd.Flags().BoolP(flagDaemon, "d", false, "Run as daemon")
+	cmd.Flags().String(flagRPCHost, "http://localhost:26657", "CometBFT rpc endpoint of the running node. If unreachable, the height is read from the CometBFT databases")
+	cmd.Flags().Uint64(flagPending, 100, "Mark the pod as pending an upgrade once within this many blocks of the upgrade height")
 
 	return cmd
 }
 
-func checkVersion(
-	ctx context.Context,
-	crd *cosmosv1.CosmosFullNode,
-	kClient client.Client,
-	namespacedName types.NamespacedName,
-	thisPod *corev1.Pod,
-	dataDir string,
-	backend string,
-	writer io.Writer,
-) error {
-	db, err := dbm.NewDB("application", getBackend(backend), dataDir)
+// versionCheck checks the pod runs the image for its height.
+type versionCheck struct {
+	client         client.Client
+	namespacedName types.NamespacedName
+	pod            *corev1.Pod
+	heights        cosmos.HeightSource
+	recorder       record.EventRecorder
+	pendingBlocks  uint64
+	writer         io.Writer
+}
+
+// Run updates the pod's height and upgrade in the crd status and returns the upgrade, if any.
+// It returns an error wrapping errImageMismatch if the pod does not run the image for its height.
+// If crd is nil, it is fetched once the height is known.
+func (c versionCheck) Run(ctx context.Context, crd *cosmosv1.CosmosFullNode) (*cosmosv1.UpgradeStatus, error) {
+	height, err := c.heights.NextHeight(ctx)
 	if err != nil {
 		if crd == nil {
-			fmt.Fprintf(writer, "Failed to open db: %s. The node is likely running.\n", err)
-			// This is okay, we will read it later if the node shuts down.
-			return nil
+			fmt.Fprintf(c.writer, "Failed to get height: %s. The node is likely starting.\n", err)
+			// This is okay, we will read it later once the node's rpc is up.
+			return nil, nil
 		} else {
-			return fmt.Errorf("failed to open db: %w", err)
+			return nil, fmt.Errorf("failed to get height: %w", err)
 		}
 	}
-	store := rootmulti.NewStore(db, log.NewNopLogger(), nil)
-
-	height := store.LatestVersion() + 1
-	db.Close()
 
 	if crd == nil {
 		crd = new(cosmosv1.CosmosFullNode)
-		if err := kClient.Get(ctx, namespacedName, crd); err != nil {
-			return fmt.Errorf("failed to get crd: %w", err)
+		if err := c.client.Get(ctx, c.namespacedName, crd); err != nil {
+			return nil, fmt.Errorf("failed to get crd: %w", err)
 		}
 	}
 
-	if err := patchStatusHeightIfNecessary(ctx, kClient, crd, thisPod.Name, uint64(height)); err != nil {
-		return err
+	var (
+		image   = c.pod.Spec.Containers[0].Image
+		prev    = crd.Status.Upgrades[c.pod.Name]
+		upgrade = fullnode.NextUpgrade(crd.Spec.ChainSpec.Versions, uint64(height), image, c.pendingBlocks)
+	)
+	if upgrade != nil {
+		upgrade.LastTransitionTime = metav1.Now()
+		if prev != nil && prev.Phase == upgrade.Phase && prev.Height == upgrade.Height && prev.Image == upgrade.Image {
+			upgrade.LastTransitionTime = prev.LastTransitionTime
+		}
 	}
 
-	var image string
-	for _, v := range crd.Spec.ChainSpec.Versions {
-		if uint64(height) < v.UpgradeHeight {
-			break
-		}
-		image = v.Image
+	if err := patchStatusIfNecessary(ctx, c.client, crd, c.pod.Name, uint64(height), upgrade); err != nil {
+		return nil, err
 	}
+	c.recordUpgrade(crd, height, prev, upgrade)
 
-	thisPodImage := thisPod.Spec.Containers[0].Image
-	if thisPodImage != image {
-		return fmt.Errorf("image mismatch for height %d: %s != %s", height, thisPodImage, image)
+	if upgrade != nil && upgrade.Phase == cosmosv1.UpgradePhaseHalted {
+		return upgrade, fmt.Errorf("%w for height %d: %s != %s", errImageMismatch, height, image, upgrade.Image)
 	}
 
-	fmt.Fprintf(writer, "Verified correct image for height %d: %s\n", height, image)
+	fmt.Fprintf(c.writer, "Verified correct image for height %d: %s\n", height, image)
 
-	return nil
+	return upgrade, nil
 }
 
-func patchStatusHeightIfNecessary(
+// recordUpgrade records an event when the pod's upgrade phase changes.
+func (c versionCheck) recordUpgrade(crd *cosmosv1.CosmosFullNode, height int64, prev, upgrade *cosmosv1.UpgradeStatus) {
+	switch {
+	case upgrade == nil && prev != nil:
+		c.recorder.Eventf(crd, corev1.EventTypeNormal, "UpgradeComplete",
+			"Pod %s runs image %s at height %d", c.pod.Name, c.pod.Spec.Containers[0].Image, height)
+	case upgrade == nil:
+	case prev != nil && prev.Phase == upgrade.Phase && prev.Height == upgrade.Height:
+	case upgrade.Phase == cosmosv1.UpgradePhasePending:
+		c.recorder.Eventf(crd, corev1.EventTypeNormal, string(upgrade.Phase),
+			"Pod %s is %d blocks from upgrade height %d, where it will be recreated with image %s",
+			c.pod.Name, upgrade.Height-uint64(height), upgrade.Height, upgrade.Image)
+	case upgrade.Phase == cosmosv1.UpgradePhaseHalted:
+		c.recorder.Eventf(crd, corev1.EventTypeNormal, "UpgradeHalted",
+			"Pod %s halted at height %d; requested recreation with image %s", c.pod.Name, height, upgrade.Image)
+	}
+}
+
+func patchStatusIfNecessary(
 	ctx context.Context,
 	kClient client.Client,
 	crd *cosmosv1.CosmosFullNode,
 	instanceName string,
 	height uint64,
+	upgrade *cosmosv1.UpgradeStatus,
 ) error {
-	if crd.Status.Height != nil {
-		if h, ok := crd.Status.Height[instanceName]; ok && h == height {
-			// Status is up to date already.
-			return nil
-		}
+	h, ok := crd.Status.Height[instanceName]
+	if ok && h == height && equality.Semantic.DeepEqual(crd.Status.Upgrades[instanceName], upgrade) {
+		// Status is up to date already.
+		return nil
 	}
 
 	patch := crd.DeepCopy()
@@ -203,6 +276,14 @@ func patchStatusHeightIfNecessary(
 		patch.Status.Height = make(map[string]uint64)
 	}
 	patch.Status.Height[instanceName] = height
+	if upgrade != nil {
+		if patch.Status.Upgrades == nil {
+			patch.Status.Upgrades = make(map[string]*cosmosv1.UpgradeStatus)
+		}
+		patch.Status.Upgrades[instanceName] = upgrade
+	} else {
+		delete(patch.Status.Upgrades, instanceName)
+	}
 
 	if err := kClient.Status().Patch(
 		ctx, patch, client.MergeFrom(crd.DeepCopy()),
@@ -212,18 +293,3 @@ func patchStatusHeightIfNecessary(
 
 	return nil
 }
-
-func getBackend(backend string) dbm.BackendType {
-	switch backend {
-	case "goleveldb":
-		return dbm.GoLevelDBBackend
-	case "memdb":
-		return dbm.MemDBBackend
-	case "rocksdb":
-		return dbm.RocksDBBackend
-	case "pebbledb":
-		return dbm.PebbleDBBackend
-	default:
-		panic(fmt.Errorf("unknown backend %s", backend))
-	}
-}
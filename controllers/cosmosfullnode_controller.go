@@ -19,11 +19,13 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
+	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -44,6 +46,7 @@ type CosmosFullNodeReconciler struct {
 
 	cacheController           *cosmos.CacheController
 	configMapControl          fullnode.ConfigMapControl
+	namadaHealth              *fullnode.NamadaHealthCollector
 	nodeKeyControl            fullnode.NodeKeyControl
 	peerCollector             *fullnode.PeerCollector
 	podControl                fullnode.PodControl
@@ -62,12 +65,14 @@ func NewFullNode(
 	recorder record.EventRecorder,
 	statusClient *fullnode.StatusClient,
 	cacheController *cosmos.CacheController,
+	httpClient *http.Client,
 ) *CosmosFullNodeReconciler {
 	return &CosmosFullNodeReconciler{
 		Client: client,
 
 		cacheController:           cacheController,
 		configMapControl:          fullnode.NewConfigMapControl(client),
+		namadaHealth:              fullnode.NewNamadaHealthCollector(healthcheck.NewClient(httpClient), cacheController),
 		nodeKeyControl:            fullnode.NewNodeKeyControl(client),
 		peerCollector:             fullnode.NewPeerCollector(client),
 		podControl:                fullnode.NewPodControl(client, cacheController),
@@ -119,6 +124,7 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	fullnode.ResetStatus(crd)
 
 	syncInfo := fullnode.SyncInfoStatus(ctx, crd, r.cacheController)
+	r.namadaHealth.Collect(ctx, crd, syncInfo)
 
 	pvcStatusChanges := fullnode.PVCStatusChanges{}
 
@@ -148,6 +154,9 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		errs.Append(perr)
 	}
 	crd.Status.Peers = peers.AllExternal()
+	if err := fullnode.CheckNodeInfo(reporter, crd, syncInfo, peers); err != nil {
+		errs.Append(err)
+	}
 
 	// Reconcile ConfigMaps.
 	configCksums, err := r.configMapControl.Reconcile(ctx, reporter, crd, peers)
@@ -246,6 +255,9 @@ func (r *CosmosFullNodeReconciler) updateStatus(
 				status.Height[k] = *v.Height + 1 // we want the block that is going through consensus, not the committed one.
 			}
 		}
+		if height, ok := fullnode.ReferenceHeight(syncInfo, r.cacheController.ReferenceHeight(ctx, crd)); ok {
+			status.ReferenceHeight = ptr(height)
+		}
 		if status.SelfHealing.PVCAutoScale != nil {
 			for _, k := range pvcStatusChanges.Deleted {
 				delete(status.SelfHealing.PVCAutoScale, k)
@@ -23,13 +23,15 @@ import (
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
 	"github.com/bharvest-devops/cosmos-operator/internal/prune"
-	corev1 "k8s.io/api/core/v1"
 	"net/http"
 	"time"
 
 	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +42,7 @@ import (
 type PruningReconciler struct {
 	client.Client
 	diskClient      *fullnode.DiskUsageCollector
+	dryRun          *fullnode.DryRunRecorder
 	recorder        record.EventRecorder
 	pruner          *prune.Pruner
 	fullNodeControl *prune.FullNodeControl
@@ -55,6 +58,7 @@ func NewPruningReconciler(
 	return &PruningReconciler{
 		Client:          client,
 		diskClient:      fullnode.NewDiskUsageCollector(healthcheck.NewClient(httpClient), client),
+		dryRun:          fullnode.NewDryRunRecorder(statusClient),
 		recorder:        recorder,
 		pruner:          prune.NewPruner(cacheController),
 		fullNodeControl: prune.NewFullNodeControl(statusClient, client),
@@ -86,70 +90,74 @@ func (r *PruningReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	retryResult := ctrl.Result{RequeueAfter: 180 * time.Second}
 
-	// Check current phase is correct.
-	checkPhase(crd)
-	status := crd.Status.SelfHealing.CosmosPruningStatus
-
-	switch status.CosmosPruningPhase {
-	case cosmosv1.CosmosPruningPhaseFindingCandidate:
-		usage, err := r.diskClient.CollectDiskUsage(ctx, crd)
-		if err != nil {
-			reporter.Error(err, "Failed to collect pvc disk usage")
-			// This error can be noisy so we record a generic error. Check logs for error details.
-			reporter.RecordError("PVCPruning", errors.New("failed to collect pvc disk usage"))
-			return retryResult, err
+	// Each candidate progresses through its own phases, so several pods may be pruning concurrently.
+	results, err := r.fullNodeControl.AdvanceCandidates(ctx, crd)
+	if err != nil {
+		reporter.Error(err, "Failed to advance pruning candidates")
+		reporter.RecordError("PVCPruning", err)
+		return retryResult, err
+	}
+	for _, result := range results {
+		if result.Succeeded() {
+			msg := fmt.Sprintf("Pruning complete: %s in %s", result.PodName, result.Duration.Round(time.Second))
+			reporter.Info(msg)
+			reporter.RecordInfo("PVCPruning", msg)
+			continue
 		}
-
-		var candidatePod *corev1.Pod
-		candidatePod = r.pruner.FindCandidate(ctx, crd, usage)
-		if candidatePod == nil {
-			return retryResult, nil
+		action := "pod will not be restored until restoreOnFailure is set"
+		if crd.Spec.SelfHeal.PruningSpec.RestoreOnFailure {
+			action = "restoring pod"
 		}
+		err = fmt.Errorf("pruning failed: %s exited with code %d; %s", result.PodName, result.ExitCode, action)
+		reporter.Error(err, "Pruning failed")
+		reporter.RecordError("PVCPruning", err)
+	}
+
+	usage, err := r.diskClient.CollectDiskUsage(ctx, crd)
+	if err != nil {
+		reporter.Error(err, "Failed to collect pvc disk usage")
+		// This error can be noisy so we record a generic error. Check logs for error details.
+		reporter.RecordError("PVCPruning", errors.New("failed to collect pvc disk usage"))
+		return retryResult, err
+	}
 
-		msg := fmt.Sprintf("Pruning candidate found: %s", candidatePod.Name)
+	reclaimed, err := r.fullNodeControl.RecordUsageAfter(ctx, crd, usage)
+	if err != nil {
+		reporter.Error(err, "Failed to record pvc disk usage after pruning")
+		reporter.RecordError("PVCPruning", err)
+		return retryResult, err
+	}
+	for _, rb := range reclaimed {
+		msg := fmt.Sprintf("Pruning reclaimed %s: %s", resource.NewQuantity(rb.Bytes, resource.BinarySI), rb.PodName)
 		reporter.Info(msg)
 		reporter.RecordInfo("PVCPruning", msg)
+	}
 
-		err = r.fullNodeControl.SignalPodReplace(ctx, crd, []*corev1.Pod{candidatePod})
-		if err != nil {
-			return retryResult, err
-		}
-	case cosmosv1.CosmosPruningPhaseWaitingForPodReplaced:
-		if err := r.fullNodeControl.ConfirmPodReplaced(ctx, crd); err != nil {
-			reporter.Error(err, "Failed to confirm pod deletion")
-			reporter.RecordError("PVCPruning", errors.Join(errors.New("WaitingForPodDeletionError"), err))
-			return retryResult, nil
-		}
-	case cosmosv1.CosmosPruningPhaseWaitingForComplete:
-		ready, err := r.fullNodeControl.CheckPruningComplete(ctx, crd)
-		if err != nil {
-			reporter.Error(err, "Failed to check pruning status")
-			reporter.RecordError("PruningCheckingError", err)
-			return retryResult, nil
-		}
-		if !ready {
-			reporter.Info("Pruning is not complete")
-			return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
-		}
-
-		reporter.Info("Pruning complete")
-	case cosmosv1.CosmosPruningPhaseRestorePod:
-		err := r.fullNodeControl.SignalPodRestoration(ctx, crd)
-		if err != nil {
-			reporter.Error(err, "Failed to restore pod")
-			reporter.RecordError("PodRestorationError", err)
-			return retryResult, err
+	candidatePods, err := r.pruner.FindCandidates(ctx, crd, usage)
+	if err != nil {
+		reporter.Error(err, "Invalid pruning spec")
+		reporter.RecordError("PVCPruning", err)
+		return retryResult, nil
+	}
+	if len(candidatePods) > 0 && fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.PruningSpec.DryRun) {
+		recordDryRun(ctx, reporter, r.dryRun, crd, lo.Map(candidatePods, func(pod *corev1.Pod, _ int) cosmosv1.DryRunAction {
+			return cosmosv1.DryRunAction{Feature: "Pruning", Target: pod.Name, Action: "Prune"}
+		}))
+	} else if len(candidatePods) > 0 {
+		for _, pod := range candidatePods {
+			msg := fmt.Sprintf("Pruning candidate found: %s", pod.Name)
+			reporter.Info(msg)
+			reporter.RecordInfo("PVCPruning", msg)
 		}
-	case cosmosv1.CosmosPruningPhaseConfirmPodRestoration:
-		// Complete pruning
-		err := r.fullNodeControl.ConfirmPodRestoration(ctx, crd)
-		if err != nil {
-			reporter.Error(err, "Failed to confirm pod Restoration")
-			reporter.RecordError("ConfirmPodRestorationErr", err)
+		if err = r.fullNodeControl.SignalPodReplace(ctx, crd, candidatePods, usage); err != nil {
 			return retryResult, err
 		}
 	}
 
+	if status := crd.Status.SelfHealing.CosmosPruningStatus; status == nil || len(status.Candidates) == 0 {
+		return retryResult, nil
+	}
+
 	// Updating status in the defer above triggers a new reconcile loop.
 	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 }
@@ -162,20 +170,3 @@ func (r *PruningReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager
 		For(&cosmosv1.CosmosFullNode{}).
 		Complete(r)
 }
-
-func checkPhase(crd *cosmosv1.CosmosFullNode) {
-	var pruningStatus = crd.Status.SelfHealing.CosmosPruningStatus
-
-	if pruningStatus == nil {
-		pruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			CosmosPruningPhase: cosmosv1.CosmosPruningPhaseFindingCandidate,
-		})
-	}
-
-	if len(pruningStatus.Candidates) != 0 &&
-		pruningStatus.CosmosPruningPhase != cosmosv1.CosmosPruningPhaseRestorePod && pruningStatus.CosmosPruningPhase != cosmosv1.CosmosPruningPhaseConfirmPodRestoration {
-		crd.Status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseWaitingForComplete
-	}
-
-	crd.Status.SelfHealing.CosmosPruningStatus = pruningStatus
-}
@@ -36,10 +36,12 @@ import (
 // ScheduledVolumeSnapshotReconciler reconciles a ScheduledVolumeSnapshot object
 type ScheduledVolumeSnapshotReconciler struct {
 	client.Client
+	exportControl         *volsnapshot.ExportControl
 	fullNodeControl       *volsnapshot.FullNodeControl
 	missingVolSnapshotCRD bool
 	recorder              record.EventRecorder
 	scheduler             *volsnapshot.Scheduler
+	verifyControl         *volsnapshot.VerifyControl
 	volSnapshotControl    *volsnapshot.VolumeSnapshotControl
 }
 
@@ -52,10 +54,12 @@ func NewScheduledVolumeSnapshotReconciler(
 ) *ScheduledVolumeSnapshotReconciler {
 	return &ScheduledVolumeSnapshotReconciler{
 		Client:                client,
+		exportControl:         volsnapshot.NewExportControl(client),
 		fullNodeControl:       volsnapshot.NewFullNodeControl(statusClient, client),
 		missingVolSnapshotCRD: missingVolSnapCRD,
 		recorder:              recorder,
 		scheduler:             volsnapshot.NewScheduler(client),
+		verifyControl:         volsnapshot.NewVerifyControl(client),
 		volSnapshotControl:    volsnapshot.NewVolumeSnapshotControl(client, cache),
 	}
 }
@@ -64,8 +68,10 @@ func NewScheduledVolumeSnapshotReconciler(
 //+kubebuilder:rbac:groups=cosmos.bharvest,resources=scheduledvolumesnapshots/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cosmos.bharvest,resources=scheduledvolumesnapshots/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cosmos.bharvest,resources=cosmosfullnodes/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;delete
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;delete;patch
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="batch",resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -181,12 +187,88 @@ func (r *ScheduledVolumeSnapshotReconciler) Reconcile(ctx context.Context, req c
 		if err := r.restorePod(ctx, logger, crd); err != nil {
 			return retryResult, nil
 		}
-		if crd.Spec.Suspend {
+		switch {
+		case crd.Spec.Suspend:
 			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseSuspended
-		} else {
+		case crd.Spec.Verify != nil:
+			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseCreatingVerification
+		case crd.Spec.Export != nil:
+			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseCreatingExport
+		default:
 			// Reset to beginning.
 			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForNext
 		}
+
+	case cosmosv1alpha1.SnapshotPhaseCreatingVerification:
+		logger.Info(string(phase))
+		if crd.Spec.Verify == nil {
+			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForNext
+			break
+		}
+		if err := r.verifyControl.CreateVerification(ctx, crd); err != nil {
+			logger.Error(err, "Failed to create volume snapshot verification")
+			r.reportError(crd, "CreateVerificationError", err)
+			return retryResult, nil
+		}
+		crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForVerification
+
+	case cosmosv1alpha1.SnapshotPhaseWaitingForVerification:
+		logger.Info(string(phase))
+		done, err := r.verifyControl.VerificationFinished(ctx, crd)
+		if !done {
+			if err != nil {
+				logger.Error(err, "Failed to find volume snapshot verification status")
+				r.reportError(crd, "VerificationStatusError", err)
+			} else {
+				logger.Info("Verification not finished; requeueing")
+			}
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if err != nil {
+			logger.Error(err, "Volume snapshot verification failed")
+			r.reportError(crd, "VerificationError", err)
+			r.finishPhase(crd)
+			break
+		}
+		r.recorder.Event(crd, kube.EventNormal, "Verified", fmt.Sprintf("Verified %s at height %d", crd.Status.LastVerification.VolumeSnapshotName, crd.Status.LastVerification.Height))
+		if crd.Spec.Export != nil && !crd.Spec.Suspend {
+			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseCreatingExport
+		} else {
+			r.finishPhase(crd)
+		}
+
+	case cosmosv1alpha1.SnapshotPhaseCreatingExport:
+		logger.Info(string(phase))
+		if crd.Spec.Export == nil {
+			crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForNext
+			break
+		}
+		if err := r.exportControl.CreateExport(ctx, crd); err != nil {
+			logger.Error(err, "Failed to create volume snapshot export")
+			r.reportError(crd, "CreateExportError", err)
+			return retryResult, nil
+		}
+		crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForExport
+
+	case cosmosv1alpha1.SnapshotPhaseWaitingForExport:
+		logger.Info(string(phase))
+		done, err := r.exportControl.ExportFinished(ctx, crd)
+		if !done {
+			if err != nil {
+				logger.Error(err, "Failed to find volume snapshot export status")
+				r.reportError(crd, "ExportStatusError", err)
+			} else {
+				logger.Info("Export not finished; requeueing")
+			}
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if err != nil {
+			logger.Error(err, "Volume snapshot export failed")
+			r.reportError(crd, "ExportError", err)
+		} else {
+			r.recorder.Event(crd, kube.EventNormal, "Exported", fmt.Sprintf("Exported %s to %s", crd.Status.LastExport.VolumeSnapshotName, crd.Status.LastExport.URL))
+		}
+		r.finishPhase(crd)
 	}
 
 	// Updating status in the defer above triggers a new reconcile loop.
@@ -205,6 +287,15 @@ func (r *ScheduledVolumeSnapshotReconciler) restorePod(ctx context.Context, logg
 	return nil
 }
 
+// finishPhase resets to the beginning or suspends if the user suspended the crd while a job was in progress.
+func (r *ScheduledVolumeSnapshotReconciler) finishPhase(crd *cosmosv1alpha1.ScheduledVolumeSnapshot) {
+	if crd.Spec.Suspend {
+		crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseSuspended
+	} else {
+		crd.Status.Phase = cosmosv1alpha1.SnapshotPhaseWaitingForNext
+	}
+}
+
 func (r *ScheduledVolumeSnapshotReconciler) reportError(crd *cosmosv1alpha1.ScheduledVolumeSnapshot, reason string, err error) {
 	r.recorder.Event(crd, kube.EventWarning, reason, err.Error())
 	crd.Status.StatusMessage = ptr(fmt.Sprint("Error: ", err))
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	corev1 "k8s.io/api/core/v1"
 	"net/http"
+	"sort"
 	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
@@ -29,6 +30,8 @@ import (
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,8 +42,13 @@ import (
 type SelfHealingReconciler struct {
 	client.Client
 	cacheController *cosmos.CacheController
+	crashLoopHealer *fullnode.CrashLoopHealer
 	diskClient      *fullnode.DiskUsageCollector
 	driftDetector   fullnode.DriftDetection
+	driftRemediator *fullnode.DriftRemediation
+	dryRun          *fullnode.DryRunRecorder
+	initWatchdog    *fullnode.InitContainerWatchdog
+	peerHealth      *fullnode.PeerHealth
 	pvcHealer       *fullnode.PVCHealer
 	recorder        record.EventRecorder
 	statusClient    *fullnode.StatusClient
@@ -56,8 +64,13 @@ func NewSelfHealing(
 	return &SelfHealingReconciler{
 		Client:          client,
 		cacheController: cacheController,
+		crashLoopHealer: fullnode.NewCrashLoopHealer(client, statusClient),
 		diskClient:      fullnode.NewDiskUsageCollector(healthcheck.NewClient(httpClient), client),
-		driftDetector:   fullnode.NewDriftDetection(cacheController),
+		driftDetector:   fullnode.NewDriftDetection(cacheController, cacheController),
+		driftRemediator: fullnode.NewDriftRemediation(statusClient),
+		dryRun:          fullnode.NewDryRunRecorder(statusClient),
+		initWatchdog:    fullnode.NewInitContainerWatchdog(client, statusClient),
+		peerHealth:      fullnode.NewPeerHealth(cacheController, statusClient),
 		pvcHealer:       fullnode.NewPVCHealer(statusClient),
 		recorder:        recorder,
 		statusClient:    statusClient,
@@ -87,8 +100,12 @@ func (r *SelfHealingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	reporter := kube.NewEventReporter(logger, r.recorder, crd)
 
 	r.checkRegeneratedPVC(ctx, reporter, crd)
-	r.pvcAutoScale(ctx, reporter, crd)
+	usage, err := r.collectDiskUsage(ctx, reporter, crd)
+	r.pvcAutoScale(ctx, reporter, crd, usage, err)
 	r.mitigateHeightDrift(ctx, reporter, crd)
+	r.healCrashLoops(ctx, reporter, crd)
+	r.restartStuckInitContainers(ctx, reporter, crd)
+	r.healPeerStarvation(ctx, reporter, crd)
 
 	return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
 }
@@ -144,17 +161,59 @@ func (r *SelfHealingReconciler) checkRegeneratedPVC(ctx context.Context, reporte
 	}
 }
 
-func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+// collectDiskUsage collects the disk usage of each PVC and reports it in status.selfHealing.diskUsage and as metrics.
+// Disk usage is only collected if a feature needs it. The status is only updated if the usage changed, because
+// every status update triggers another reconcile.
+func (r *SelfHealingReconciler) collectDiskUsage(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) ([]fullnode.PVCDiskUsage, error) {
+	if crd.Spec.SelfHeal.PVCAutoScale == nil && crd.Spec.SelfHeal.PruningSpec == nil {
+		return nil, nil
+	}
+	usage, err := r.diskClient.CollectDiskUsage(ctx, crd)
+	if err != nil {
+		return nil, err
+	}
+	fullnode.RecordDiskUsageMetrics(crd, usage)
+	diskUsage := fullnode.DiskUsageStatus(usage)
+	if equality.Semantic.DeepEqual(diskUsage, crd.Status.SelfHealing.DiskUsage) {
+		return usage, nil
+	}
+	if err = r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		status.SelfHealing.DiskUsage = diskUsage
+	}); err != nil {
+		reporter.Error(err, "Failed to update disk usage status")
+	}
+	return usage, nil
+}
+
+func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, usage []fullnode.PVCDiskUsage, err error) {
 	if crd.Spec.SelfHeal.PVCAutoScale == nil {
 		return
 	}
-	usage, err := r.diskClient.CollectDiskUsage(ctx, crd)
 	if err != nil {
 		reporter.Error(err, "Failed to collect pvc disk usage")
 		// This error can be noisy so we record a generic error. Check logs for error details.
 		reporter.RecordError("PVCAutoScaleCollectUsage", errors.New("failed to collect pvc disk usage"))
 		return
 	}
+	if fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.PVCAutoScale.DryRun) {
+		sizes, err := r.pvcHealer.PlanPVCResize(crd, usage)
+		if err != nil {
+			reporter.Error(err, "Failed to plan pvc resize")
+			reporter.RecordError("PVCAutoScaleSignalResize", err)
+		}
+		names := lo.Keys(sizes)
+		sort.Strings(names)
+		recordDryRun(ctx, reporter, r.dryRun, crd, lo.Map(names, func(name string, _ int) cosmosv1.DryRunAction {
+			size := sizes[name]
+			return cosmosv1.DryRunAction{
+				Feature: "PVCAutoScale",
+				Target:  name,
+				Action:  "ResizePVC",
+				Message: fmt.Sprintf("Request %s", size.String()),
+			}
+		}))
+		return
+	}
 	didSignal, err := r.pvcHealer.SignalPVCResize(ctx, crd, usage)
 	if err != nil {
 		reporter.Error(err, "Failed to signal pvc resize")
@@ -175,6 +234,22 @@ func (r *SelfHealingReconciler) mitigateHeightDrift(ctx context.Context, reporte
 	}
 
 	pods := r.driftDetector.LaggingPods(ctx, crd)
+	if len(crd.Spec.SelfHeal.HeightDriftMitigation.Remediation) > 0 {
+		r.remediateHeightDrift(ctx, reporter, crd, pods)
+		return
+	}
+	if fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.HeightDriftMitigation.DryRun) {
+		actions := lo.Map(pods, func(pod *corev1.Pod, _ int) cosmosv1.DryRunAction {
+			return cosmosv1.DryRunAction{
+				Feature: "HeightDriftMitigation",
+				Target:  pod.Name,
+				Action:  "DeletePod",
+				Message: "Height lagged behind or exceeded heightRetainTime",
+			}
+		})
+		recordDryRun(ctx, reporter, r.dryRun, crd, actions)
+		return
+	}
 	var deleted int
 	for _, pod := range pods {
 		// CosmosFullNodeController will detect missing pod and re-create it.
@@ -194,6 +269,166 @@ func (r *SelfHealingReconciler) mitigateHeightDrift(ctx context.Context, reporte
 	}
 }
 
+// remediateHeightDrift escalates the remediation of each lagging pod through the configured steps.
+func (r *SelfHealingReconciler) remediateHeightDrift(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, lagging []*corev1.Pod) {
+	healthy := r.driftDetector.HealthyPods(ctx, crd)
+	actions, err := r.driftRemediator.Remediate(ctx, crd, lagging, healthy)
+	if err != nil {
+		reporter.Error(err, "Failed to update height drift remediation status")
+		reporter.RecordError("HeightDriftMitigation", err)
+		return
+	}
+	if fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.HeightDriftMitigation.DryRun) {
+		recordDryRun(ctx, reporter, r.dryRun, crd, lo.Map(actions, func(action fullnode.DriftAction, _ int) cosmosv1.DryRunAction {
+			return cosmosv1.DryRunAction{
+				Feature: "HeightDriftMitigation",
+				Target:  action.Pod.Name,
+				Action:  string(action.Action),
+				Message: fmt.Sprintf("Step %d, attempt %d", action.Step+1, action.Attempt),
+			}
+		}))
+		return
+	}
+	for _, action := range actions {
+		// CosmosFullNodeController will detect missing pod and re-create it, applying the action.
+		if err = r.Delete(ctx, action.Pod); kube.IgnoreNotFound(err) != nil {
+			reporter.Error(err, "Failed to delete pod", "pod", action.Pod.Name)
+			reporter.RecordError("HeightDriftMitigationDeletePod", err)
+			continue
+		}
+		msg := fmt.Sprintf("Height drift remediation for %s: %s (step %d, attempt %d)",
+			action.Pod.Name, action.Action, action.Step+1, action.Attempt)
+		reporter.Info(msg)
+		reporter.RecordInfo("HeightDriftMitigation", msg)
+	}
+}
+
+func (r *SelfHealingReconciler) healCrashLoops(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+	if crd.Spec.SelfHeal.CrashLoopHealing == nil {
+		return
+	}
+	found, err := r.crashLoopHealer.Heal(ctx, crd)
+	if err != nil {
+		reporter.Error(err, "Failed to heal crash loops")
+		reporter.RecordError("CrashLoopHealing", err)
+		return
+	}
+	for _, c := range found {
+		msg := fmt.Sprintf("Pod %s is crash looping from %s (%d restarts); remedy %s: %s",
+			c.PodName, c.Status.Reason, c.Status.RestartCount, c.Status.Remedy, c.Status.Message)
+		reporter.Info(msg)
+		reporter.RecordError("CrashLoopHealing", errors.New(msg))
+	}
+	if !fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.CrashLoopHealing.DryRun) {
+		return
+	}
+	var actions []cosmosv1.DryRunAction
+	for _, c := range found {
+		if c.Status.Remedy == cosmosv1.CrashLoopRemedyAlertOnly {
+			continue
+		}
+		actions = append(actions, cosmosv1.DryRunAction{
+			Feature: "CrashLoopHealing",
+			Target:  c.PodName,
+			Action:  string(c.Status.Remedy),
+			Message: string(c.Status.Reason),
+		})
+	}
+	recordDryRun(ctx, reporter, r.dryRun, crd, actions)
+}
+
+func (r *SelfHealingReconciler) restartStuckInitContainers(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+	if crd.Spec.SelfHeal.InitContainerWatchdog == nil {
+		return
+	}
+	stuck, err := r.initWatchdog.FindStuck(ctx, crd)
+	if err != nil {
+		reporter.Error(err, "Failed to find stuck init containers")
+		reporter.RecordError("InitContainerWatchdog", err)
+		return
+	}
+	if fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.InitContainerWatchdog.DryRun) {
+		recordDryRun(ctx, reporter, r.dryRun, crd, lo.Map(stuck, func(s fullnode.StuckInitContainer, _ int) cosmosv1.DryRunAction {
+			return cosmosv1.DryRunAction{
+				Feature: "InitContainerWatchdog",
+				Target:  s.Pod.Name,
+				Action:  "DeletePod",
+				Message: fmt.Sprintf("Init container %s %s", s.Container, s.Reason),
+			}
+		}))
+		return
+	}
+	for _, s := range stuck {
+		// CosmosFullNodeController will detect missing pod and re-create it.
+		if err = r.Delete(ctx, s.Pod); kube.IgnoreNotFound(err) != nil {
+			reporter.Error(err, "Failed to delete pod", "pod", s.Pod.Name)
+			reporter.RecordError("InitContainerWatchdogDeletePod", err)
+			continue
+		}
+		msg := fmt.Sprintf("Restarted pod %s: init container %s %s", s.Pod.Name, s.Container, s.Reason)
+		if s.NextURL != "" {
+			msg += fmt.Sprintf("; retrying from %s", s.NextURL)
+		}
+		reporter.Info(msg)
+		reporter.RecordError("InitContainerWatchdog", errors.New(msg))
+	}
+}
+
+func (r *SelfHealingReconciler) healPeerStarvation(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+	if crd.Spec.SelfHeal.PeerHealth == nil {
+		return
+	}
+	actions, err := r.peerHealth.Check(ctx, crd)
+	if err != nil {
+		reporter.Error(err, "Failed to update peer health status")
+		reporter.RecordError("PeerHealth", err)
+		return
+	}
+	if fullnode.DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.PeerHealth.DryRun) {
+		recordDryRun(ctx, reporter, r.dryRun, crd, lo.Map(actions, func(action fullnode.PeerHealthAction, _ int) cosmosv1.DryRunAction {
+			return cosmosv1.DryRunAction{
+				Feature: "PeerHealth",
+				Target:  action.Pod.Name,
+				Action:  string(action.Stage),
+				Message: fmt.Sprintf("Starved of peers (%d peers)", action.Peers),
+			}
+		}))
+		return
+	}
+	for _, action := range actions {
+		// CosmosFullNodeController will detect missing pod and re-create it, applying the remediation.
+		if err = r.Delete(ctx, action.Pod); kube.IgnoreNotFound(err) != nil {
+			reporter.Error(err, "Failed to delete pod", "pod", action.Pod.Name)
+			reporter.RecordError("PeerHealthDeletePod", err)
+			continue
+		}
+		msg := fmt.Sprintf("Pod %s is starved of peers (%d peers): %s", action.Pod.Name, action.Peers, action.Stage)
+		reporter.Info(msg)
+		reporter.RecordInfo("PeerHealth", msg)
+	}
+}
+
+// recordDryRun records the actions a feature would have taken in dry-run mode and reports the newly planned ones.
+func recordDryRun(ctx context.Context, reporter kube.Reporter, recorder *fullnode.DryRunRecorder, crd *cosmosv1.CosmosFullNode, actions []cosmosv1.DryRunAction) {
+	if len(actions) == 0 {
+		return
+	}
+	planned, err := recorder.Record(ctx, crd, actions)
+	if err != nil {
+		reporter.Error(err, "Failed to record dry run actions")
+		reporter.RecordError("DryRun", err)
+		return
+	}
+	for _, action := range planned {
+		msg := fmt.Sprintf("Dry run: %s would %s %s", action.Feature, action.Action, action.Target)
+		if action.Message != "" {
+			msg += ": " + action.Message
+		}
+		reporter.Info(msg)
+		reporter.RecordInfo("DryRun", msg)
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SelfHealingReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
 	// We do not have to index Pods because the CosmosFullNodeReconciler already does so.
@@ -147,7 +147,14 @@ func (r *StatefulJobReconciler) createResources(ctx context.Context, crd *cosmos
 	logger := log.FromContext(ctx)
 
 	// Find most recent VolumeSnapshot.
-	recent, err := kube.RecentVolumeSnapshot(ctx, r, crd.Namespace, crd.Spec.Selector)
+	var filters []kube.VolumeSnapshotFilter
+	if crd.Spec.MinHeight != nil {
+		filters = append(filters, kube.MinHeightFilter(*crd.Spec.MinHeight))
+	}
+	if crd.Spec.MatchImage != "" {
+		filters = append(filters, kube.ImageFilter(crd.Spec.MatchImage))
+	}
+	recent, err := kube.RecentVolumeSnapshot(ctx, r, crd.Namespace, crd.Spec.Selector, filters...)
 	if err != nil {
 		return err
 	}
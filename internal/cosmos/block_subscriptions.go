@@ -0,0 +1,129 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BlockSubscriber subscribes to new blocks of a CometBFT node.
+type BlockSubscriber interface {
+	SubscribeNewBlock(ctx context.Context, rpcHost string, fn func(NewBlockEvent)) error
+}
+
+// podBlock is a new block announced by a pod.
+type podBlock struct {
+	UID   types.UID
+	Block NewBlockEvent
+}
+
+type blockSubscription struct {
+	host   string
+	cancel context.CancelFunc
+	live   atomic.Bool
+}
+
+// blockSubscriptions keeps a NewBlockHeader subscription open to each pod of a controller.
+// A subscription is live once it delivered a block and until it breaks. It is retried after retryInterval.
+type blockSubscriptions struct {
+	subscriber    BlockSubscriber
+	reporter      kube.Reporter
+	retryInterval time.Duration
+	blocks        chan podBlock
+
+	wg   sync.WaitGroup
+	subs map[types.UID]*blockSubscription
+}
+
+func newBlockSubscriptions(subscriber BlockSubscriber, reporter kube.Reporter, retryInterval time.Duration) *blockSubscriptions {
+	return &blockSubscriptions{
+		subscriber:    subscriber,
+		reporter:      reporter,
+		retryInterval: retryInterval,
+		blocks:        make(chan podBlock, 64),
+		subs:          make(map[types.UID]*blockSubscription),
+	}
+}
+
+// Blocks returns the channel of new blocks from all subscriptions.
+func (s *blockSubscriptions) Blocks() <-chan podBlock { return s.blocks }
+
+// Sync subscribes to pods that are not yet subscribed, and unsubscribes from pods which are gone or changed IP.
+// Not safe for concurrent use.
+func (s *blockSubscriptions) Sync(ctx context.Context, pods []corev1.Pod) {
+	want := make(map[types.UID]string)
+	for _, pod := range pods {
+		if pod.Status.PodIP == "" {
+			continue
+		}
+		want[pod.UID] = fmt.Sprintf("http://%s:26657", pod.Status.PodIP)
+	}
+
+	for uid, sub := range s.subs {
+		if want[uid] != sub.host {
+			sub.cancel()
+			delete(s.subs, uid)
+		}
+	}
+
+	for _, pod := range pods {
+		host, ok := want[pod.UID]
+		if !ok {
+			continue
+		}
+		if _, ok = s.subs[pod.UID]; ok {
+			continue
+		}
+		cctx, cancel := context.WithCancel(ctx)
+		sub := &blockSubscription{host: host, cancel: cancel}
+		s.subs[pod.UID] = sub
+		s.wg.Add(1)
+		go s.subscribe(cctx, pod.Name, pod.UID, sub)
+	}
+}
+
+// Live returns true if the pod's subscription is delivering blocks.
+func (s *blockSubscriptions) Live(uid types.UID) bool {
+	sub, ok := s.subs[uid]
+	return ok && sub.live.Load()
+}
+
+// Close cancels all subscriptions and waits for them to exit.
+func (s *blockSubscriptions) Close() {
+	for uid, sub := range s.subs {
+		sub.cancel()
+		delete(s.subs, uid)
+	}
+	s.wg.Wait()
+}
+
+func (s *blockSubscriptions) subscribe(ctx context.Context, podName string, uid types.UID, sub *blockSubscription) {
+	defer s.wg.Done()
+	defer sub.cancel()
+	for {
+		err := s.subscriber.SubscribeNewBlock(ctx, sub.host, func(block NewBlockEvent) {
+			sub.live.Store(true)
+			select {
+			case s.blocks <- podBlock{UID: uid, Block: block}:
+			case <-ctx.Done():
+			}
+		})
+		sub.live.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+		s.reporter.Debug("Block subscription broken, falling back to polling", "pod", podName, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retryInterval):
+		}
+	}
+}
@@ -2,14 +2,20 @@ package cosmos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,8 +29,10 @@ type cache struct {
 }
 
 type cacheItem struct {
-	coll   StatusCollection
-	cancel context.CancelFunc
+	coll      StatusCollection
+	reference uint64
+	netInfo   bool
+	cancel    context.CancelFunc
 }
 
 func newCache() *cache {
@@ -33,6 +41,7 @@ func newCache() *cache {
 	}
 }
 
+// Get returns a copy of the cached collection, so callers may modify it freely.
 func (c *cache) Get(key client.ObjectKey) (StatusCollection, bool) {
 	c.RLock()
 	defer c.RUnlock()
@@ -40,7 +49,7 @@ func (c *cache) Get(key client.ObjectKey) (StatusCollection, bool) {
 	if !ok {
 		return nil, false
 	}
-	return v.coll, ok
+	return slices.Clone(v.coll), ok
 }
 
 func (c *cache) Init(key client.ObjectKey, cancel context.CancelFunc) {
@@ -62,6 +71,44 @@ func (c *cache) Update(key client.ObjectKey, value StatusCollection) {
 	v.coll = value
 }
 
+func (c *cache) UpdateReference(key client.ObjectKey, height uint64) {
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return
+	}
+	v.reference = height
+}
+
+func (c *cache) Reference(key client.ObjectKey) uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	v, ok := c.m[key]
+	if !ok {
+		return 0
+	}
+	return v.reference
+}
+
+// SetNetInfo sets whether the connected peers are collected along with the status.
+func (c *cache) SetNetInfo(key client.ObjectKey, netInfo bool) {
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return
+	}
+	v.netInfo = netInfo
+}
+
+func (c *cache) NetInfo(key client.ObjectKey) bool {
+	c.RLock()
+	defer c.RUnlock()
+	v, ok := c.m[key]
+	return ok && v.netInfo
+}
+
 func (c *cache) Del(key client.ObjectKey) {
 	c.Lock()
 	defer c.Unlock()
@@ -81,29 +128,47 @@ func (c *cache) DelAll() {
 }
 
 type Collector interface {
-	Collect(ctx context.Context, pods []corev1.Pod) StatusCollection
+	Collect(ctx context.Context, pods []corev1.Pod, withNetInfo bool) StatusCollection
+	CollectHeight(ctx context.Context, chainID string, rpcHosts []string) (uint64, error)
 }
 
 const CacheControllerName = "CosmosCache"
 
 // CacheController periodically polls pods for their CometBFT status and caches the result.
+// It also polls the trusted nodes in spec.chain.reference, less often, because they are typically public endpoints.
 // The cache is a controller so it can watch CosmosFullNode objects to warm or invalidate the cache.
+//
+// If a BlockSubscriber is given, the controller subscribes to NewBlockHeader events of each pod and updates the latest
+// block of the cached status as blocks arrive. Pods with a live subscription are only fully polled every
+// resyncInterval, so the rest of the status (e.g. catching up, peers) stays current. Pods whose subscription is
+// broken fall back to polling every interval until the subscription is restored.
+//
+// The connected peers are only collected for CosmosFullNodes with spec.selfHeal.peerHealth set.
 type CacheController struct {
-	cache     *cache
-	client    client.Reader
-	collector Collector
-	eg        errgroup.Group
-	interval  time.Duration
-	recorder  record.EventRecorder
+	cache             *cache
+	client            client.Reader
+	collector         Collector
+	subscriber        BlockSubscriber
+	eg                errgroup.Group
+	interval          time.Duration
+	referenceInterval time.Duration
+	resyncInterval    time.Duration
+	retryInterval     time.Duration
+	recorder          record.EventRecorder
 }
 
-func NewCacheController(collector Collector, reader client.Reader, recorder record.EventRecorder) *CacheController {
+// NewCacheController returns a valid CacheController. Subscriber may be nil, in which case pods are only polled.
+func NewCacheController(collector Collector, subscriber BlockSubscriber, reader client.Reader, recorder record.EventRecorder) *CacheController {
 	return &CacheController{
-		cache:     newCache(),
-		client:    reader,
-		collector: collector,
-		interval:  5 * time.Second,
-		recorder:  recorder,
+		cache:             newCache(),
+		client:            reader,
+		collector:         collector,
+		subscriber:        subscriber,
+		interval:          5 * time.Second,
+		referenceInterval: 30 * time.Second,
+		resyncInterval:    time.Minute,
+		retryInterval:     30 * time.Second,
+		recorder:          recorder,
 	}
 }
 
@@ -135,16 +200,20 @@ func (c *CacheController) Reconcile(ctx context.Context, req reconcile.Request)
 
 	reporter := kube.NewEventReporter(log.FromContext(ctx), c.recorder, crd)
 
+	netInfo := crd.Spec.SelfHeal != nil && crd.Spec.SelfHeal.PeerHealth != nil
+
 	// If not already cached, start collecting status from pods.
 	if _, ok := c.cache.Get(req.NamespacedName); !ok {
 		cctx, cancel := context.WithCancel(ctx)
 		c.cache.Init(req.NamespacedName, cancel)
+		c.cache.SetNetInfo(req.NamespacedName, netInfo)
 		c.eg.Go(func() error {
 			defer cancel()
 			c.collectFromPods(cctx, reporter, req.NamespacedName)
 			return nil
 		})
-
+	} else {
+		c.cache.SetNetInfo(req.NamespacedName, netInfo)
 	}
 
 	return finishResult, nil
@@ -185,6 +254,28 @@ func (c *CacheController) SyncedPods(ctx context.Context, controller client.Obje
 	return kube.AvailablePods(c.Collect(ctx, controller).SyncedPods(), 5*time.Second, time.Now())
 }
 
+// ReferenceHeight returns the maximum height of the trusted nodes in spec.chain.reference.
+// Returns 0 if there is no reference or no trusted node.
+func (c *CacheController) ReferenceHeight(ctx context.Context, crd *cosmosv1.CosmosFullNode) uint64 {
+	ref := crd.Spec.ChainSpec.Reference
+	if ref == nil {
+		return 0
+	}
+	height := c.cache.Reference(client.ObjectKeyFromObject(crd))
+	if ref.FullNode == "" || ref.FullNode == crd.Name {
+		return height
+	}
+	for _, item := range c.Collect(ctx, client.ObjectKey{Namespace: crd.Namespace, Name: ref.FullNode}).Synced() {
+		if item.Status.Result.NodeInfo.Network != crd.Spec.ChainSpec.ChainID {
+			continue
+		}
+		if h := item.Status.LatestBlockHeight(); h > height {
+			height = h
+		}
+	}
+	return height
+}
+
 func (c *CacheController) listPods(ctx context.Context, controller client.ObjectKey) ([]corev1.Pod, error) {
 	var pods corev1.PodList
 	if err := c.client.List(ctx, &pods,
@@ -199,6 +290,17 @@ func (c *CacheController) listPods(ctx context.Context, controller client.Object
 func (c *CacheController) collectFromPods(ctx context.Context, reporter kube.Reporter, controller client.ObjectKey) {
 	defer c.cache.Del(controller)
 
+	var (
+		subs       *blockSubscriptions
+		blocks     <-chan podBlock
+		lastResync time.Time
+	)
+	if c.subscriber != nil {
+		subs = newBlockSubscriptions(c.subscriber, reporter, c.retryInterval)
+		defer subs.Close()
+		blocks = subs.Blocks()
+	}
+
 	collect := func() {
 		pods, err := c.listPods(ctx, controller)
 		if err != nil {
@@ -207,18 +309,114 @@ func (c *CacheController) collectFromPods(ctx context.Context, reporter kube.Rep
 			reporter.RecordError("ListPods", err)
 			return
 		}
-		c.cache.Update(controller, c.collector.Collect(ctx, pods))
+		withNetInfo := c.cache.NetInfo(controller)
+		if subs == nil {
+			c.cache.Update(controller, c.collector.Collect(ctx, pods, withNetInfo))
+			return
+		}
+
+		subs.Sync(ctx, pods)
+		cached, _ := c.cache.Get(controller)
+		now := time.Now()
+		resync := now.Sub(lastResync) >= c.resyncInterval
+		if resync {
+			lastResync = now
+		}
+		poll := lo.Filter(pods, func(pod corev1.Pod, _ int) bool {
+			return resync || !subs.Live(pod.UID) || needsPoll(cached, pod.UID)
+		})
+		var polled StatusCollection
+		if len(poll) > 0 {
+			polled = c.collector.Collect(ctx, poll, withNetInfo)
+		}
+		c.cache.Update(controller, mergeStatus(cached, polled, pods))
+	}
+
+	collectReference := func() {
+		crd := new(cosmosv1.CosmosFullNode)
+		if err := c.client.Get(ctx, controller, crd); err != nil {
+			// If deleted, Reconcile stops collecting.
+			return
+		}
+		ref := crd.Spec.ChainSpec.Reference
+		if ref == nil || len(ref.RPCEndpoints) == 0 {
+			c.cache.UpdateReference(controller, 0)
+			return
+		}
+		height, err := c.collector.CollectHeight(ctx, crd.Spec.ChainSpec.ChainID, ref.RPCEndpoints)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", controller, err)
+			reporter.Error(err, "Failed to collect reference height")
+			reporter.RecordError("ReferenceHeight", err)
+		}
+		c.cache.UpdateReference(controller, height)
 	}
 
-	collect() // Collect once immediately.
+	// Collect once immediately.
+	collect()
+	collectReference()
 	tick := time.NewTicker(c.interval)
 	defer tick.Stop()
+	refTick := time.NewTicker(c.referenceInterval)
+	defer refTick.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-tick.C:
 			collect()
+		case <-refTick.C:
+			collectReference()
+		case block := <-blocks:
+			cached, _ := c.cache.Get(controller)
+			c.cache.Update(controller, applyBlock(cached, block, time.Now()))
+		}
+	}
+}
+
+// needsPoll returns true if the pod's cached status cannot be kept current by new blocks alone.
+func needsPoll(coll StatusCollection, uid types.UID) bool {
+	item, ok := lo.Find(coll, func(item StatusItem) bool { return item.GetPod().UID == uid })
+	return !ok || item.Err != nil || item.Status.Result.SyncInfo.CatchingUp
+}
+
+// mergeStatus returns a collection of pods, preferring freshly polled status over cached status.
+func mergeStatus(cached, polled StatusCollection, pods []corev1.Pod) StatusCollection {
+	byUID := make(map[types.UID]StatusItem, len(pods))
+	for _, item := range cached {
+		byUID[item.GetPod().UID] = item
+	}
+	for _, item := range polled {
+		byUID[item.GetPod().UID] = item
+	}
+	merged := make(StatusCollection, 0, len(pods))
+	for i := range pods {
+		item, ok := byUID[pods[i].UID]
+		if !ok {
+			item = StatusItem{TS: time.Now(), Err: errors.New("missing status")}
+		}
+		item.Pod = &pods[i]
+		merged = append(merged, item)
+	}
+	sort.Sort(merged)
+	return merged
+}
+
+// applyBlock updates the pod's latest block from a NewBlockHeader event in-place.
+func applyBlock(coll StatusCollection, block podBlock, now time.Time) StatusCollection {
+	for i := range coll {
+		item := &coll[i]
+		if item.GetPod().UID != block.UID || item.Err != nil {
+			continue
+		}
+		if block.Block.Height <= item.Status.LatestBlockHeight() {
+			continue
 		}
+		item.Status.Result.SyncInfo.LatestBlockHeight = strconv.FormatUint(block.Block.Height, 10)
+		// The event has no block hash. Clear it rather than keep the hash of an older block.
+		item.Status.Result.SyncInfo.LatestBlockHash = ""
+		item.Status.Result.SyncInfo.LatestBlockTime = block.Block.Time
+		item.TS = now
 	}
+	return coll
 }
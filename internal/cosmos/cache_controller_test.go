@@ -2,6 +2,8 @@ package cosmos
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -22,21 +25,41 @@ import (
 type mockCollector struct {
 	Called         int64
 	GotPods        []corev1.Pod
+	GotNetInfo     bool
 	StubCollection StatusCollection
+
+	mu          sync.Mutex
+	GotChainID  string
+	GotRPCHosts []string
+	StubHeight  uint64
 }
 
-func (m *mockCollector) Collect(ctx context.Context, pods []corev1.Pod) StatusCollection {
-	atomic.AddInt64(&m.Called, 1)
+func (m *mockCollector) Collect(ctx context.Context, pods []corev1.Pod, withNetInfo bool) StatusCollection {
 	if ctx == nil {
 		panic("nil context")
 	}
 	m.GotPods = pods
+	m.GotNetInfo = withNetInfo
+	// Increment last, so readers observing the count also observe the fields.
+	atomic.AddInt64(&m.Called, 1)
 	return m.StubCollection
 }
 
+func (m *mockCollector) CollectHeight(ctx context.Context, chainID string, rpcHosts []string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainID = chainID
+	m.GotRPCHosts = rpcHosts
+	return m.StubHeight, nil
+}
+
 type mockReader struct {
 	sync.Mutex
-	GetErr error
+	GetErr  error
+	GetSpec cosmosv1.FullNodeSpec
 
 	ListPods []corev1.Pod
 	ListOpts []client.ListOption
@@ -51,6 +74,7 @@ func (m *mockReader) Get(ctx context.Context, key client.ObjectKey, obj client.O
 	var crd cosmosv1.CosmosFullNode
 	crd.Name = key.Name
 	crd.Namespace = key.Namespace
+	crd.Spec = m.GetSpec
 	*obj.(*cosmosv1.CosmosFullNode) = crd
 	return m.GetErr
 }
@@ -81,6 +105,34 @@ func TestCacheController_Reconcile(t *testing.T) {
 		{Pod: new(corev1.Pod)},
 	}
 
+	t.Run("peer health", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+		var reader mockReader
+		reader.ListPods = make([]corev1.Pod, 1)
+		reader.GetSpec.SelfHeal = &cosmosv1.SelfHealSpec{PeerHealth: &cosmosv1.PeerHealthSpec{}}
+
+		var collector mockCollector
+		controller := NewCacheController(&collector, nil, &reader, nil)
+		defer controller.Close()
+
+		req := reconcile.Request{NamespacedName: client.ObjectKey{Name: name, Namespace: namespace}}
+		_, err := controller.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt64(&collector.Called) > 0
+		}, time.Second, time.Millisecond)
+		require.True(t, collector.GotNetInfo)
+
+		reader.Lock()
+		reader.GetSpec.SelfHeal = nil
+		reader.Unlock()
+		_, err = controller.Reconcile(ctx, req)
+		require.NoError(t, err)
+		require.False(t, controller.cache.NetInfo(req.NamespacedName))
+	})
+
 	t.Run("crd created or updated", func(t *testing.T) {
 		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
 
@@ -91,7 +143,7 @@ func TestCacheController_Reconcile(t *testing.T) {
 		var collector mockCollector
 		collector.StubCollection = validStatusColl
 
-		controller := NewCacheController(&collector, &reader, nil)
+		controller := NewCacheController(&collector, nil, &reader, nil)
 
 		var req reconcile.Request
 		req.Name = name
@@ -112,6 +164,7 @@ func TestCacheController_Reconcile(t *testing.T) {
 
 		require.Equal(t, collector.StubCollection, controller.Collect(ctx, key))
 		require.Equal(t, pods, collector.GotPods)
+		require.False(t, collector.GotNetInfo)
 
 		opts := reader.ListOpts
 		require.Len(t, opts, 2)
@@ -138,7 +191,7 @@ func TestCacheController_Reconcile(t *testing.T) {
 		var collector mockCollector
 		collector.StubCollection = validStatusColl[:1]
 
-		controller := NewCacheController(&collector, reader, nil)
+		controller := NewCacheController(&collector, nil, reader, nil)
 
 		var req reconcile.Request
 		req.Name = name
@@ -166,7 +219,7 @@ func TestCacheController_Reconcile(t *testing.T) {
 		var collector mockCollector
 		collector.StubCollection = make(StatusCollection, 1)
 
-		controller := NewCacheController(&collector, &reader, nil)
+		controller := NewCacheController(&collector, nil, &reader, nil)
 		key := client.ObjectKey{Name: name, Namespace: namespace}
 		require.Empty(t, controller.Collect(ctx, key))
 		require.Empty(t, controller.SyncedPods(ctx, key))
@@ -203,7 +256,7 @@ func TestCacheController_SyncedPods(t *testing.T) {
 	}
 	reader.ListPods = pods
 
-	controller := NewCacheController(&collector, reader, nil)
+	controller := NewCacheController(&collector, nil, reader, nil)
 
 	var req reconcile.Request
 	req.Name = name
@@ -259,3 +312,173 @@ func TestCacheController_SyncedPods(t *testing.T) {
 	require.Zero(t, listOpt.Limit)
 	require.Equal(t, ".metadata.controller=axelar", listOpt.FieldSelector.String())
 }
+
+func TestCacheController_ReferenceHeight(t *testing.T) {
+	t.Parallel()
+
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx := context.Background()
+	const namespace = "default"
+
+	var crd cosmosv1.CosmosFullNode
+	crd.Name = "cosmoshub"
+	crd.Namespace = namespace
+	crd.Spec.ChainSpec.ChainID = "cosmoshub-4"
+	crd.Spec.ChainSpec.Reference = &cosmosv1.ReferenceSpec{
+		RPCEndpoints: []string{"https://rpc.example.com:443"},
+		FullNode:     "cosmoshub-archive",
+	}
+
+	reader := new(mockReader)
+	reader.GetSpec = crd.Spec
+	reader.ListPods = []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "1"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "2"}},
+	}
+	collector := &mockCollector{StubHeight: 100}
+
+	controller := NewCacheController(collector, nil, reader, nil)
+	_, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&crd)})
+	require.NoError(t, err)
+
+	// The referenced CosmosFullNode has no status yet.
+	require.Eventually(t, func() bool {
+		return controller.ReferenceHeight(ctx, &crd) == 100
+	}, time.Second, time.Millisecond)
+
+	collector.mu.Lock()
+	require.Equal(t, "cosmoshub-4", collector.GotChainID)
+	require.Equal(t, []string{"https://rpc.example.com:443"}, collector.GotRPCHosts)
+	collector.mu.Unlock()
+
+	var trusted, otherChain CometStatus
+	trusted.Result.NodeInfo.Network = "cosmoshub-4"
+	trusted.Result.SyncInfo.LatestBlockHeight = "120"
+	otherChain.Result.NodeInfo.Network = "theta-testnet-001"
+	otherChain.Result.SyncInfo.LatestBlockHeight = "200"
+
+	archiveKey := client.ObjectKey{Namespace: namespace, Name: "cosmoshub-archive"}
+	controller.cache.Init(archiveKey, func() {})
+	controller.cache.Update(archiveKey, StatusCollection{
+		{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "1"}}, Status: trusted},
+		{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "2"}}, Status: otherChain},
+	})
+	require.EqualValues(t, 120, controller.ReferenceHeight(ctx, &crd))
+
+	crd.Spec.ChainSpec.Reference = nil
+	require.Zero(t, controller.ReferenceHeight(ctx, &crd))
+
+	require.NoError(t, controller.Close())
+}
+
+type pollCollector struct {
+	mu     sync.Mutex
+	height uint64
+	polled map[types.UID]int
+}
+
+func (m *pollCollector) Collect(ctx context.Context, pods []corev1.Pod, _ bool) StatusCollection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.polled == nil {
+		m.polled = make(map[types.UID]int)
+	}
+	var status CometStatus
+	status.Result.SyncInfo.LatestBlockHeight = strconv.FormatUint(m.height, 10)
+	return lo.Map(pods, func(pod corev1.Pod, _ int) StatusItem {
+		m.polled[pod.UID]++
+		return StatusItem{Pod: &pod, Status: status, TS: time.Now()}
+	})
+}
+
+func (m *pollCollector) CollectHeight(ctx context.Context, chainID string, rpcHosts []string) (uint64, error) {
+	return 0, nil
+}
+
+func (m *pollCollector) Polled(uid types.UID) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.polled[uid]
+}
+
+type mockSubscriber struct {
+	Blocks chan NewBlockEvent
+	Break  chan struct{}
+}
+
+func (m *mockSubscriber) SubscribeNewBlock(ctx context.Context, rpcHost string, fn func(NewBlockEvent)) error {
+	if rpcHost != "http://10.0.0.1:26657" {
+		return errors.New("connection refused")
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.Break:
+			return errors.New("broken")
+		case block := <-m.Blocks:
+			fn(block)
+		}
+	}
+}
+
+func TestCacheController_BlockSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "osmosis", Namespace: "default"}
+
+	reader := new(mockReader)
+	reader.ListPods = []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "subscribed"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "broken"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+	}
+	collector := &pollCollector{height: 10}
+	subscriber := &mockSubscriber{Blocks: make(chan NewBlockEvent), Break: make(chan struct{})}
+
+	controller := NewCacheController(collector, subscriber, reader, nil)
+	controller.interval = time.Millisecond
+	controller.resyncInterval = time.Hour
+	controller.retryInterval = time.Hour
+
+	_, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	height := func(uid types.UID) uint64 {
+		item, _ := lo.Find(controller.Collect(ctx, key), func(item StatusItem) bool { return item.Pod.UID == uid })
+		return item.Status.LatestBlockHeight()
+	}
+
+	require.Eventually(t, func() bool {
+		return height("subscribed") == 10 && height("broken") == 10
+	}, time.Second, time.Millisecond)
+
+	blockTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	subscriber.Blocks <- NewBlockEvent{Height: 11, Time: blockTime}
+	require.Eventually(t, func() bool { return height("subscribed") == 11 }, time.Second, time.Millisecond)
+
+	item, _ := lo.Find(controller.Collect(ctx, key), func(item StatusItem) bool { return item.Pod.UID == "subscribed" })
+	require.Empty(t, item.Status.Result.SyncInfo.LatestBlockHash)
+	require.Equal(t, blockTime, item.Status.Result.SyncInfo.LatestBlockTime)
+
+	// The live subscription replaces polling; the broken one falls back to it.
+	polled := collector.Polled("subscribed")
+	brokenPolled := collector.Polled("broken")
+	require.Eventually(t, func() bool { return collector.Polled("broken") > brokenPolled+5 }, time.Second, time.Millisecond)
+	require.Equal(t, polled, collector.Polled("subscribed"))
+	require.EqualValues(t, 11, height("subscribed"))
+
+	// Stale blocks are ignored.
+	subscriber.Blocks <- NewBlockEvent{Height: 9}
+	subscriber.Blocks <- NewBlockEvent{Height: 12}
+	require.Eventually(t, func() bool { return height("subscribed") == 12 }, time.Second, time.Millisecond)
+
+	close(subscriber.Break)
+	require.Eventually(t, func() bool { return collector.Polled("subscribed") > polled }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return height("subscribed") == 10 }, time.Second, time.Millisecond)
+
+	require.NoError(t, controller.Close())
+}
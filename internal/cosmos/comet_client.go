@@ -81,6 +81,61 @@ func (status CometStatus) LatestBlockHeight() uint64 {
 	return h
 }
 
+// EarliestBlockHeight parses the earliest block height string. If the string is malformed, returns 0.
+func (status CometStatus) EarliestBlockHeight() uint64 {
+	h, _ := strconv.ParseUint(status.Result.SyncInfo.EarliestBlockHeight, 10, 64)
+	return h
+}
+
+// CometPeer is a peer connected to the node.
+type CometPeer struct {
+	NodeInfo   NodeInfo `json:"node_info"`
+	IsOutbound bool     `json:"is_outbound"`
+	RemoteIP   string   `json:"remote_ip"`
+}
+
+// CometNetInfo is the response from the /net_info RPC endpoint.
+type CometNetInfo struct {
+	Listening bool        `json:"listening"`
+	NPeers    string      `json:"n_peers"`
+	Peers     []CometPeer `json:"peers"`
+}
+
+// PeerCount parses the number of peers. If the string is malformed, returns the number of listed peers.
+func (info CometNetInfo) PeerCount() int {
+	n, err := strconv.Atoi(info.NPeers)
+	if err != nil {
+		return len(info.Peers)
+	}
+	return n
+}
+
+// ABCIInfo is the response from the /abci_info RPC endpoint. CometBFT gets it from the application, so it
+// shows whether the application is responsive.
+type ABCIInfo struct {
+	Data            string `json:"data"`
+	Version         string `json:"version"`
+	LastBlockHeight string `json:"last_block_height"`
+}
+
+// LastHeight parses the application's last block height. If the string is malformed, returns 0.
+func (info ABCIInfo) LastHeight() uint64 {
+	h, _ := strconv.ParseUint(info.LastBlockHeight, 10, 64)
+	return h
+}
+
+type rpcABCIInfoResponse struct {
+	Result struct {
+		Response ABCIInfo `json:"response"`
+	} `json:"result"`
+}
+
+// rpcCometNetInfoResponse is the union of possible server responses.
+type rpcCometNetInfoResponse struct {
+	Result *CometNetInfo `json:"result"`
+	CometNetInfo
+}
+
 // CometClient knows how to make requests to the CometBFT (formerly Comet) RPC endpoints.
 // This package uses a custom client because 1) parsing JSON is simple and 2) we prevent any dependency on
 // CometBFT packages.
@@ -95,37 +150,64 @@ func NewCometClient(client *http.Client) *CometClient {
 // Status finds the latest status.
 func (client *CometClient) Status(ctx context.Context, rpcHost string) (CometStatus, error) {
 	var status CometStatus
+	var rpcStatusResponse rpcCometStatusResponse
+	if err := client.get(ctx, rpcHost, "status", &rpcStatusResponse); err != nil {
+		return status, err
+	}
+	if rpcStatusResponse.ValidatorInfo != nil {
+		status.Result.ValidatorInfo = *rpcStatusResponse.ValidatorInfo
+		status.Result.SyncInfo = *rpcStatusResponse.SyncInfo
+		status.Result.NodeInfo = *rpcStatusResponse.NodeInfo
+	} else {
+		status.Result.ValidatorInfo = *rpcStatusResponse.Result.ValidatorInfo
+		status.Result.SyncInfo = *rpcStatusResponse.Result.SyncInfo
+		status.Result.NodeInfo = *rpcStatusResponse.Result.NodeInfo
+	}
+	return status, nil
+}
+
+// NetInfo finds the node's connected peers.
+func (client *CometClient) NetInfo(ctx context.Context, rpcHost string) (CometNetInfo, error) {
+	var resp rpcCometNetInfoResponse
+	if err := client.get(ctx, rpcHost, "net_info", &resp); err != nil {
+		return CometNetInfo{}, err
+	}
+	if resp.Result != nil {
+		return *resp.Result, nil
+	}
+	return resp.CometNetInfo, nil
+}
+
+// ABCIInfo finds the application's info.
+func (client *CometClient) ABCIInfo(ctx context.Context, rpcHost string) (ABCIInfo, error) {
+	var resp rpcABCIInfoResponse
+	if err := client.get(ctx, rpcHost, "abci_info", &resp); err != nil {
+		return ABCIInfo{}, err
+	}
+	return resp.Result.Response, nil
+}
+
+func (client *CometClient) get(ctx context.Context, rpcHost, path string, v any) error {
 	u, err := url.ParseRequestURI(rpcHost)
 	if err != nil {
-		return status, fmt.Errorf("malformed host: %w", err)
+		return fmt.Errorf("malformed host: %w", err)
 	}
-	u.Path = "status"
+	u.Path = path
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return status, fmt.Errorf("malformed request: %w", err)
+		return fmt.Errorf("malformed request: %w", err)
 	}
 	req = req.WithContext(ctx)
 	resp, err := client.httpDo(req)
 	if err != nil {
-		return status, err
+		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return status, errors.New(resp.Status)
+		return errors.New(resp.Status)
 	}
-	var rpcStatusResponse rpcCometStatusResponse
-	err = json.NewDecoder(resp.Body).Decode(&rpcStatusResponse)
-	if err != nil {
-		return status, fmt.Errorf("malformed json: %w", err)
-	}
-	if rpcStatusResponse.ValidatorInfo != nil {
-		status.Result.ValidatorInfo = *rpcStatusResponse.ValidatorInfo
-		status.Result.SyncInfo = *rpcStatusResponse.SyncInfo
-		status.Result.NodeInfo = *rpcStatusResponse.NodeInfo
-	} else {
-		status.Result.ValidatorInfo = *rpcStatusResponse.Result.ValidatorInfo
-		status.Result.SyncInfo = *rpcStatusResponse.Result.SyncInfo
-		status.Result.NodeInfo = *rpcStatusResponse.Result.NodeInfo
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
 	}
-	return status, err
+	return nil
 }
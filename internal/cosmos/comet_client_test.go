@@ -119,6 +119,72 @@ func TestCometClient_Status(t *testing.T) {
 	})
 }
 
+func TestCometClient_NetInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		for _, fixture := range []string{netInfoResponseFixture, `{"listening":true,"n_peers":"1","peers":[{"node_info":{"id":"abc"},"remote_ip":"1.2.3.4"}]}`} {
+			client := NewCometClient(http.DefaultClient)
+			client.httpDo = func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, "http://10.2.3.4:26657/net_info", req.URL.String())
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(fixture)),
+				}, nil
+			}
+
+			got, err := client.NetInfo(context.Background(), "http://10.2.3.4:26657")
+			require.NoError(t, err)
+			require.True(t, got.Listening)
+			require.Equal(t, 1, got.PeerCount())
+			require.Equal(t, "abc", got.Peers[0].NodeInfo.ID)
+			require.Equal(t, "1.2.3.4", got.Peers[0].RemoteIP)
+		}
+	})
+
+	t.Run("malformed peer count", func(t *testing.T) {
+		info := CometNetInfo{NPeers: "huh", Peers: make([]CometPeer, 2)}
+		require.Equal(t, 2, info.PeerCount())
+	})
+
+	t.Run("non 200 response", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 500,
+				Status:     "internal server error",
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		_, err := client.NetInfo(context.Background(), "http://10.2.3.4:26657")
+		require.EqualError(t, err, "internal server error")
+	})
+}
+
+const netInfoResponseFixture = `
+{
+  "jsonrpc": "2.0",
+  "id": -1,
+  "result": {
+    "listening": true,
+    "listeners": ["Listener(@)"],
+    "n_peers": "1",
+    "peers": [
+      {
+        "node_info": {
+          "id": "abc",
+          "listen_addr": "tcp://0.0.0.0:26656",
+          "network": "cosmoshub-4",
+          "moniker": "peer"
+        },
+        "is_outbound": true,
+        "remote_ip": "1.2.3.4"
+      }
+    ]
+  }
+}`
+
 const statusResponseFixture = `
 {
   "jsonrpc": "2.0",
@@ -216,3 +282,37 @@ const seiStatusResponseFixture = `
   }
 }
 `
+
+func TestCometClient_ABCIInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "http://10.2.3.4:26657/abci_info", req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"jsonrpc":"2.0","id":-1,"result":{"response":{"data":"Namada","version":"v0.39.0","last_block_height":"123","last_block_app_hash":"AAAA"}}}`)),
+			}, nil
+		}
+
+		got, err := client.ABCIInfo(context.Background(), "http://10.2.3.4:26657")
+		require.NoError(t, err)
+		require.Equal(t, "Namada", got.Data)
+		require.EqualValues(t, 123, got.LastHeight())
+	})
+
+	t.Run("non 200 response", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 500,
+				Status:     "internal server error",
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		_, err := client.ABCIInfo(context.Background(), "http://10.2.3.4:26657")
+		require.EqualError(t, err, "internal server error")
+	})
+}
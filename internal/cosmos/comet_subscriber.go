@@ -0,0 +1,199 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// NewBlockEvent is the header of a block announced by a CometBFT NewBlockHeader event.
+type NewBlockEvent struct {
+	ChainID string
+	Height  uint64
+	Time    time.Time
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e rpcError) Error() string {
+	if e.Data != "" {
+		return fmt.Sprintf("%s (%d): %s", e.Message, e.Code, e.Data)
+	}
+	return fmt.Sprintf("%s (%d)", e.Message, e.Code)
+}
+
+type rpcSubscribeRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  struct {
+		Query string `json:"query"`
+	} `json:"params"`
+}
+
+type rpcNewBlockHeaderResponse struct {
+	Result struct {
+		Data struct {
+			Type  string `json:"type"`
+			Value struct {
+				Header struct {
+					ChainID string    `json:"chain_id"`
+					Height  string    `json:"height"`
+					Time    time.Time `json:"time"`
+				} `json:"header"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+	Error *rpcError `json:"error"`
+}
+
+// NewBlockHeader events only carry the header, unlike NewBlock events which carry the whole block, e.g. txs and
+// evidence.
+const newBlockQuery = "tm.event='NewBlockHeader'"
+
+// CometSubscriber knows how to subscribe to CometBFT events over the RPC websocket endpoint.
+// Like CometClient, it prevents any dependency on CometBFT packages.
+type CometSubscriber struct {
+	dialer      *net.Dialer
+	idleTimeout time.Duration
+}
+
+// NewCometSubscriber returns a valid CometSubscriber.
+// If no event arrives within idleTimeout, the subscription is considered broken. Because CometBFT emits a
+// NewBlockHeader event for every block, idleTimeout should be comfortably longer than the chain's block time.
+func NewCometSubscriber(dialTimeout, idleTimeout time.Duration) *CometSubscriber {
+	return &CometSubscriber{
+		dialer:      &net.Dialer{Timeout: dialTimeout},
+		idleTimeout: idleTimeout,
+	}
+}
+
+// SubscribeNewBlock subscribes to NewBlockHeader events of the node at rpcHost (e.g. http://10.0.0.1:26657) and calls fn
+// for every new block. It blocks until ctx is cancelled or the subscription breaks, and always returns a non-nil error.
+func (s *CometSubscriber) SubscribeNewBlock(ctx context.Context, rpcHost string, fn func(NewBlockEvent)) error {
+	ws, err := s.dial(ctx, rpcHost)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	// Unblock reads if the context is cancelled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = ws.Close()
+		case <-done:
+		}
+	}()
+
+	req := rpcSubscribeRequest{JSONRPC: "2.0", ID: 1, Method: "subscribe"}
+	req.Params.Query = newBlockQuery
+	if err = websocket.JSON.Send(ws, req); err != nil {
+		return s.readErr(ctx, fmt.Errorf("subscribe: %w", err))
+	}
+
+	for {
+		if err = ws.SetReadDeadline(time.Now().Add(s.idleTimeout)); err != nil {
+			return err
+		}
+		var resp rpcNewBlockHeaderResponse
+		if err = websocket.JSON.Receive(ws, &resp); err != nil {
+			return s.readErr(ctx, err)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("subscription error: %w", resp.Error)
+		}
+		// The first response acknowledges the subscription and has no data.
+		if resp.Result.Data.Type == "" {
+			continue
+		}
+		header := resp.Result.Data.Value.Header
+		height, err := strconv.ParseUint(header.Height, 10, 64)
+		if err != nil {
+			return fmt.Errorf("malformed block height %q: %w", header.Height, err)
+		}
+		fn(NewBlockEvent{
+			ChainID: header.ChainID,
+			Height:  height,
+			Time:    header.Time,
+		})
+	}
+}
+
+func (s *CometSubscriber) dial(ctx context.Context, rpcHost string) (*websocket.Conn, error) {
+	u, err := url.ParseRequestURI(rpcHost)
+	if err != nil {
+		return nil, fmt.Errorf("malformed host: %w", err)
+	}
+	origin := *u
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return nil, fmt.Errorf("malformed host: unsupported scheme %q", u.Scheme)
+	}
+	u.Path = "websocket"
+	origin.Path = ""
+
+	cfg, err := websocket.NewConfig(u.String(), origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("malformed host: %w", err)
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "wss" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+	var conn net.Conn
+	if u.Scheme == "wss" {
+		dialer := &tls.Dialer{NetDialer: s.dialer, Config: &tls.Config{ServerName: u.Hostname()}}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = s.dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake does not accept a context, so bound it with the dial timeout.
+	if s.dialer.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.dialer.Timeout))
+	}
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("websocket handshake: %w", err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+func (s *CometSubscriber) readErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("no new block within %s: %w", s.idleTimeout, err)
+	}
+	return err
+}
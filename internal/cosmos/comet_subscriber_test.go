@@ -0,0 +1,201 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+const newBlockEventFixture = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "query": "tm.event='NewBlockHeader'",
+    "data": {
+      "type": "tendermint/event/NewBlockHeader",
+      "value": {
+        "header": {
+          "chain_id": "cosmoshub-4",
+          "height": "%d",
+          "time": "2023-02-27T23:18:%02d.000000000Z"
+        },
+        "result_finalize_block": {}
+      }
+    },
+    "events": {
+      "tm.event": ["NewBlockHeader"]
+    }
+  }
+}`
+
+// fakeCometServer is a local CometBFT websocket server which accepts a single NewBlockHeader subscription per connection.
+type fakeCometServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	gotRequest rpcSubscribeRequest
+	gotPath    string
+}
+
+func newFakeCometServer(t *testing.T, serve func(ws *websocket.Conn)) *fakeCometServer {
+	srv := new(fakeCometServer)
+	srv.Server = httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var req rpcSubscribeRequest
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		srv.mu.Lock()
+		srv.gotRequest = req
+		srv.gotPath = ws.Request().URL.Path
+		srv.mu.Unlock()
+		// Acknowledge the subscription.
+		if _, err := ws.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)); err != nil {
+			return
+		}
+		serve(ws)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func sendNewBlock(ws *websocket.Conn, height int) error {
+	_, err := fmt.Fprintf(ws, newBlockEventFixture, height, height%60)
+	return err
+}
+
+// waitClosed blocks until the client closes the connection.
+func waitClosed(ws *websocket.Conn) {
+	var discard []byte
+	for websocket.Message.Receive(ws, &discard) == nil {
+	}
+}
+
+func TestCometSubscriber_SubscribeNewBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		srv := newFakeCometServer(t, func(ws *websocket.Conn) {
+			for h := 100; h < 103; h++ {
+				if err := sendNewBlock(ws, h); err != nil {
+					return
+				}
+			}
+			waitClosed(ws)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var got []NewBlockEvent
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		err := subscriber.SubscribeNewBlock(ctx, srv.URL, func(block NewBlockEvent) {
+			got = append(got, block)
+			if len(got) == 3 {
+				cancel()
+			}
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, got, 3)
+		require.Equal(t, NewBlockEvent{
+			ChainID: "cosmoshub-4",
+			Height:  100,
+			Time:    time.Date(2023, 2, 27, 23, 18, 40, 0, time.UTC),
+		}, got[0])
+		require.EqualValues(t, 102, got[2].Height)
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		require.Equal(t, "/websocket", srv.gotPath)
+		require.Equal(t, "2.0", srv.gotRequest.JSONRPC)
+		require.Equal(t, "subscribe", srv.gotRequest.Method)
+		require.Equal(t, "tm.event='NewBlockHeader'", srv.gotRequest.Params.Query)
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		srv := newFakeCometServer(t, func(ws *websocket.Conn) {
+			_, _ = ws.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"Server error","data":"subscription was cancelled (reason: CometBFT exited)"}}`))
+			waitClosed(ws)
+		})
+
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		err := subscriber.SubscribeNewBlock(context.Background(), srv.URL, func(NewBlockEvent) {
+			panic("should not be called")
+		})
+
+		require.EqualError(t, err, "subscription error: Server error (-32000): subscription was cancelled (reason: CometBFT exited)")
+	})
+
+	t.Run("no new blocks", func(t *testing.T) {
+		srv := newFakeCometServer(t, waitClosed)
+
+		subscriber := NewCometSubscriber(time.Second, 50*time.Millisecond)
+		err := subscriber.SubscribeNewBlock(context.Background(), srv.URL, func(NewBlockEvent) {
+			panic("should not be called")
+		})
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no new block within 50ms")
+	})
+
+	t.Run("server closes connection", func(t *testing.T) {
+		srv := newFakeCometServer(t, func(ws *websocket.Conn) {
+			_ = sendNewBlock(ws, 1)
+		})
+
+		var calls int
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		err := subscriber.SubscribeNewBlock(context.Background(), srv.URL, func(NewBlockEvent) {
+			calls++
+		})
+
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("malformed block", func(t *testing.T) {
+		srv := newFakeCometServer(t, func(ws *websocket.Conn) {
+			_, _ = ws.Write([]byte(`{"result":{"data":{"type":"tendermint/event/NewBlockHeader","value":{"header":{"height":"nope"}}}}}`))
+			waitClosed(ws)
+		})
+
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		err := subscriber.SubscribeNewBlock(context.Background(), srv.URL, func(NewBlockEvent) {
+			panic("should not be called")
+		})
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `malformed block height "nope"`)
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		srv := httptest.NewServer(nil)
+		host := srv.URL
+		srv.Close()
+
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		err := subscriber.SubscribeNewBlock(context.Background(), host, func(NewBlockEvent) {
+			panic("should not be called")
+		})
+
+		require.Error(t, err)
+		require.False(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("malformed host", func(t *testing.T) {
+		subscriber := NewCometSubscriber(time.Second, time.Minute)
+		for _, host := range []string{"", "10.0.0.1:26657", "tcp://10.0.0.1:26657"} {
+			err := subscriber.SubscribeNewBlock(context.Background(), host, func(NewBlockEvent) {})
+
+			require.Error(t, err, host)
+			require.Contains(t, err.Error(), "malformed host", host)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtstore "github.com/cometbft/cometbft/proto/tendermint/store"
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+const heightSourceTimeout = 5 * time.Second
+
+// HeightSource reads the height a CometBFT node will process next.
+type HeightSource struct {
+	comet   Statuser
+	rpcHost string
+	dataDir string
+	backend string
+}
+
+// NewHeightSource returns a valid HeightSource.
+// If rpcHost is empty, the height is only read from the CometBFT databases in dataDir.
+// Backend is the database backend, one of goleveldb, memdb, rocksdb or pebbledb.
+func NewHeightSource(comet Statuser, rpcHost, dataDir, backend string) HeightSource {
+	return HeightSource{comet: comet, rpcHost: rpcHost, dataDir: dataDir, backend: backend}
+}
+
+// NextHeight returns the height the node will process next.
+// It prefers the running node's rpc, which answers even while the node holds the database locks.
+// Otherwise, it reads CometBFT's state.db, falling back to blockstore.db. Unlike the application database, these are
+// quick to open regardless of the chain's size and are laid out the same for Cosmos SDK and Namada chains.
+func (s HeightSource) NextHeight(ctx context.Context) (int64, error) {
+	var errs []error
+	if s.rpcHost != "" {
+		height, err := s.rpcHeight(ctx)
+		if err == nil {
+			return height, nil
+		}
+		errs = append(errs, fmt.Errorf("rpc: %w", err))
+	}
+	height, err := StateHeight(s.dataDir, s.backend)
+	if err == nil {
+		return height, nil
+	}
+	errs = append(errs, fmt.Errorf("state.db: %w", err))
+	height, err = BlockStoreHeight(s.dataDir, s.backend)
+	if err == nil {
+		return height, nil
+	}
+	errs = append(errs, fmt.Errorf("blockstore.db: %w", err))
+	return 0, errors.Join(errs...)
+}
+
+func (s HeightSource) rpcHeight(ctx context.Context) (int64, error) {
+	cctx, cancel := context.WithTimeout(ctx, heightSourceTimeout)
+	defer cancel()
+	status, err := s.comet.Status(cctx, s.rpcHost)
+	if err != nil {
+		return 0, err
+	}
+	height := status.LatestBlockHeight()
+	if height == 0 {
+		// E.g. the node is state syncing, so the height is unknown.
+		return 0, errors.New("node has no blocks yet")
+	}
+	return int64(height) + 1, nil
+}
+
+var (
+	stateKey      = []byte("stateKey")
+	blockStoreKey = []byte("blockStore")
+
+	// ErrStateNotFound is returned if state.db exists but holds no state.
+	ErrStateNotFound = errors.New("state not found")
+)
+
+// StateHeight returns the height after the last block CometBFT applied to the application.
+// At an upgrade height, the block store may already contain the block the application halted on, but the state
+// does not, so the state is preferred.
+// Returns an error wrapping os.ErrNotExist if there is no state.db.
+func StateHeight(dataDir, backend string) (int64, error) {
+	state, err := loadState(dataDir, backend)
+	if err != nil {
+		return 0, err
+	}
+	return state.LastBlockHeight + 1, nil
+}
+
+// VerifyStateChainID returns an error if CometBFT's state.db belongs to another chain than chainID,
+// e.g. because a snapshot of another network was restored. It returns nil if there is no state yet.
+func VerifyStateChainID(dataDir, backend, chainID string) error {
+	state, err := loadState(dataDir, backend)
+	switch {
+	case errors.Is(err, os.ErrNotExist) || errors.Is(err, ErrStateNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read state.db: %w", err)
+	case chainID != "" && state.ChainID != chainID:
+		return fmt.Errorf("state.db chain ID %q does not match chain ID %q: the chain data belongs to another network", state.ChainID, chainID)
+	}
+	return nil
+}
+
+func loadState(dataDir, backend string) (cmtstate.State, error) {
+	var state cmtstate.State
+	db, err := openExistingDB("state", dataDir, backend)
+	if err != nil {
+		return state, err
+	}
+	defer db.Close()
+	bz, err := db.Get(stateKey)
+	if err != nil {
+		return state, err
+	}
+	if len(bz) == 0 {
+		return state, ErrStateNotFound
+	}
+	if err = state.Unmarshal(bz); err != nil {
+		return state, fmt.Errorf("unmarshal state: %w", err)
+	}
+	return state, nil
+}
+
+// BlockStoreHeight returns the height after the last block in CometBFT's block store.
+// Returns 1 if there is no blockstore.db, because the node has not stored any blocks yet.
+func BlockStoreHeight(dataDir, backend string) (int64, error) {
+	db, err := openExistingDB("blockstore", dataDir, backend)
+	if errors.Is(err, os.ErrNotExist) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	bz, err := db.Get(blockStoreKey)
+	if err != nil {
+		return 0, err
+	}
+	var state cmtstore.BlockStoreState
+	if len(bz) > 0 {
+		if err = state.Unmarshal(bz); err != nil {
+			return 0, fmt.Errorf("unmarshal block store state: %w", err)
+		}
+	}
+	return state.Height + 1, nil
+}
+
+// openExistingDB opens the database, avoiding creating it as a side effect.
+func openExistingDB(name, dataDir, backend string) (dbm.DB, error) {
+	backendType, err := dbBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(filepath.Join(dataDir, name+".db")); err != nil {
+		return nil, err
+	}
+	return dbm.NewDB(name, backendType, dataDir)
+}
+
+func dbBackend(backend string) (dbm.BackendType, error) {
+	switch backend {
+	case "goleveldb":
+		return dbm.GoLevelDBBackend, nil
+	case "memdb":
+		return dbm.MemDBBackend, nil
+	case "rocksdb":
+		return dbm.RocksDBBackend, nil
+	case "pebbledb":
+		return dbm.PebbleDBBackend, nil
+	default:
+		return "", fmt.Errorf("unknown database backend %q", backend)
+	}
+}
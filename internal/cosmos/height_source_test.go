@@ -0,0 +1,130 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtstore "github.com/cometbft/cometbft/proto/tendermint/store"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func writeState(t *testing.T, dataDir string, state cmtstate.State) {
+	t.Helper()
+	bz, err := state.Marshal()
+	require.NoError(t, err)
+	db, err := dbm.NewDB("state", dbm.GoLevelDBBackend, dataDir)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Set(stateKey, bz))
+}
+
+func writeBlockStore(t *testing.T, dataDir string, height int64) {
+	t.Helper()
+	bz, err := (&cmtstore.BlockStoreState{Base: 1, Height: height}).Marshal()
+	require.NoError(t, err)
+	db, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, dataDir)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Set(blockStoreKey, bz))
+}
+
+func newRPCServer(t *testing.T, height string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/status", r.URL.Path)
+		fmt.Fprintf(w, `{"result":{"node_info":{},"sync_info":{"latest_block_height":%q},"validator_info":{}}}`, height)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func downRPCHost(t *testing.T) string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func TestHeightSource_NextHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	comet := NewCometClient(http.DefaultClient)
+
+	t.Run("rpc preferred", func(t *testing.T) {
+		dataDir := t.TempDir()
+		writeState(t, dataDir, cmtstate.State{LastBlockHeight: 50})
+
+		got, err := NewHeightSource(comet, newRPCServer(t, "100"), dataDir, "goleveldb").NextHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 101, got)
+	})
+
+	t.Run("rpc down falls back to state", func(t *testing.T) {
+		dataDir := t.TempDir()
+		writeState(t, dataDir, cmtstate.State{LastBlockHeight: 50})
+		writeBlockStore(t, dataDir, 51)
+
+		got, err := NewHeightSource(comet, downRPCHost(t), dataDir, "goleveldb").NextHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 51, got)
+	})
+
+	t.Run("rpc without blocks falls back to state", func(t *testing.T) {
+		dataDir := t.TempDir()
+		writeState(t, dataDir, cmtstate.State{LastBlockHeight: 50})
+
+		got, err := NewHeightSource(comet, newRPCServer(t, "0"), dataDir, "goleveldb").NextHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 51, got)
+	})
+
+	t.Run("state missing falls back to blockstore", func(t *testing.T) {
+		dataDir := t.TempDir()
+		writeBlockStore(t, dataDir, 40)
+
+		got, err := NewHeightSource(comet, downRPCHost(t), dataDir, "goleveldb").NextHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 41, got)
+
+		_, err = os.Stat(filepath.Join(dataDir, "state.db"))
+		require.ErrorIs(t, err, os.ErrNotExist, "state.db should not be created")
+	})
+
+	t.Run("no blockstore", func(t *testing.T) {
+		dataDir := t.TempDir()
+
+		got, err := NewHeightSource(comet, "", dataDir, "goleveldb").NextHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, got)
+
+		entries, err := os.ReadDir(dataDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := NewHeightSource(comet, "", t.TempDir(), "nope").NextHeight(ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unknown database backend "nope"`)
+	})
+}
+
+func TestVerifyStateChainID(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, VerifyStateChainID(t.TempDir(), "goleveldb", "cosmoshub-4"))
+
+	dataDir := t.TempDir()
+	writeState(t, dataDir, cmtstate.State{ChainID: "theta-testnet-001", LastBlockHeight: 10})
+
+	require.NoError(t, VerifyStateChainID(dataDir, "goleveldb", "theta-testnet-001"))
+
+	err := VerifyStateChainID(dataDir, "goleveldb", "cosmoshub-4")
+	require.EqualError(t, err, `state.db chain ID "theta-testnet-001" does not match chain ID "cosmoshub-4": the chain data belongs to another network`)
+}
@@ -15,8 +15,10 @@ import (
 type StatusItem struct {
 	Pod    *corev1.Pod
 	Status CometStatus
-	TS     time.Time
-	Err    error
+	// The pod's connected peers. Nil if unavailable.
+	NetInfo *CometNetInfo
+	TS      time.Time
+	Err     error
 }
 
 // GetPod returns the pod.
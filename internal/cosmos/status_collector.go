@@ -16,22 +16,32 @@ type Statuser interface {
 	Status(ctx context.Context, rpcHost string) (CometStatus, error)
 }
 
+// NetInfoer calls the RPC net_info endpoint.
+type NetInfoer interface {
+	NetInfo(ctx context.Context, rpcHost string) (CometNetInfo, error)
+}
+
 // StatusCollector collects the CometBFT status of all pods owned by a controller.
 type StatusCollector struct {
 	comet   Statuser
+	netInfo NetInfoer
 	timeout time.Duration
 }
 
 // NewStatusCollector returns a valid StatusCollector.
 // Timeout is exposed here because it is important for good performance in reconcile loops,
 // and reminds callers to set it.
+// If comet is also a NetInfoer, Collect can collect the connected peers too.
 func NewStatusCollector(comet Statuser, timeout time.Duration) *StatusCollector {
-	return &StatusCollector{comet: comet, timeout: timeout}
+	netInfo, _ := comet.(NetInfoer)
+	return &StatusCollector{comet: comet, netInfo: netInfo, timeout: timeout}
 }
 
 // Collect returns a StatusCollection for the given pods.
+// If withNetInfo is true, the connected peers are collected too. Because net_info lists every peer, it is
+// considerably larger than the status, so only request it if needed.
 // Any non-nil error can be treated as transient and retried.
-func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) StatusCollection {
+func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod, withNetInfo bool) StatusCollection {
 	var eg errgroup.Group
 	now := time.Now()
 	statuses := make(StatusCollection, len(pods))
@@ -57,6 +67,12 @@ func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) Stat
 				return nil
 			}
 			statuses[i].Status = resp
+			if withNetInfo && coll.netInfo != nil {
+				// Peers are informational, so an error does not invalidate the status.
+				if info, err := coll.netInfo.NetInfo(cctx, host); err == nil {
+					statuses[i].NetInfo = &info
+				}
+			}
 			return nil
 		})
 	}
@@ -65,3 +81,44 @@ func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) Stat
 	sort.Sort(statuses)
 	return statuses
 }
+
+// CollectHeight returns the maximum height of the nodes at the given RPC endpoints which report the chain ID and
+// are caught up with the chain tip. Returns an error if no node qualifies.
+func (coll StatusCollector) CollectHeight(ctx context.Context, chainID string, rpcHosts []string) (uint64, error) {
+	var (
+		eg      errgroup.Group
+		heights = make([]uint64, len(rpcHosts))
+		errs    = make([]error, len(rpcHosts))
+	)
+	for i := range rpcHosts {
+		i := i
+		eg.Go(func() error {
+			cctx, cancel := context.WithTimeout(ctx, coll.timeout)
+			defer cancel()
+			resp, err := coll.comet.Status(cctx, rpcHosts[i])
+			switch {
+			case err != nil:
+				errs[i] = fmt.Errorf("%s: %w", rpcHosts[i], err)
+			case resp.Result.NodeInfo.Network != chainID:
+				errs[i] = fmt.Errorf("%s: chain ID %q does not match %q", rpcHosts[i], resp.Result.NodeInfo.Network, chainID)
+			case resp.Result.SyncInfo.CatchingUp:
+				errs[i] = fmt.Errorf("%s: catching up", rpcHosts[i])
+			default:
+				heights[i] = resp.LatestBlockHeight()
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	var max uint64
+	for _, h := range heights {
+		if h > max {
+			max = h
+		}
+	}
+	if max == 0 {
+		return 0, errors.Join(errs...)
+	}
+	return max, nil
+}
@@ -20,6 +20,15 @@ func (fn mockStatuser) Status(ctx context.Context, rpcHost string) (CometStatus,
 	return fn(ctx, rpcHost)
 }
 
+type mockCometClient struct {
+	mockStatuser
+	netInfo func(ctx context.Context, rpcHost string) (CometNetInfo, error)
+}
+
+func (m mockCometClient) NetInfo(ctx context.Context, rpcHost string) (CometNetInfo, error) {
+	return m.netInfo(ctx, rpcHost)
+}
+
 var panicStatuser = mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
 	panic("should not be called")
 })
@@ -59,7 +68,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 		})
 
 		coll := NewStatusCollector(cometClient, timeout)
-		got := coll.Collect(ctx, pods)
+		got := coll.Collect(ctx, pods, true)
 
 		require.Len(t, got, 3)
 
@@ -80,7 +89,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 
 	t.Run("no pod IP", func(t *testing.T) {
 		coll := NewStatusCollector(panicStatuser, timeout)
-		got := coll.Collect(ctx, make([]corev1.Pod, 1))
+		got := coll.Collect(ctx, make([]corev1.Pod, 1), true)
 
 		require.Len(t, got, 1)
 
@@ -97,7 +106,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 		coll := NewStatusCollector(cometClient, timeout)
 		var pod corev1.Pod
 		pod.Status.PodIP = "1.1.1.1"
-		got := coll.Collect(ctx, []corev1.Pod{pod})
+		got := coll.Collect(ctx, []corev1.Pod{pod}, true)
 
 		require.Len(t, got, 1)
 
@@ -107,10 +116,92 @@ func TestStatusCollector_Collect(t *testing.T) {
 		require.NotZero(t, got[0].Timestamp())
 	})
 
+	t.Run("net info", func(t *testing.T) {
+		comet := mockCometClient{
+			mockStatuser: func(ctx context.Context, rpcHost string) (CometStatus, error) {
+				return CometStatus{}, nil
+			},
+			netInfo: func(ctx context.Context, rpcHost string) (CometNetInfo, error) {
+				if rpcHost == "http://2.2.2.2:26657" {
+					return CometNetInfo{}, errors.New("net info error")
+				}
+				return CometNetInfo{NPeers: "7"}, nil
+			},
+		}
+		coll := NewStatusCollector(comet, timeout)
+		pods := make([]corev1.Pod, 2)
+		pods[0].Status.PodIP = "1.1.1.1"
+		pods[0].Annotations = map[string]string{kube.OrdinalAnnotation: "0"}
+		pods[1].Status.PodIP = "2.2.2.2"
+		pods[1].Annotations = map[string]string{kube.OrdinalAnnotation: "1"}
+		got := coll.Collect(ctx, pods, true)
+
+		require.Len(t, got, 2)
+		require.Equal(t, 7, got[0].NetInfo.PeerCount())
+		// Status is still valid without net info.
+		_, err := got[1].GetStatus()
+		require.NoError(t, err)
+		require.Nil(t, got[1].NetInfo)
+
+		got = coll.Collect(ctx, pods, false)
+		require.Len(t, got, 2)
+		require.Nil(t, got[0].NetInfo)
+	})
+
 	t.Run("no pods", func(t *testing.T) {
 		coll := NewStatusCollector(panicStatuser, timeout)
-		got := coll.Collect(ctx, nil)
+		got := coll.Collect(ctx, nil, true)
 
 		require.Empty(t, got)
 	})
 }
+
+func TestStatusCollector_CollectHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const chainID = "cosmoshub-4"
+
+	newStatus := func(network, height string, catchingUp bool) CometStatus {
+		var status CometStatus
+		status.Result.NodeInfo.Network = network
+		status.Result.SyncInfo.LatestBlockHeight = height
+		status.Result.SyncInfo.CatchingUp = catchingUp
+		return status
+	}
+	comet := mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+		_, ok := ctx.Deadline()
+		if !ok {
+			panic("no deadline")
+		}
+		switch rpcHost {
+		case "https://trusted-1":
+			return newStatus(chainID, "100", false), nil
+		case "https://trusted-2":
+			return newStatus(chainID, "105", false), nil
+		case "https://catching-up":
+			return newStatus(chainID, "500", true), nil
+		case "https://other-chain":
+			return newStatus("theta-testnet-001", "900", false), nil
+		}
+		return CometStatus{}, errors.New("boom")
+	})
+	coll := NewStatusCollector(comet, time.Second)
+
+	t.Run("happy path", func(t *testing.T) {
+		got, err := coll.CollectHeight(ctx, chainID, []string{
+			"https://trusted-1", "https://trusted-2", "https://catching-up", "https://other-chain", "https://down",
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 105, got)
+	})
+
+	t.Run("no trusted nodes", func(t *testing.T) {
+		got, err := coll.CollectHeight(ctx, chainID, []string{"https://catching-up", "https://other-chain", "https://down"})
+		require.Zero(t, got)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "https://catching-up: catching up")
+		require.Contains(t, err.Error(), `https://other-chain: chain ID "theta-testnet-001" does not match "cosmoshub-4"`)
+		require.Contains(t, err.Error(), "https://down: boom")
+	})
+}
@@ -32,9 +32,19 @@ func DownloadAddrbookCommand(cfg cosmosv1.ChainSpec) (string, []string) {
 	case cfg.AddrbookScript != nil:
 		args = append(args, fmt.Sprintf(addrbookScriptWrapper, *cfg.AddrbookScript))
 	case cfg.AddrbookURL != nil:
-		args = append(args, fmt.Sprintf(addrbookScriptWrapper, scriptDownloadAddrbook), "-s", *cfg.AddrbookURL)
+		args = append(args, fmt.Sprintf(addrbookScriptWrapper, scriptDownloadAddrbook), "-s")
+		args = append(args, addrbookURLs(cfg)...)
 	default:
 		args = append(args, "echo Using default address book")
 	}
 	return "sh", args
 }
+
+// addrbookURLs returns the URLs the address book download tries in order.
+// Returns nil if the address book is not downloaded from a URL.
+func addrbookURLs(cfg cosmosv1.ChainSpec) []string {
+	if cfg.AddrbookScript != nil || cfg.AddrbookURL == nil {
+		return nil
+	}
+	return append([]string{*cfg.AddrbookURL}, cfg.AddrbookFallbackURLs...)
+}
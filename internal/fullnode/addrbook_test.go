@@ -50,6 +50,16 @@ func TestDownloadAddrbookCommand(t *testing.T) {
 		require.Equal(t, "https://example.com/addrbook.json", args[3])
 	})
 
+	t.Run("download with fallbacks", func(t *testing.T) {
+		cfg := cosmosv1.ChainSpec{
+			AddrbookURL:          ptr("https://example.com/addrbook.json"),
+			AddrbookFallbackURLs: []string{"https://mirror.com/addrbook.json"},
+		}
+		_, args := DownloadAddrbookCommand(cfg)
+
+		require.Equal(t, []string{"-s", "https://example.com/addrbook.json", "https://mirror.com/addrbook.json"}, args[2:])
+	})
+
 	t.Run("custom", func(t *testing.T) {
 		cfg := cosmosv1.ChainSpec{
 			// Keeping this to assert that custom script takes precedence.
@@ -1,8 +1,11 @@
 package fullnode
 
 import (
+	"fmt"
+
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/diff"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -12,7 +15,8 @@ const (
 )
 
 // BuildPods creates the final state of pods given the crd.
-func BuildPods(crd *cosmosv1.CosmosFullNode, cksums ConfigChecksums) ([]diff.Resource[*corev1.Pod], error) {
+// A pruner pod which cannot be built is skipped and recorded as a warning event, so other pods still reconcile.
+func BuildPods(reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, cksums ConfigChecksums) ([]diff.Resource[*corev1.Pod], error) {
 	var (
 		builder   = NewPodBuilder(crd)
 		overrides = crd.Spec.InstanceOverrides
@@ -29,7 +33,12 @@ func BuildPods(crd *cosmosv1.CosmosFullNode, cksums ConfigChecksums) ([]diff.Res
 		}
 
 		// If current pod's pvc should be pruned, it'll automatically change current pod into pruningPod.
-		if prunerPod := podPruner(crd, pod).BuildPruningContainer(crd); prunerPod != nil {
+		prunerPod, err := podPruner(crd, pod).BuildPruningContainer(crd)
+		if err != nil {
+			reporter.RecordError("PruningPodBuild", fmt.Errorf("pod %s: %w", pod.Name, err))
+			continue
+		}
+		if prunerPod != nil {
 			pods = append(pods, diff.Adapt(prunerPod, i))
 			continue
 		}
@@ -48,21 +57,13 @@ func BuildPods(crd *cosmosv1.CosmosFullNode, cksums ConfigChecksums) ([]diff.Res
 			}
 		}
 
-		if len(crd.Spec.ChainSpec.Versions) > 0 {
-			instanceHeight := uint64(0)
-			if height, ok := crd.Status.Height[pod.Name]; ok {
-				instanceHeight = height
-			}
-			var image string
-			for _, version := range crd.Spec.ChainSpec.Versions {
-				if instanceHeight < version.UpgradeHeight {
-					break
-				}
-				image = version.Image
-			}
-			if image != "" {
-				setChainContainerImage(pod, image)
-			}
+		// The pod is not recreated until the crd's spec changes.
+		if CrashLoopDisabled(crd, pod.Name) {
+			continue
+		}
+
+		if version, ok := VersionForHeight(crd.Spec.ChainSpec.Versions, crd.Status.Height[pod.Name]); ok && version.Image != "" {
+			setChainContainerImage(pod, version.Image)
 		}
 		if o, ok := overrides[pod.Name]; ok {
 			if o.DisableStrategy != nil {
@@ -119,6 +120,11 @@ func podPruner(crd *cosmosv1.CosmosFullNode, pod *corev1.Pod) *PrunerPod {
 		return nil
 	}
 	for _, p := range pruningStatus.Candidates {
+		switch p.Phase {
+		case cosmosv1.CosmosPruningPhaseRestorePod, cosmosv1.CosmosPruningPhaseConfirmPodRestoration:
+			// Pruning finished, so the pod is restored.
+			continue
+		}
 		if pod.Name == p.PodName && pod.Namespace == p.Namespace {
 			prunerPod := PrunerPod(*pod)
 			return ptr(prunerPod)
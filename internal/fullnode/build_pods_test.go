@@ -40,7 +40,7 @@ func TestBuildPods(t *testing.T) {
 			cksums[client.ObjectKey{Namespace: crd.Namespace, Name: fmt.Sprintf("agoric-%d", i)}] = strconv.Itoa(i)
 		}
 
-		pods, err := BuildPods(crd, cksums)
+		pods, err := BuildPods(nopReporter, crd, cksums)
 		require.NoError(t, err)
 		require.Equal(t, 5, len(pods))
 
@@ -85,7 +85,7 @@ func TestBuildPods(t *testing.T) {
 			cksums[client.ObjectKey{Namespace: crd.Namespace, Name: fmt.Sprintf("agoric-%d", i)}] = strconv.Itoa(i)
 		}
 
-		pods, err := BuildPods(crd, cksums)
+		pods, err := BuildPods(nopReporter, crd, cksums)
 		require.NoError(t, err)
 		require.Equal(t, 5, len(pods))
 
@@ -130,7 +130,7 @@ func TestBuildPods(t *testing.T) {
 			},
 		}
 
-		pods, err := BuildPods(crd, nil)
+		pods, err := BuildPods(nopReporter, crd, nil)
 		require.NoError(t, err)
 		require.Equal(t, 4, len(pods))
 
@@ -172,7 +172,7 @@ func TestBuildPods(t *testing.T) {
 			},
 		}
 
-		pods, err := BuildPods(crd, nil)
+		pods, err := BuildPods(nopReporter, crd, nil)
 		require.NoError(t, err)
 		require.Equal(t, 4, len(pods))
 
@@ -213,25 +213,91 @@ func TestBuildPods(t *testing.T) {
 			Status: cosmosv1.FullNodeStatus{
 				SelfHealing: cosmosv1.SelfHealingStatus{
 					CosmosPruningStatus: ptr(cosmosv1.CosmosPruningStatus{
-						Candidates: map[string]cosmosv1.SelfHealingCandidate{
-							"some.pruning.pods.1": {PodName: "agoric-1", Namespace: "default"},
+						Candidates: map[string]cosmosv1.PruningCandidate{
+							"some.pruning.pods.1": {
+								SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "agoric-1", Namespace: "default"},
+								Phase:                cosmosv1.CosmosPruningPhaseWaitingForComplete,
+							},
+							"some.pruning.pods.3": {
+								SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "agoric-3", Namespace: "default"},
+								Phase:                cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+							},
+							"some.pruning.pods.4": {
+								SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "agoric-4", Namespace: "default"},
+								Phase:                cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+							},
 						},
 					}),
 				},
 			},
 		}
 
-		pods, err := BuildPods(crd, nil)
+		pods, err := BuildPods(nopReporter, crd, nil)
 		require.NoError(t, err)
 		require.Equal(t, 6, len(pods))
 
-		want := lo.Map([]string{"0", "1-pruner", "2", "3", "4", "5"}, func(i string, _ int) string {
+		want := lo.Map([]string{"0", "1-pruner", "2", "3-pruner", "4", "5"}, func(i string, _ int) string {
 			return fmt.Sprintf("agoric-%s", i)
 		})
 		got := lo.Map(pods, func(pod diff.Resource[*corev1.Pod], _ int) string { return pod.Object().Name })
 		require.Equal(t, want, got)
 
-		require.Equal(t, pruningImage, pods[1].Object().Spec.Containers[0].Image)
+		pruner := pods[1].Object().Spec.Containers[0]
+		require.Equal(t, pruningImage, pruner.Image)
+		require.Equal(t, []string{"/bin/sh"}, pruner.Command)
+		require.Contains(t, pruner.Args[1], "sh -c 'cosmos-pruner compact /home/operator/cosmos/data --backend=goleveldb' ")
+		require.Contains(t, pruner.Args[1], "> /dev/termination-log")
+		require.Equal(t, corev1.TerminationMessageFallbackToLogsOnError, pruner.TerminationMessagePolicy)
+	})
+
+	t.Run("unsupported pruning strategy", func(t *testing.T) {
+		crd := &cosmosv1.CosmosFullNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "namada",
+				Namespace: "default",
+			},
+			Spec: cosmosv1.FullNodeSpec{
+				Replicas: 3,
+				ChainSpec: cosmosv1.ChainSpec{
+					ChainID:    "namada-dryrun.abaaeaf7b78cb3ac",
+					ChainType:  chainTypeNamada,
+					GenesisURL: ptr("https://example.com/namada-genesis.tar.gz"),
+					CometBFT:   &cosmosv1.CometBFTConfig{},
+					Namada:     &cosmosv1.NamadaConfig{},
+				},
+				SelfHeal: ptr(cosmosv1.SelfHealSpec{
+					PruningSpec: ptr(cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneAppState}),
+				}),
+			},
+			Status: cosmosv1.FullNodeStatus{
+				SelfHealing: cosmosv1.SelfHealingStatus{
+					CosmosPruningStatus: ptr(cosmosv1.CosmosPruningStatus{
+						Candidates: map[string]cosmosv1.PruningCandidate{
+							"some.pruning.pods.1": {
+								SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "namada-1", Namespace: "default"},
+								Phase:                cosmosv1.CosmosPruningPhaseWaitingForComplete,
+							},
+						},
+					}),
+				},
+			},
+		}
+
+		var reporter mockReporter
+		pods, err := BuildPods(&reporter, crd, nil)
+		require.NoError(t, err)
+
+		got := lo.Map(pods, func(pod diff.Resource[*corev1.Pod], _ int) string { return pod.Object().Name })
+		require.Equal(t, []string{"namada-0", "namada-2"}, got)
+		require.Equal(t, []string{"PruningPodBuild: pod namada-1: pruning strategy PruneAppState is not supported for chain type namada"}, reporter.Errors)
+
+		crd.Spec.SelfHeal.PruningSpec.PruningCommand = "echo custom"
+		pods, err = BuildPods(nopReporter, crd, nil)
+		require.NoError(t, err)
+
+		got = lo.Map(pods, func(pod diff.Resource[*corev1.Pod], _ int) string { return pod.Object().Name })
+		require.Equal(t, []string{"namada-0", "namada-1-pruner", "namada-2"}, got)
+		require.Equal(t, PRUNING_POD_IMAGE_DEFAULT, pods[1].Object().Spec.Containers[0].Image)
 	})
 
 	t.Run("regen pvc pod candidates", func(t *testing.T) {
@@ -257,7 +323,7 @@ func TestBuildPods(t *testing.T) {
 			},
 		}
 
-		pods, err := BuildPods(crd, nil)
+		pods, err := BuildPods(nopReporter, crd, nil)
 		require.NoError(t, err)
 		require.Equal(t, 4, len(pods))
 
@@ -267,4 +333,35 @@ func TestBuildPods(t *testing.T) {
 		got := lo.Map(pods, func(pod diff.Resource[*corev1.Pod], _ int) string { return pod.Object().Name })
 		require.Equal(t, want, got)
 	})
+
+	t.Run("crash loop disabled pods", func(t *testing.T) {
+		cometConfig := cosmosv1.CometBFTConfig{}
+		appConfig := cosmosv1.SDKAppConfig{}
+		crd := &cosmosv1.CosmosFullNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "agoric",
+			},
+			Spec: cosmosv1.FullNodeSpec{
+				Replicas: 3,
+				ChainSpec: cosmosv1.ChainSpec{
+					CometBFT:  &cometConfig,
+					CosmosSDK: &appConfig,
+				},
+			},
+			Status: cosmosv1.FullNodeStatus{
+				SelfHealing: cosmosv1.SelfHealingStatus{
+					CrashLoop: map[string]*cosmosv1.CrashLoopStatus{
+						"agoric-1": {Remedy: cosmosv1.CrashLoopRemedyDisableInstance},
+						"agoric-2": {Remedy: cosmosv1.CrashLoopRemedyAlertOnly},
+					},
+				},
+			},
+		}
+
+		pods, err := BuildPods(nopReporter, crd, nil)
+		require.NoError(t, err)
+
+		got := lo.Map(pods, func(pod diff.Resource[*corev1.Pod], _ int) string { return pod.Object().Name })
+		require.Equal(t, []string{"agoric-0", "agoric-2"}, got)
+	})
 }
@@ -217,7 +217,7 @@ func addCosmosConfigToml(config *blockchain_toml.CosmosConfigFile, crd *cosmosv1
 	privateIDs = commaDelimited(&privateIDStr, config.P2P.PrivatePeerIds)
 	config.P2P.PrivatePeerIds = &privateIDs
 
-	persistentPeers = commaDelimited(&privatePeerStr, config.P2P.PersistentPeers)
+	persistentPeers = commaDelimited(&privatePeerStr, config.P2P.PersistentPeers, injectedPeers(crd, instance))
 	config.P2P.PersistentPeers = &persistentPeers
 
 	unconditionalIDs = commaDelimited(&privateIDStr, config.P2P.UnconditionalPeerIds)
@@ -342,7 +342,7 @@ func addNamadaConfigToml(config *blockchain_toml.NamadaConfigFile, crd *cosmosv1
 	privateIDs = commaDelimited(&privateIDStr, config.Ledger.Cometbft.P2P.PrivatePeerIds)
 	config.Ledger.Cometbft.P2P.PrivatePeerIds = &privateIDs
 
-	persistentPeers = commaDelimited(&privatePeerStr, config.Ledger.Cometbft.P2P.PersistentPeers)
+	persistentPeers = commaDelimited(&privatePeerStr, config.Ledger.Cometbft.P2P.PersistentPeers, injectedPeers(crd, instance))
 	config.Ledger.Cometbft.P2P.PersistentPeers = &persistentPeers
 
 	unconditionalIDs = commaDelimited(&privateIDStr, config.Ledger.Cometbft.P2P.UnconditionalPeerIds)
@@ -217,6 +217,28 @@ func TestBuildConfigMaps(t *testing.T) {
 			}
 		})
 
+		t.Run("injected peers", func(t *testing.T) {
+			starved := crd.DeepCopy()
+			starved.Spec.Replicas = 2
+			starved.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+				"osmosis-1": {InjectedPeers: []string{"aaa@4.4.4.4:26656", "bbb@5.5.5.5:26656"}},
+			}
+			cms, err := BuildConfigMaps(starved, nil)
+			require.NoError(t, err)
+			require.Len(t, cms, 2)
+
+			for i, want := range []string{
+				"peer1@1.2.2.2:789,peer2@2.2.2.2:789,peer3@3.2.2.2:789",
+				"peer1@1.2.2.2:789,peer2@2.2.2.2:789,peer3@3.2.2.2:789,aaa@4.4.4.4:26656,bbb@5.5.5.5:26656",
+			} {
+				var got map[string]any
+				_, err = toml.Decode(cms[i].Object().Data["config-overlay.toml"], &got)
+				require.NoError(t, err, i)
+
+				require.Equal(t, want, got["p2p"].(map[string]any)["persistent_peers"], i)
+			}
+		})
+
 		t.Run("validator sentry", func(t *testing.T) {
 			sentry := crd.DeepCopy()
 			sentry.Spec.Type = cosmosv1.Sentry
@@ -0,0 +1,218 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	crashLoopRestartThresholdDefault = 3
+	// Keeps the status small. Termination messages may be up to 4096 bytes.
+	crashLoopMessageMaxLen = 512
+)
+
+type crashLoopPattern struct {
+	reason cosmosv1.CrashLoopReason
+	re     *regexp.Regexp
+}
+
+var builtinCrashLoopPatterns = []crashLoopPattern{
+	// CometBFT: "wrong Block.Header.AppHash. Expected ..., got ..."
+	{reason: cosmosv1.CrashLoopReasonAppHashMismatch, re: regexp.MustCompile(`(?i)wrong Block\.Header\.AppHash|app ?hash mismatch`)},
+	{reason: cosmosv1.CrashLoopReasonConsensusFailure, re: regexp.MustCompile(`CONSENSUS FAILURE`)},
+}
+
+// CrashLoop is a pod crash looping from a known fatal error.
+type CrashLoop struct {
+	PodName string
+	Status  cosmosv1.CrashLoopStatus
+}
+
+// CrashLoopHealer classifies crash looping pods and records the remedy in status.selfHealing.crashLoop.
+type CrashLoopHealer struct {
+	client Lister
+	syncer StatusSyncer
+	now    func() time.Time
+}
+
+func NewCrashLoopHealer(client Lister, syncer StatusSyncer) *CrashLoopHealer {
+	return &CrashLoopHealer{
+		client: client,
+		syncer: syncer,
+		now:    time.Now,
+	}
+}
+
+// Heal classifies pods whose node container crash loops from a known fatal error and returns the newly classified
+// crash loops. The remedy is applied by the CosmosFullNode controller which reads the status:
+// "RestoreSnapshot" adds the pod as a PVC regeneration candidate and "DisableInstance" stops building the pod.
+// In dry-run mode, crash loops are recorded but the remedy is not applied.
+// Entries are removed once the CosmosFullNode's generation changes or, unless disabled, once the pod is ready.
+// Assumes spec.selfHeal.crashLoopHealing is set.
+func (h CrashLoopHealer) Heal(ctx context.Context, crd *cosmosv1.CosmosFullNode) ([]CrashLoop, error) {
+	spec := crd.Spec.SelfHeal.CrashLoopHealing
+	patterns, err := crashLoopPatterns(spec.Patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods corev1.PodList
+	if err = h.client.List(ctx, &pods,
+		client.InNamespace(crd.Namespace),
+		client.MatchingFields{kube.ControllerOwnerField: crd.Name},
+	); err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	var (
+		current  = crd.Status.SelfHealing.CrashLoop
+		resolved []string
+		found    []CrashLoop
+	)
+	for name, status := range current {
+		if status.ObservedGeneration != crd.Generation {
+			resolved = append(resolved, name)
+		}
+	}
+
+	threshold := spec.RestartThreshold
+	if threshold <= 0 {
+		threshold = crashLoopRestartThresholdDefault
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		nodeStatus, ok := nodeContainerStatus(pod)
+		if !ok {
+			continue
+		}
+		if status, ok := current[pod.Name]; ok {
+			if status.ObservedGeneration == crd.Generation && nodeStatus.Ready {
+				resolved = append(resolved, pod.Name)
+			}
+			continue
+		}
+		if nodeStatus.RestartCount < threshold {
+			continue
+		}
+		reason, msg, ok := classifyCrashLoop(patterns, terminationMessage(nodeStatus))
+		if !ok {
+			continue
+		}
+		found = append(found, CrashLoop{
+			PodName: pod.Name,
+			Status: cosmosv1.CrashLoopStatus{
+				Reason:             reason,
+				Message:            msg,
+				RestartCount:       nodeStatus.RestartCount,
+				DetectedAt:         metav1.NewTime(h.now()),
+				Remedy:             crashLoopRemedy(crd),
+				ObservedGeneration: crd.Generation,
+			},
+		})
+	}
+
+	if len(found) == 0 && len(resolved) == 0 {
+		return nil, nil
+	}
+
+	dryRun := DryRun(crd.Spec.SelfHeal, spec.DryRun)
+	update := func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.CrashLoop == nil {
+			status.SelfHealing.CrashLoop = make(map[string]*cosmosv1.CrashLoopStatus)
+		}
+		for _, name := range resolved {
+			delete(status.SelfHealing.CrashLoop, name)
+		}
+		for _, c := range found {
+			c := c
+			status.SelfHealing.CrashLoop[c.PodName] = &c.Status
+			if c.Status.Remedy == cosmosv1.CrashLoopRemedyRestoreSnapshot && !dryRun {
+				addRegenPVCCandidate(status, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: c.PodName, Namespace: crd.Namespace}})
+			}
+		}
+	}
+	update(&crd.Status)
+	return found, h.syncer.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
+
+// crashLoopRemedy returns the remedy to apply. RestoreSnapshot falls back to AlertOnly without an autoDataSource.
+func crashLoopRemedy(crd *cosmosv1.CosmosFullNode) cosmosv1.CrashLoopRemedy {
+	switch remedy := crd.Spec.SelfHeal.CrashLoopHealing.Remedy; remedy {
+	case cosmosv1.CrashLoopRemedyRestoreSnapshot:
+		if crd.Spec.VolumeClaimTemplate.AutoDataSource == nil {
+			return cosmosv1.CrashLoopRemedyAlertOnly
+		}
+		return remedy
+	case cosmosv1.CrashLoopRemedyDisableInstance:
+		return remedy
+	}
+	return cosmosv1.CrashLoopRemedyAlertOnly
+}
+
+func crashLoopPatterns(custom []string) ([]crashLoopPattern, error) {
+	patterns := append([]crashLoopPattern(nil), builtinCrashLoopPatterns...)
+	for _, expr := range custom {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid crash loop pattern %q: %w", expr, err)
+		}
+		patterns = append(patterns, crashLoopPattern{reason: cosmosv1.CrashLoopReasonCustom, re: re})
+	}
+	return patterns, nil
+}
+
+// classifyCrashLoop returns the reason and the first line of the message matching a pattern.
+func classifyCrashLoop(patterns []crashLoopPattern, msg string) (cosmosv1.CrashLoopReason, string, bool) {
+	for _, p := range patterns {
+		for _, line := range strings.Split(msg, "\n") {
+			if !p.re.MatchString(line) {
+				continue
+			}
+			line = strings.TrimSpace(line)
+			if len(line) > crashLoopMessageMaxLen {
+				line = line[:crashLoopMessageMaxLen]
+			}
+			return p.reason, line, true
+		}
+	}
+	return "", "", false
+}
+
+func nodeContainerStatus(pod *corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == mainContainer {
+			return cs, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+func terminationMessage(cs corev1.ContainerStatus) string {
+	if cs.State.Terminated != nil {
+		return cs.State.Terminated.Message
+	}
+	if cs.LastTerminationState.Terminated != nil {
+		return cs.LastTerminationState.Terminated.Message
+	}
+	return ""
+}
+
+// CrashLoopDisabled returns true if the pod is disabled because it crash looped.
+// Pods are never disabled in dry-run mode.
+func CrashLoopDisabled(crd *cosmosv1.CosmosFullNode, podName string) bool {
+	if selfHeal := crd.Spec.SelfHeal; selfHeal != nil && selfHeal.CrashLoopHealing != nil && DryRun(selfHeal, selfHeal.CrashLoopHealing.DryRun) {
+		return false
+	}
+	status := crd.Status.SelfHealing.CrashLoop[podName]
+	return status != nil && status.Remedy == cosmosv1.CrashLoopRemedyDisableInstance
+}
@@ -0,0 +1,239 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCrashLoopHealer_Heal(t *testing.T) {
+	t.Parallel()
+
+	type mockLister = mockClient[*corev1.Pod]
+
+	ctx := context.Background()
+	now := time.Now()
+
+	newCRD := func(spec cosmosv1.CrashLoopHealingSpec) *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "cosmoshub"
+		crd.Namespace = "default"
+		crd.Generation = 2
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{CrashLoopHealing: &spec}
+		return &crd
+	}
+	crashingPod := func(name string, restarts int32, msg string) corev1.Pod {
+		var pod corev1.Pod
+		pod.Name = name
+		pod.Namespace = "default"
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{Name: "healthcheck", Ready: true},
+			{
+				Name:                 "node",
+				RestartCount:         restarts,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: msg}},
+			},
+		}
+		return pod
+	}
+
+	const (
+		appHashLog   = "INF committed state height=100\nERR error in proxyAppConn.FinalizeBlock: wrong Block.Header.AppHash. Expected 1A2B, got 3C4D\npanic: exit"
+		consensusLog = "INF starting\nCONSENSUS FAILURE!!! err=\"invalid nonce\"\n"
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			crashingPod("cosmoshub-0", 5, appHashLog),
+			crashingPod("cosmoshub-1", 3, consensusLog),
+			crashingPod("cosmoshub-2", 2, consensusLog), // below threshold
+			crashingPod("cosmoshub-3", 9, "unknown error"),
+			{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-4"}}, // no container statuses
+		}}
+
+		crd := newCRD(cosmosv1.CrashLoopHealingSpec{Remedy: cosmosv1.CrashLoopRemedyDisableInstance})
+
+		var got cosmosv1.FullNodeStatus
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
+		})
+		healer := NewCrashLoopHealer(&lister, syncer)
+		healer.now = func() time.Time { return now }
+
+		found, err := healer.Heal(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+
+		require.Len(t, lister.GotListOpts, 2)
+		var listOpt client.ListOptions
+		for _, opt := range lister.GotListOpts {
+			opt.ApplyToList(&listOpt)
+		}
+		require.Equal(t, "default", listOpt.Namespace)
+		require.Equal(t, ".metadata.controller=cosmoshub", listOpt.FieldSelector.String())
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			require.Len(t, status.SelfHealing.CrashLoop, 2)
+
+			appHash := status.SelfHealing.CrashLoop["cosmoshub-0"]
+			require.Equal(t, cosmosv1.CrashLoopReasonAppHashMismatch, appHash.Reason)
+			require.Equal(t, "ERR error in proxyAppConn.FinalizeBlock: wrong Block.Header.AppHash. Expected 1A2B, got 3C4D", appHash.Message)
+			require.EqualValues(t, 5, appHash.RestartCount)
+			require.Equal(t, cosmosv1.CrashLoopRemedyDisableInstance, appHash.Remedy)
+			require.EqualValues(t, 2, appHash.ObservedGeneration)
+			require.Equal(t, now.Unix(), appHash.DetectedAt.Unix())
+
+			consensus := status.SelfHealing.CrashLoop["cosmoshub-1"]
+			require.Equal(t, cosmosv1.CrashLoopReasonConsensusFailure, consensus.Reason)
+			require.Equal(t, `CONSENSUS FAILURE!!! err="invalid nonce"`, consensus.Message)
+		}
+		require.True(t, CrashLoopDisabled(crd, "cosmoshub-0"))
+		require.False(t, CrashLoopDisabled(crd, "cosmoshub-2"))
+	})
+
+	t.Run("custom patterns", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			crashingPod("cosmoshub-0", 3, "panic: "+strings.Repeat("x", 1000)),
+		}}
+		crd := newCRD(cosmosv1.CrashLoopHealingSpec{RestartThreshold: 1, Patterns: []string{`^panic:`}})
+
+		healer := NewCrashLoopHealer(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		found, err := healer.Heal(ctx, crd)
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, cosmosv1.CrashLoopReasonCustom, found[0].Status.Reason)
+		require.Len(t, found[0].Status.Message, crashLoopMessageMaxLen)
+		require.Equal(t, cosmosv1.CrashLoopRemedyAlertOnly, found[0].Status.Remedy)
+	})
+
+	t.Run("restore snapshot", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{crashingPod("cosmoshub-0", 3, appHashLog)}}
+
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		})
+
+		crd := newCRD(cosmosv1.CrashLoopHealingSpec{Remedy: cosmosv1.CrashLoopRemedyRestoreSnapshot})
+		found, err := NewCrashLoopHealer(&lister, syncer).Heal(ctx, crd)
+		require.NoError(t, err)
+		// Without an autoDataSource, falls back to alerting.
+		require.Equal(t, cosmosv1.CrashLoopRemedyAlertOnly, found[0].Status.Remedy)
+		require.Nil(t, crd.Status.SelfHealing.RegenPVCStatus)
+
+		crd = newCRD(cosmosv1.CrashLoopHealingSpec{Remedy: cosmosv1.CrashLoopRemedyRestoreSnapshot})
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{}
+		found, err = NewCrashLoopHealer(&lister, syncer).Heal(ctx, crd)
+		require.NoError(t, err)
+		require.Equal(t, cosmosv1.CrashLoopRemedyRestoreSnapshot, found[0].Status.Remedy)
+		require.Equal(t, cosmosv1.RegenPVCPhaseRegeneratingPVC, crd.Status.SelfHealing.RegenPVCStatus.RegenPVCPhase)
+		require.Contains(t, crd.Status.SelfHealing.RegenPVCStatus.Candidates, sourceKey("cosmoshub-0", "default"))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			crashingPod("cosmoshub-0", 3, appHashLog),
+			crashingPod("cosmoshub-1", 3, consensusLog),
+		}}
+
+		crd := newCRD(cosmosv1.CrashLoopHealingSpec{Remedy: cosmosv1.CrashLoopRemedyRestoreSnapshot, DryRun: ptr(true)})
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{}
+		found, err := NewCrashLoopHealer(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		})).Heal(ctx, crd)
+
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		require.Equal(t, cosmosv1.CrashLoopRemedyRestoreSnapshot, found[0].Status.Remedy)
+		require.Len(t, crd.Status.SelfHealing.CrashLoop, 2)
+		require.Nil(t, crd.Status.SelfHealing.RegenPVCStatus)
+
+		crd.Spec.SelfHeal.CrashLoopHealing.Remedy = cosmosv1.CrashLoopRemedyDisableInstance
+		crd.Status.SelfHealing.CrashLoop["cosmoshub-0"].Remedy = cosmosv1.CrashLoopRemedyDisableInstance
+		require.False(t, CrashLoopDisabled(crd, "cosmoshub-0"))
+
+		// The feature's dryRun takes precedence.
+		crd.Spec.SelfHeal.DryRun = true
+		crd.Spec.SelfHeal.CrashLoopHealing.DryRun = ptr(false)
+		require.True(t, CrashLoopDisabled(crd, "cosmoshub-0"))
+	})
+
+	t.Run("resolved", func(t *testing.T) {
+		readyPod := crashingPod("cosmoshub-0", 5, appHashLog)
+		readyPod.Status.ContainerStatuses[1].Ready = true
+
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			readyPod,
+			crashingPod("cosmoshub-1", 5, appHashLog),
+		}}
+
+		crd := newCRD(cosmosv1.CrashLoopHealingSpec{})
+		crd.Status.SelfHealing.CrashLoop = map[string]*cosmosv1.CrashLoopStatus{
+			"cosmoshub-0": {Remedy: cosmosv1.CrashLoopRemedyAlertOnly, ObservedGeneration: 2},
+			"cosmoshub-1": {Remedy: cosmosv1.CrashLoopRemedyAlertOnly, ObservedGeneration: 2},
+			// Disabled pods are removed once the spec changes.
+			"cosmoshub-2": {Remedy: cosmosv1.CrashLoopRemedyDisableInstance, ObservedGeneration: 1},
+			"cosmoshub-3": {Remedy: cosmosv1.CrashLoopRemedyDisableInstance, ObservedGeneration: 2},
+		}
+
+		healer := NewCrashLoopHealer(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		found, err := healer.Heal(ctx, crd)
+
+		require.NoError(t, err)
+		require.Empty(t, found)
+		require.Len(t, crd.Status.SelfHealing.CrashLoop, 2)
+		require.Contains(t, crd.Status.SelfHealing.CrashLoop, "cosmoshub-1")
+		require.Contains(t, crd.Status.SelfHealing.CrashLoop, "cosmoshub-3")
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{crashingPod("cosmoshub-0", 1, appHashLog)}}
+
+		healer := NewCrashLoopHealer(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		found, err := healer.Heal(ctx, newCRD(cosmosv1.CrashLoopHealingSpec{}))
+
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{crashingPod("cosmoshub-0", 3, appHashLog)}}
+
+		healer := NewCrashLoopHealer(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return errors.New("boom")
+		}))
+		_, err := healer.Heal(ctx, newCRD(cosmosv1.CrashLoopHealingSpec{}))
+		require.EqualError(t, err, "boom")
+
+		_, err = healer.Heal(ctx, newCRD(cosmosv1.CrashLoopHealingSpec{Patterns: []string{"("}}))
+		require.ErrorContains(t, err, `invalid crash loop pattern "("`)
+
+		lister.ListErr = errors.New("list boom")
+		_, err = healer.Heal(ctx, newCRD(cosmosv1.CrashLoopHealingSpec{}))
+		require.EqualError(t, err, "list pods: list boom")
+	})
+}
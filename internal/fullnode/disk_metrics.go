@@ -0,0 +1,64 @@
+package fullnode
+
+import (
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	pvcUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Name:      "pvc_used_bytes",
+		Help:      "Bytes used on a CosmosFullNode PVC.",
+	}, []string{"namespace", "fullnode", "pvc"})
+
+	pvcUsedPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Name:      "pvc_used_percent",
+		Help:      "Percentage of disk space used on a CosmosFullNode PVC.",
+	}, []string{"namespace", "fullnode", "pvc"})
+
+	pvcInodesUsedPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Name:      "pvc_inodes_used_percent",
+		Help:      "Percentage of inodes used on a CosmosFullNode PVC.",
+	}, []string{"namespace", "fullnode", "pvc"})
+
+	pvcDirectoryBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Name:      "pvc_directory_bytes",
+		Help:      "Size of a directory in the chain home directory on a CosmosFullNode PVC.",
+	}, []string{"namespace", "fullnode", "pvc", "dir"})
+
+	pvcDirectoryInodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Name:      "pvc_directory_inodes",
+		Help:      "Number of files and directories in a directory in the chain home directory on a CosmosFullNode PVC.",
+	}, []string{"namespace", "fullnode", "pvc", "dir"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(pvcUsedBytes, pvcUsedPercent, pvcInodesUsedPercent, pvcDirectoryBytes, pvcDirectoryInodes)
+}
+
+// RecordDiskUsageMetrics exports usage as prometheus metrics on the operator's metrics endpoint.
+// Metrics of PVCs or directories absent from usage are removed.
+func RecordDiskUsageMetrics(crd *cosmosv1.CosmosFullNode, usage []PVCDiskUsage) {
+	owner := prometheus.Labels{"namespace": crd.Namespace, "fullnode": crd.Name}
+	for _, gauge := range []*prometheus.GaugeVec{pvcUsedBytes, pvcUsedPercent, pvcInodesUsedPercent, pvcDirectoryBytes, pvcDirectoryInodes} {
+		gauge.DeletePartialMatch(owner)
+	}
+
+	for _, u := range usage {
+		pvcUsedBytes.WithLabelValues(crd.Namespace, crd.Name, u.Name).Set(float64(u.UsedBytes))
+		pvcUsedPercent.WithLabelValues(crd.Namespace, crd.Name, u.Name).Set(float64(u.PercentUsed))
+		if u.PercentInodesUsed > 0 {
+			pvcInodesUsedPercent.WithLabelValues(crd.Namespace, crd.Name, u.Name).Set(float64(u.PercentInodesUsed))
+		}
+		for _, dir := range u.Dirs {
+			pvcDirectoryBytes.WithLabelValues(crd.Namespace, crd.Name, u.Name, dir.Path).Set(float64(dir.Bytes))
+			pvcDirectoryInodes.WithLabelValues(crd.Namespace, crd.Name, u.Name, dir.Path).Set(float64(dir.Inodes))
+		}
+	}
+}
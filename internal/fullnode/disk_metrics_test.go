@@ -0,0 +1,48 @@
+package fullnode
+
+import (
+	"testing"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordDiskUsageMetrics(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	crd.Name = "metrics-test"
+	crd.Namespace = "metrics"
+
+	RecordDiskUsageMetrics(&crd, []PVCDiskUsage{
+		{
+			Name:              "pvc-metrics-test-0",
+			UsedBytes:         100,
+			PercentUsed:       10,
+			PercentInodesUsed: 75,
+			Dirs: []healthcheck.DirUsage{
+				{Path: "data", Bytes: 90, Inodes: 7},
+				{Path: "wasm", Bytes: 5, Inodes: 2},
+			},
+		},
+		{Name: "pvc-metrics-test-1", UsedBytes: 200, PercentUsed: 20},
+	})
+
+	require.Equal(t, float64(100), testutil.ToFloat64(pvcUsedBytes.WithLabelValues("metrics", "metrics-test", "pvc-metrics-test-0")))
+	require.Equal(t, float64(20), testutil.ToFloat64(pvcUsedPercent.WithLabelValues("metrics", "metrics-test", "pvc-metrics-test-1")))
+	require.Equal(t, float64(75), testutil.ToFloat64(pvcInodesUsedPercent.WithLabelValues("metrics", "metrics-test", "pvc-metrics-test-0")))
+	require.Equal(t, float64(90), testutil.ToFloat64(pvcDirectoryBytes.WithLabelValues("metrics", "metrics-test", "pvc-metrics-test-0", "data")))
+	require.Equal(t, float64(2), testutil.ToFloat64(pvcDirectoryInodes.WithLabelValues("metrics", "metrics-test", "pvc-metrics-test-0", "wasm")))
+
+	// Stale PVCs and directories are removed.
+	RecordDiskUsageMetrics(&crd, []PVCDiskUsage{
+		{Name: "pvc-metrics-test-0", UsedBytes: 150, PercentUsed: 15, Dirs: []healthcheck.DirUsage{{Path: "data", Bytes: 140}}},
+	})
+
+	owner := map[string]string{"namespace": "metrics", "fullnode": "metrics-test"}
+	require.Equal(t, 1, pvcUsedBytes.DeletePartialMatch(owner))
+	require.Zero(t, pvcInodesUsedPercent.DeletePartialMatch(owner))
+	require.Equal(t, 1, pvcDirectoryBytes.DeletePartialMatch(owner))
+}
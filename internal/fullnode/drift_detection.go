@@ -15,28 +15,47 @@ import (
 )
 
 // DriftDetection detects pods that are lagging behind the latest block height.
+// The latest block height is the maximum height of the synced pods and the trusted nodes in spec.chain.reference.
 type DriftDetection struct {
 	available      func(pods []*corev1.Pod, minReady time.Duration, now time.Time) []*corev1.Pod
 	collector      StatusCollector
 	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int
+	reference      ReferenceHeighter
 }
 
-func NewDriftDetection(collector StatusCollector) DriftDetection {
+func NewDriftDetection(collector StatusCollector, reference ReferenceHeighter) DriftDetection {
 	return DriftDetection{
 		available:      kube.AvailablePods,
 		collector:      collector,
 		computeRollout: kube.ComputeRollout,
+		reference:      reference,
 	}
 }
 
 // LaggingPods returns pods that are lagging behind the latest block height.
 func (d DriftDetection) LaggingPods(ctx context.Context, crd *cosmosv1.CosmosFullNode) []*corev1.Pod {
-	var lagging []*corev1.Pod
 	pods := d.collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+	synced := pods.Synced()
+	lagging := laggingPods(crd, pods, d.reference.ReferenceHeight(ctx, crd))
+
+	avail := d.available(synced.Pods(), 5*time.Second, time.Now())
+	rollout := d.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), len(avail))
+	return lo.Slice(lagging, 0, rollout)
+}
 
+// HealthyPods returns the names of pods that are in-sync and not lagging behind the latest block height.
+func (d DriftDetection) HealthyPods(ctx context.Context, crd *cosmosv1.CosmosFullNode) []string {
+	pods := d.collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+	lagging := lo.SliceToMap(laggingPods(crd, pods, d.reference.ReferenceHeight(ctx, crd)), func(pod *corev1.Pod) (string, bool) { return pod.Name, true })
+	return lo.FilterMap(pods.SyncedPods(), func(pod *corev1.Pod, _ int) (string, bool) {
+		return pod.Name, !lagging[pod.Name]
+	})
+}
+
+func laggingPods(crd *cosmosv1.CosmosFullNode, pods cosmos.StatusCollection, referenceHeight uint64) []*corev1.Pod {
 	synced := pods.Synced()
 
-	lagging = lo.FilterMap(pods, func(item cosmos.StatusItem, _ int) (*corev1.Pod, bool) {
+	lagging := lo.FilterMap(pods, func(item cosmos.StatusItem, _ int) (*corev1.Pod, bool) {
 		itemSyncInfo := crd.Status.SyncInfo[item.GetPod().Name]
 		thresholdTime := crd.Spec.SelfHeal.HeightDriftMitigation.MaxHeightRetentionTime.Duration
 
@@ -47,10 +66,13 @@ func (d DriftDetection) LaggingPods(ctx context.Context, crd *cosmosv1.CosmosFul
 			return item.GetPod(), false
 		}
 	})
-	if len(lagging) == 0 {
+	if len(lagging) == 0 && len(synced) > 0 {
 		maxHeight := lo.MaxBy(synced, func(a cosmos.StatusItem, b cosmos.StatusItem) bool {
 			return a.Status.LatestBlockHeight() > b.Status.LatestBlockHeight()
 		}).Status.LatestBlockHeight()
+		if referenceHeight > maxHeight {
+			maxHeight = referenceHeight
+		}
 
 		thresh := uint64(crd.Spec.SelfHeal.HeightDriftMitigation.ThresholdHeight)
 		lagging = lo.FilterMap(synced, func(item cosmos.StatusItem, _ int) (*corev1.Pod, bool) {
@@ -58,8 +80,5 @@ func (d DriftDetection) LaggingPods(ctx context.Context, crd *cosmosv1.CosmosFul
 			return item.GetPod(), isLagging
 		})
 	}
-
-	avail := d.available(synced.Pods(), 5*time.Second, time.Now())
-	rollout := d.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), len(avail))
-	return lo.Slice(lagging, 0, rollout)
+	return lagging
 }
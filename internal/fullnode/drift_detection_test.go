@@ -16,6 +16,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+type mockReferenceHeighter uint64
+
+func (m mockReferenceHeighter) ReferenceHeight(ctx context.Context, crd *cosmosv1.CosmosFullNode) uint64 {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return uint64(m)
+}
+
 func TestDriftDetection_LaggingPods(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		var crd cosmosv1.CosmosFullNode
@@ -41,7 +50,7 @@ func TestDriftDetection_LaggingPods(t *testing.T) {
 			return coll
 		}}
 
-		detector := NewDriftDetection(collector)
+		detector := NewDriftDetection(collector, mockReferenceHeighter(0))
 
 		for _, tt := range []struct {
 			Threshold uint32
@@ -80,11 +89,45 @@ func TestDriftDetection_LaggingPods(t *testing.T) {
 		}
 	})
 
+	t.Run("reference height", func(t *testing.T) {
+		var crd cosmosv1.CosmosFullNode
+		crd.Spec.Replicas = 2
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{ThresholdHeight: 10},
+		}
+
+		var coll cosmos.StatusCollection = lo.Map(lo.Range(2), func(_, i int) cosmos.StatusItem {
+			return cosmos.StatusItem{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}}}
+		})
+		coll[0].Status.Result.SyncInfo.LatestBlockHeight = "100"
+		coll[1].Status.Result.SyncInfo.LatestBlockHeight = "95"
+
+		collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			return coll
+		}}
+		detector := NewDriftDetection(collector, mockReferenceHeighter(106))
+		detector.available = func(pods []*corev1.Pod, minReady time.Duration, now time.Time) []*corev1.Pod {
+			return pods
+		}
+		detector.computeRollout = func(unavail *intstr.IntOrString, desired, ready int) int {
+			return 2
+		}
+
+		// Both pods fell behind together.
+		got := detector.LaggingPods(context.Background(), &crd)
+		require.Equal(t, []string{"pod-1"}, lo.Map(got, func(pod *corev1.Pod, _ int) string { return pod.Name }))
+
+		detector.reference = mockReferenceHeighter(110)
+		got = detector.LaggingPods(context.Background(), &crd)
+		require.Equal(t, []string{"pod-0", "pod-1"}, lo.Map(got, func(pod *corev1.Pod, _ int) string { return pod.Name }))
+		require.Empty(t, detector.HealthyPods(context.Background(), &crd))
+	})
+
 	t.Run("no pods or replicas", func(t *testing.T) {
 		collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
 			return nil
 		}}
-		detector := NewDriftDetection(collector)
+		detector := NewDriftDetection(collector, mockReferenceHeighter(0))
 
 		var crd cosmosv1.CosmosFullNode
 		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{}
@@ -104,7 +147,7 @@ func TestDriftDetection_LaggingPods(t *testing.T) {
 	//	collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
 	//		return nil
 	//	}}
-	//	detector := NewDriftDetection(collector)
+	//	detector := NewDriftDetection(collector, mockReferenceHeighter(0))
 	//
 	//	var crd cosmosv1.CosmosFullNode
 	//	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{}
@@ -121,3 +164,26 @@ func TestDriftDetection_LaggingPods(t *testing.T) {
 	//	require.Empty(t, got)
 	//})
 }
+
+func TestDriftDetection_HealthyPods(t *testing.T) {
+	var crd cosmosv1.CosmosFullNode
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+		HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{ThresholdHeight: 10},
+	}
+
+	var coll cosmos.StatusCollection = lo.Map(lo.Range(4), func(_, i int) cosmos.StatusItem {
+		return cosmos.StatusItem{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}}}
+	})
+	coll[0].Status.Result.SyncInfo.LatestBlockHeight = "100"
+	coll[1].Status.Result.SyncInfo.LatestBlockHeight = "95"
+	coll[2].Status.Result.SyncInfo.LatestBlockHeight = "80"
+	coll[3].Status.Result.SyncInfo.CatchingUp = true
+
+	collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+		return coll
+	}}
+	detector := NewDriftDetection(collector, mockReferenceHeighter(0))
+
+	got := detector.HealthyPods(context.Background(), &crd)
+	require.Equal(t, []string{"pod-0", "pod-1"}, got)
+}
@@ -0,0 +1,210 @@
+package fullnode
+
+import (
+	"context"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const driftRemediationBackoffDefault = 5 * time.Minute
+
+// DriftAction is a remediation to apply to a lagging pod.
+type DriftAction struct {
+	Pod     *corev1.Pod
+	Action  cosmosv1.DriftRemediationAction
+	Step    int32
+	Attempt int32
+}
+
+// DriftRemediation escalates the remediation of lagging pods through the steps in
+// spec.selfHeal.heightDriftMitigation.remediation.
+type DriftRemediation struct {
+	client StatusSyncer
+	now    func() time.Time
+}
+
+func NewDriftRemediation(client StatusSyncer) *DriftRemediation {
+	return &DriftRemediation{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Remediate returns the remediation due for each lagging pod and records the attempts in
+// status.selfHealing.driftRemediation. Progress of healthy pods is reset.
+// Actions which regenerate the PVC add the pod as a PVC regeneration candidate.
+// The caller is responsible for deleting the returned pods.
+// In dry-run mode, the attempts are not recorded so the returned actions are only reported.
+// Assumes spec.selfHeal.heightDriftMitigation.remediation is set.
+func (r DriftRemediation) Remediate(ctx context.Context, crd *cosmosv1.CosmosFullNode, lagging []*corev1.Pod, healthy []string) ([]DriftAction, error) {
+	var (
+		steps     = crd.Spec.SelfHeal.HeightDriftMitigation.Remediation
+		current   = crd.Status.SelfHealing.DriftRemediation
+		now       = r.now()
+		actions   []DriftAction
+		patches   = make(map[string]*cosmosv1.DriftRemediationStatus)
+		recovered []string
+	)
+
+	for _, name := range healthy {
+		if _, ok := current[name]; ok {
+			recovered = append(recovered, name)
+		}
+	}
+
+	for _, pod := range lagging {
+		status := current[pod.Name]
+		if status == nil {
+			status = &cosmosv1.DriftRemediationStatus{Step: -1}
+		} else {
+			status = status.DeepCopy()
+			if status.Step >= 0 && int(status.Step) < len(steps) &&
+				now.Sub(status.LastAttemptTime.Time) < stepBackoff(steps[status.Step]) {
+				continue
+			}
+		}
+
+		step := nextDriftStep(crd, steps, status)
+		if step != status.Step {
+			status.Step = step
+			status.Attempts = 0
+		}
+		status.Action = steps[step].Action
+		status.Attempts++
+		status.LastAttemptTime = metav1.NewTime(now)
+		status.Applied = false
+
+		patches[pod.Name] = status
+		actions = append(actions, DriftAction{Pod: pod, Action: status.Action, Step: status.Step, Attempt: status.Attempts})
+	}
+
+	if DryRun(crd.Spec.SelfHeal, crd.Spec.SelfHeal.HeightDriftMitigation.DryRun) {
+		return actions, nil
+	}
+
+	if len(patches) == 0 && len(recovered) == 0 {
+		return nil, nil
+	}
+
+	update := func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.DriftRemediation == nil {
+			status.SelfHealing.DriftRemediation = make(map[string]*cosmosv1.DriftRemediationStatus)
+		}
+		for _, name := range recovered {
+			delete(status.SelfHealing.DriftRemediation, name)
+		}
+		for name, patch := range patches {
+			status.SelfHealing.DriftRemediation[name] = patch
+		}
+		for _, action := range actions {
+			switch action.Action {
+			case cosmosv1.DriftRemediationRestoreSnapshot, cosmosv1.DriftRemediationRegeneratePVC:
+				addRegenPVCCandidate(status, action.Pod)
+			}
+		}
+	}
+	update(&crd.Status)
+	return actions, r.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
+
+// nextDriftStep returns the step to apply given the pod's current progress. Steps which do not apply to the crd are
+// skipped. Once all steps are exhausted, the last applicable step repeats.
+func nextDriftStep(crd *cosmosv1.CosmosFullNode, steps []cosmosv1.DriftRemediationStep, status *cosmosv1.DriftRemediationStatus) int32 {
+	last := int32(-1)
+	for i := range steps {
+		if driftActionApplies(crd, steps[i].Action) {
+			last = int32(i)
+		}
+	}
+
+	step := status.Step
+	if step >= 0 && int(step) < len(steps) && status.Attempts < stepAttempts(steps[step]) && driftActionApplies(crd, steps[step].Action) {
+		return step
+	}
+	for i := step + 1; int(i) < len(steps); i++ {
+		if driftActionApplies(crd, steps[i].Action) {
+			return i
+		}
+	}
+	if last < 0 {
+		// No applicable steps, so fall back to the first step.
+		return 0
+	}
+	return last
+}
+
+func driftActionApplies(crd *cosmosv1.CosmosFullNode, action cosmosv1.DriftRemediationAction) bool {
+	if action == cosmosv1.DriftRemediationRestoreSnapshot {
+		return crd.Spec.VolumeClaimTemplate.AutoDataSource != nil
+	}
+	return true
+}
+
+func stepAttempts(step cosmosv1.DriftRemediationStep) int32 {
+	if step.Attempts <= 0 {
+		return 1
+	}
+	return step.Attempts
+}
+
+func stepBackoff(step cosmosv1.DriftRemediationStep) time.Duration {
+	if step.Backoff == nil {
+		return driftRemediationBackoffDefault
+	}
+	return step.Backoff.Duration
+}
+
+func addRegenPVCCandidate(status *cosmosv1.FullNodeStatus, pod *corev1.Pod) {
+	regen := status.SelfHealing.RegenPVCStatus
+	if regen == nil {
+		regen = new(cosmosv1.RegenPVCStatus)
+		status.SelfHealing.RegenPVCStatus = regen
+	}
+	if regen.Candidates == nil {
+		regen.Candidates = make(map[string]cosmosv1.SelfHealingCandidate)
+	}
+	regen.Candidates[sourceKey(pod.Name, pod.Namespace)] = cosmosv1.SelfHealingCandidate{
+		PodName:   pod.Name,
+		Namespace: pod.Namespace,
+	}
+	regen.RegenPVCPhase = cosmosv1.RegenPVCPhaseRegeneratingPVC
+}
+
+// DriftRemediationPending returns the remediation the pod must apply to its chain data on startup, if any.
+// Used by the pod's drift-remediation init container.
+func DriftRemediationPending(crd *cosmosv1.CosmosFullNode, podName string) (cosmosv1.DriftRemediationAction, bool) {
+	status := crd.Status.SelfHealing.DriftRemediation[podName]
+	if status == nil || status.Applied {
+		return "", false
+	}
+	switch status.Action {
+	case cosmosv1.DriftRemediationRefreshPeers, cosmosv1.DriftRemediationStateSync:
+		return status.Action, true
+	}
+	return "", false
+}
+
+// usesDriftRemediationInit returns true if any remediation step modifies chain data or the address book when the pod
+// starts.
+func usesDriftRemediationInit(crd *cosmosv1.CosmosFullNode) bool {
+	if crd.Spec.SelfHeal == nil {
+		return false
+	}
+	if crd.Spec.SelfHeal.PeerHealth != nil {
+		return true
+	}
+	if crd.Spec.SelfHeal.HeightDriftMitigation == nil {
+		return false
+	}
+	for _, step := range crd.Spec.SelfHeal.HeightDriftMitigation.Remediation {
+		switch step.Action {
+		case cosmosv1.DriftRemediationRefreshPeers, cosmosv1.DriftRemediationStateSync:
+			return true
+		}
+	}
+	return false
+}
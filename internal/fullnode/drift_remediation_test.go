@@ -0,0 +1,195 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDriftRemediation_Remediate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	newCRD := func(steps ...cosmosv1.DriftRemediationStep) *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "cosmoshub"
+		crd.Namespace = "default"
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{ThresholdHeight: 10, Remediation: steps},
+		}
+		return &crd
+	}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	steps := []cosmosv1.DriftRemediationStep{
+		{Action: cosmosv1.DriftRemediationRestart, Attempts: 2},
+		{Action: cosmosv1.DriftRemediationRefreshPeers, Backoff: &metav1.Duration{Duration: time.Hour}},
+		{Action: cosmosv1.DriftRemediationRestoreSnapshot},
+		{Action: cosmosv1.DriftRemediationRegeneratePVC},
+	}
+
+	t.Run("escalates", func(t *testing.T) {
+		crd := newCRD(steps...)
+
+		var got cosmosv1.FullNodeStatus
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
+		})
+		remediation := NewDriftRemediation(syncer)
+
+		var (
+			elapsed time.Duration
+			history []cosmosv1.DriftRemediationAction
+		)
+		remediation.now = func() time.Time { return now.Add(elapsed) }
+
+		for i := 0; i < 6; i++ {
+			actions, err := remediation.Remediate(ctx, crd, []*corev1.Pod{pod("cosmoshub-0")}, nil)
+			require.NoError(t, err)
+			require.Len(t, actions, 1)
+			require.Equal(t, "cosmoshub-0", actions[0].Pod.Name)
+			history = append(history, actions[0].Action)
+
+			// Within backoff, nothing happens.
+			elapsed += time.Minute
+			actions, err = remediation.Remediate(ctx, crd, []*corev1.Pod{pod("cosmoshub-0")}, nil)
+			require.NoError(t, err)
+			require.Empty(t, actions)
+
+			elapsed += 2 * time.Hour
+		}
+
+		// RestoreSnapshot is skipped without an autoDataSource and the last step repeats.
+		require.Equal(t, []cosmosv1.DriftRemediationAction{
+			cosmosv1.DriftRemediationRestart,
+			cosmosv1.DriftRemediationRestart,
+			cosmosv1.DriftRemediationRefreshPeers,
+			cosmosv1.DriftRemediationRegeneratePVC,
+			cosmosv1.DriftRemediationRegeneratePVC,
+			cosmosv1.DriftRemediationRegeneratePVC,
+		}, history)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			remediationStatus := status.SelfHealing.DriftRemediation["cosmoshub-0"]
+			require.EqualValues(t, 3, remediationStatus.Step)
+			require.EqualValues(t, 3, remediationStatus.Attempts)
+			require.Equal(t, cosmosv1.DriftRemediationRegeneratePVC, remediationStatus.Action)
+			require.Contains(t, status.SelfHealing.RegenPVCStatus.Candidates, sourceKey("cosmoshub-0", "default"))
+		}
+	})
+
+	t.Run("restore snapshot", func(t *testing.T) {
+		crd := newCRD(steps[2:]...)
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{}
+
+		remediation := NewDriftRemediation(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		actions, err := remediation.Remediate(ctx, crd, []*corev1.Pod{pod("cosmoshub-0")}, nil)
+
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Equal(t, cosmosv1.DriftRemediationRestoreSnapshot, actions[0].Action)
+		require.Equal(t, cosmosv1.RegenPVCPhaseRegeneratingPVC, crd.Status.SelfHealing.RegenPVCStatus.RegenPVCPhase)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		crd := newCRD(steps[2:]...)
+		crd.Spec.SelfHeal.DryRun = true
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{}
+
+		remediation := NewDriftRemediation(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		for i := 0; i < 2; i++ {
+			actions, err := remediation.Remediate(ctx, crd, []*corev1.Pod{pod("cosmoshub-0")}, nil)
+
+			require.NoError(t, err)
+			require.Len(t, actions, 1)
+			require.Equal(t, cosmosv1.DriftRemediationRestoreSnapshot, actions[0].Action)
+		}
+		require.Empty(t, crd.Status.SelfHealing.DriftRemediation)
+		require.Nil(t, crd.Status.SelfHealing.RegenPVCStatus)
+	})
+
+	t.Run("resets healthy pods", func(t *testing.T) {
+		crd := newCRD(steps...)
+		crd.Status.SelfHealing.DriftRemediation = map[string]*cosmosv1.DriftRemediationStatus{
+			"cosmoshub-0": {Step: 1, Action: cosmosv1.DriftRemediationRefreshPeers, Attempts: 1, LastAttemptTime: metav1.NewTime(now)},
+			"cosmoshub-1": {Step: 0, Action: cosmosv1.DriftRemediationRestart, Attempts: 1, LastAttemptTime: metav1.NewTime(now)},
+		}
+
+		var got cosmosv1.FullNodeStatus
+		got.SelfHealing.DriftRemediation = map[string]*cosmosv1.DriftRemediationStatus{
+			"cosmoshub-0": {}, "cosmoshub-1": {},
+		}
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			update(&got)
+			return nil
+		})
+		remediation := NewDriftRemediation(syncer)
+		remediation.now = func() time.Time { return now }
+
+		actions, err := remediation.Remediate(ctx, crd, nil, []string{"cosmoshub-0", "cosmoshub-2"})
+
+		require.NoError(t, err)
+		require.Empty(t, actions)
+		require.Len(t, got.SelfHealing.DriftRemediation, 1)
+		require.Contains(t, got.SelfHealing.DriftRemediation, "cosmoshub-1")
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		remediation := NewDriftRemediation(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		actions, err := remediation.Remediate(ctx, newCRD(steps...), nil, []string{"cosmoshub-0"})
+
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		remediation := NewDriftRemediation(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return errors.New("boom")
+		}))
+		_, err := remediation.Remediate(ctx, newCRD(steps...), []*corev1.Pod{pod("cosmoshub-0")}, nil)
+
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestDriftRemediationPending(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	_, ok := DriftRemediationPending(&crd, "cosmoshub-0")
+	require.False(t, ok)
+
+	crd.Status.SelfHealing.DriftRemediation = map[string]*cosmosv1.DriftRemediationStatus{
+		"cosmoshub-0": {Action: cosmosv1.DriftRemediationStateSync},
+		"cosmoshub-1": {Action: cosmosv1.DriftRemediationRefreshPeers, Applied: true},
+		"cosmoshub-2": {Action: cosmosv1.DriftRemediationRestart},
+	}
+
+	action, ok := DriftRemediationPending(&crd, "cosmoshub-0")
+	require.True(t, ok)
+	require.Equal(t, cosmosv1.DriftRemediationStateSync, action)
+
+	_, ok = DriftRemediationPending(&crd, "cosmoshub-1")
+	require.False(t, ok)
+
+	_, ok = DriftRemediationPending(&crd, "cosmoshub-2")
+	require.False(t, ok)
+}
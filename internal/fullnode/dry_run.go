@@ -0,0 +1,98 @@
+package fullnode
+
+import (
+	"context"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Keeps the status small.
+	dryRunMaxActions = 20
+	// An action planned again within this interval is not recorded again, so the same action planned every
+	// reconcile does not flood status and events.
+	dryRunRepeatInterval = time.Hour
+)
+
+// DryRun returns true if a self-healing feature only records the actions it would take.
+// The feature's dryRun takes precedence over spec.selfHeal.dryRun.
+func DryRun(spec *cosmosv1.SelfHealSpec, feature *bool) bool {
+	if feature != nil {
+		return *feature
+	}
+	return spec != nil && spec.DryRun
+}
+
+// DryRunRecorder records the actions self-healing would take in status.selfHealing.dryRun.
+type DryRunRecorder struct {
+	client StatusSyncer
+	now    func() time.Time
+}
+
+func NewDryRunRecorder(client StatusSyncer) *DryRunRecorder {
+	return &DryRunRecorder{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Record adds the planned actions to status.selfHealing.dryRun and returns the actions which were not recently
+// recorded. The caller should report only the returned actions.
+func (r DryRunRecorder) Record(ctx context.Context, crd *cosmosv1.CosmosFullNode, actions []cosmosv1.DryRunAction) ([]cosmosv1.DryRunAction, error) {
+	var (
+		now     = r.now()
+		planned []cosmosv1.DryRunAction
+	)
+	for _, action := range actions {
+		if recentDryRunAction(crd.Status.SelfHealing.DryRun, action, now) {
+			continue
+		}
+		action.PlannedAt = metav1.NewTime(now)
+		planned = append(planned, action)
+	}
+
+	if len(planned) == 0 {
+		return nil, nil
+	}
+
+	update := func(status *cosmosv1.FullNodeStatus) {
+		recorded := status.SelfHealing.DryRun[:0:0]
+		for _, existing := range status.SelfHealing.DryRun {
+			if !containsDryRunAction(planned, existing) {
+				recorded = append(recorded, existing)
+			}
+		}
+		recorded = append(recorded, planned...)
+		if len(recorded) > dryRunMaxActions {
+			recorded = recorded[len(recorded)-dryRunMaxActions:]
+		}
+		status.SelfHealing.DryRun = recorded
+	}
+	update(&crd.Status)
+	return planned, r.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
+
+func recentDryRunAction(recorded []cosmosv1.DryRunAction, action cosmosv1.DryRunAction, now time.Time) bool {
+	for _, existing := range recorded {
+		if sameDryRunAction(existing, action) && now.Sub(existing.PlannedAt.Time) < dryRunRepeatInterval {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDryRunAction(actions []cosmosv1.DryRunAction, action cosmosv1.DryRunAction) bool {
+	for _, a := range actions {
+		if sameDryRunAction(a, action) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameDryRunAction(a, b cosmosv1.DryRunAction) bool {
+	return a.Feature == b.Feature && a.Target == b.Target && a.Action == b.Action
+}
@@ -0,0 +1,116 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, DryRun(nil, nil))
+	require.True(t, DryRun(nil, ptr(true)))
+	require.False(t, DryRun(&cosmosv1.SelfHealSpec{}, nil))
+	require.True(t, DryRun(&cosmosv1.SelfHealSpec{DryRun: true}, nil))
+	require.False(t, DryRun(&cosmosv1.SelfHealSpec{DryRun: true}, ptr(false)))
+	require.True(t, DryRun(&cosmosv1.SelfHealSpec{}, ptr(true)))
+}
+
+func TestDryRunRecorder_Record(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	newCRD := func() *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "cosmoshub"
+		crd.Namespace = "default"
+		return &crd
+	}
+	restart := func(pod string) cosmosv1.DryRunAction {
+		return cosmosv1.DryRunAction{Feature: "HeightDriftMitigation", Target: pod, Action: "Restart"}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		crd := newCRD()
+		crd.Status.SelfHealing.DryRun = []cosmosv1.DryRunAction{
+			{Feature: "HeightDriftMitigation", Target: "cosmoshub-0", Action: "Restart", PlannedAt: metav1.NewTime(now.Add(-time.Minute))},
+			{Feature: "HeightDriftMitigation", Target: "cosmoshub-1", Action: "Restart", PlannedAt: metav1.NewTime(now.Add(-2 * time.Hour))},
+			{Feature: "PeerHealth", Target: "cosmoshub-2", Action: "Restart", PlannedAt: metav1.NewTime(now.Add(-3 * time.Hour))},
+		}
+
+		var got cosmosv1.FullNodeStatus
+		got.SelfHealing.DryRun = crd.Status.SelfHealing.DryRun
+		recorder := NewDryRunRecorder(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
+		}))
+		recorder.now = func() time.Time { return now }
+
+		planned, err := recorder.Record(ctx, crd, []cosmosv1.DryRunAction{restart("cosmoshub-0"), restart("cosmoshub-1"), restart("cosmoshub-3")})
+		require.NoError(t, err)
+
+		// Recently recorded actions are not reported again.
+		require.Equal(t, []string{"cosmoshub-1", "cosmoshub-3"}, lo.Map(planned, func(a cosmosv1.DryRunAction, _ int) string { return a.Target }))
+		require.Equal(t, now.Unix(), planned[0].PlannedAt.Unix())
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			targets := lo.Map(status.SelfHealing.DryRun, func(a cosmosv1.DryRunAction, _ int) string { return a.Target })
+			require.Equal(t, []string{"cosmoshub-0", "cosmoshub-2", "cosmoshub-1", "cosmoshub-3"}, targets)
+		}
+	})
+
+	t.Run("max actions", func(t *testing.T) {
+		crd := newCRD()
+		actions := make([]cosmosv1.DryRunAction, 25)
+		for i := range actions {
+			actions[i] = restart(fmt.Sprintf("cosmoshub-%d", i))
+		}
+
+		recorder := NewDryRunRecorder(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		planned, err := recorder.Record(ctx, crd, actions)
+
+		require.NoError(t, err)
+		require.Len(t, planned, 25)
+		require.Len(t, crd.Status.SelfHealing.DryRun, dryRunMaxActions)
+		require.Equal(t, "cosmoshub-5", crd.Status.SelfHealing.DryRun[0].Target)
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		crd := newCRD()
+		crd.Status.SelfHealing.DryRun = []cosmosv1.DryRunAction{
+			{Feature: "HeightDriftMitigation", Target: "cosmoshub-0", Action: "Restart", PlannedAt: metav1.NewTime(now)},
+		}
+
+		recorder := NewDryRunRecorder(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		recorder.now = func() time.Time { return now }
+		planned, err := recorder.Record(ctx, crd, []cosmosv1.DryRunAction{restart("cosmoshub-0")})
+
+		require.NoError(t, err)
+		require.Empty(t, planned)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		recorder := NewDryRunRecorder(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return errors.New("boom")
+		}))
+		_, err := recorder.Record(ctx, newCRD(), []cosmosv1.DryRunAction{restart("cosmoshub-0")})
+
+		require.EqualError(t, err, "boom")
+	})
+}
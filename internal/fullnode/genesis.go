@@ -14,9 +14,12 @@ var (
 	scriptUseInitGenesis string
 	//go:embed script/download-genesis-namada.sh
 	scriptDownloadGenesisNamada string
+	//go:embed script/verify-genesis.sh
+	scriptVerifyGenesis string
 )
 
 // If $DATA_DIR is populated, then we assume we have the genesis file.
+// Otherwise, the genesis file is verified after it is initialized, so a node never starts syncing the wrong network.
 const genesisScriptWrapper = `ls $DATA_DIR/*.db 1> /dev/null 2>&1
 DB_INIT=$?
 if [ $DB_INIT -eq 0 ]; then
@@ -26,9 +29,15 @@ fi
 
 %s
 
+%s
+
 echo "Genesis $GENESIS_FILE initialized."
 `
 
+func wrapGenesisScript(script string) string {
+	return fmt.Sprintf(genesisScriptWrapper, script, scriptVerifyGenesis)
+}
+
 // DownloadGenesisCommand returns a proper genesis command for use in an init container.
 //
 // The general strategy is if the user does not configure an external genesis file, use the genesis from the <chain-binary> init command.
@@ -39,11 +48,21 @@ func DownloadGenesisCommand(cfg cosmosv1.ChainSpec) (string, []string) {
 	case cfg.ChainType == chainTypeNamada:
 		args = append(args, scriptDownloadGenesisNamada, *cfg.GenesisURL)
 	case cfg.GenesisScript != nil:
-		args = append(args, fmt.Sprintf(genesisScriptWrapper, *cfg.GenesisScript))
+		args = append(args, wrapGenesisScript(*cfg.GenesisScript))
 	case cfg.GenesisURL != nil:
-		args = append(args, fmt.Sprintf(genesisScriptWrapper, scriptDownloadGenesis), "-s", *cfg.GenesisURL)
+		args = append(args, wrapGenesisScript(scriptDownloadGenesis), "-s")
+		args = append(args, genesisURLs(cfg)...)
 	default:
-		args = append(args, fmt.Sprintf(genesisScriptWrapper, scriptUseInitGenesis))
+		args = append(args, wrapGenesisScript(scriptUseInitGenesis))
 	}
 	return "sh", args
 }
+
+// genesisURLs returns the URLs the genesis download tries in order.
+// Returns nil if the genesis is not downloaded from a URL.
+func genesisURLs(cfg cosmosv1.ChainSpec) []string {
+	if cfg.ChainType == chainTypeNamada || cfg.GenesisScript != nil || cfg.GenesisURL == nil {
+		return nil
+	}
+	return append([]string{*cfg.GenesisURL}, cfg.GenesisFallbackURLs...)
+}
@@ -14,6 +14,8 @@ func TestDownloadGenesisCommand(t *testing.T) {
 		t.Helper()
 		require.NotEmpty(t, script)
 		require.Contains(t, script, `if [ $DB_INIT -eq 0 ]`)
+		require.Contains(t, script, `if [ "$GENESIS_CHAIN_ID" != "$CHAIN_ID" ]`)
+		require.Contains(t, script, `sha256sum "$GENESIS_FILE"`)
 	}
 
 	t.Run("default", func(t *testing.T) {
@@ -51,6 +53,17 @@ func TestDownloadGenesisCommand(t *testing.T) {
 		require.Equal(t, "https://example.com/genesis.json", args[3])
 	})
 
+	t.Run("download with fallbacks", func(t *testing.T) {
+		cfg := cosmosv1.ChainSpec{
+			GenesisURL:          ptr("https://example.com/genesis.json"),
+			GenesisFallbackURLs: []string{"https://mirror1.com/genesis.json.gz", "https://mirror2.com/genesis.tar.gz"},
+		}
+		_, args := DownloadGenesisCommand(cfg)
+
+		require.Equal(t, []string{"-s", "https://example.com/genesis.json", "https://mirror1.com/genesis.json.gz", "https://mirror2.com/genesis.tar.gz"}, args[2:])
+		require.Contains(t, args[1], `for GENESIS_URL in "$@"`)
+	})
+
 	t.Run("custom", func(t *testing.T) {
 		cfg := cosmosv1.ChainSpec{
 			// Keeping this to assert that custom script takes precedence.
@@ -70,4 +83,16 @@ func TestDownloadGenesisCommand(t *testing.T) {
 		require.NotContains(t, got, "GENESIS_URL")
 		require.Contains(t, got, "echo hi")
 	})
+
+	t.Run("namada", func(t *testing.T) {
+		cfg := cosmosv1.ChainSpec{
+			ChainType:     chainTypeNamada,
+			GenesisURL:    ptr("https://example.com/genesis"),
+			GenesisSHA256: ptr("7a49293a495b5cad95a0f334aa30a7088975fdb3800e25d85142f1c36aac345a"),
+		}
+		_, args := DownloadGenesisCommand(cfg)
+
+		// Namada's genesis is a directory of files, so it is not verified.
+		require.NotContains(t, args[1], "GENESIS_CHAIN_ID")
+	})
 }
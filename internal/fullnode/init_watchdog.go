@@ -0,0 +1,231 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	initDeadlineDefault            = 15 * time.Minute
+	snapshotRestoreDeadlineDefault = 12 * time.Hour
+	initMaxFailuresDefault         = 3
+)
+
+// StuckInitContainer is a pod whose init container is stuck.
+type StuckInitContainer struct {
+	Pod       *corev1.Pod
+	Container string
+	Reason    cosmosv1.InitContainerStuckReason
+	// The URL the container downloads from first once the pod restarts. Empty if the container does not download.
+	NextURL string
+}
+
+// InitContainerWatchdog finds pods stuck initializing and records them in status.selfHealing.initContainers.
+type InitContainerWatchdog struct {
+	client Lister
+	syncer StatusSyncer
+	now    func() time.Time
+}
+
+func NewInitContainerWatchdog(client Lister, syncer StatusSyncer) *InitContainerWatchdog {
+	return &InitContainerWatchdog{
+		client: client,
+		syncer: syncer,
+		now:    time.Now,
+	}
+}
+
+// FindStuck returns pods with an init container running past its deadline or failing repeatedly.
+// For download init containers, the status advances to the next URL so the download is retried from it once the pod
+// restarts. The caller is responsible for deleting the returned pods.
+// Once a pod's init containers complete, its retries are reset, keeping the URL offsets so the pod is not recreated
+// with a different URL. Entries for pods which are no longer instances of the CosmosFullNode are removed. Pods
+// deleted because they were stuck keep their entries, because they are recreated with the same name.
+// In dry-run mode, the status is not updated so the returned pods are only reported.
+// Assumes spec.selfHeal.initContainerWatchdog is set.
+func (w InitContainerWatchdog) FindStuck(ctx context.Context, crd *cosmosv1.CosmosFullNode) ([]StuckInitContainer, error) {
+	var pods corev1.PodList
+	if err := w.client.List(ctx, &pods,
+		client.InNamespace(crd.Namespace),
+		client.MatchingFields{kube.ControllerOwnerField: crd.Name},
+	); err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	var (
+		spec    = crd.Spec.SelfHeal.InitContainerWatchdog
+		current = crd.Status.SelfHealing.InitContainers
+		now     = w.now()
+		stuck   []StuckInitContainer
+		patches = make(map[string]*cosmosv1.InitContainerWatchdogStatus)
+		removed []string
+	)
+
+	instances := make(map[string]bool)
+	for i := int32(0); i < crd.Spec.Replicas; i++ {
+		instances[instanceName(crd, i)] = true
+	}
+	for name := range current {
+		if !instances[name] {
+			removed = append(removed, name)
+		}
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		container, reason, ok := stuckInitContainer(spec, pod, now)
+		if !ok {
+			if status := current[pod.Name]; status != nil && status.Retries > 0 && initContainersDone(pod) {
+				if len(status.URLOffsets) == 0 {
+					removed = append(removed, pod.Name)
+					continue
+				}
+				status = status.DeepCopy()
+				status.Retries = 0
+				patches[pod.Name] = status
+			}
+			continue
+		}
+
+		status := crd.Status.SelfHealing.InitContainers[pod.Name].DeepCopy()
+		if status == nil {
+			status = new(cosmosv1.InitContainerWatchdogStatus)
+		}
+		status.Container = container
+		status.Reason = reason
+		status.DetectedAt = metav1.NewTime(now)
+		status.Retries++
+
+		var nextURL string
+		if urls := initDownloadURLs(crd.Spec.ChainSpec, container); len(urls) > 0 {
+			if status.URLOffsets == nil {
+				status.URLOffsets = make(map[string]int32)
+			}
+			offset := (status.URLOffsets[container] + 1) % int32(len(urls))
+			status.URLOffsets[container] = offset
+			nextURL = urls[offset]
+		}
+
+		patches[pod.Name] = status
+		stuck = append(stuck, StuckInitContainer{Pod: pod, Container: container, Reason: reason, NextURL: nextURL})
+	}
+
+	if len(patches) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+	if DryRun(crd.Spec.SelfHeal, spec.DryRun) {
+		return stuck, nil
+	}
+
+	update := func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.InitContainers == nil {
+			status.SelfHealing.InitContainers = make(map[string]*cosmosv1.InitContainerWatchdogStatus)
+		}
+		for _, name := range removed {
+			delete(status.SelfHealing.InitContainers, name)
+		}
+		for name, patch := range patches {
+			status.SelfHealing.InitContainers[name] = patch
+		}
+	}
+	update(&crd.Status)
+	return stuck, w.syncer.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
+
+// stuckInitContainer returns the name of the pod's init container which is running past its deadline or has failed
+// at least maxFailures times.
+func stuckInitContainer(spec *cosmosv1.InitContainerWatchdogSpec, pod *corev1.Pod, now time.Time) (string, cosmosv1.InitContainerStuckReason, bool) {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodPending {
+		return "", "", false
+	}
+	maxFailures := spec.MaxFailures
+	if maxFailures <= 0 {
+		maxFailures = initMaxFailuresDefault
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Ready {
+			continue
+		}
+		if cs.RestartCount >= maxFailures {
+			return cs.Name, cosmosv1.InitContainerRepeatedFailure, true
+		}
+		if cs.State.Running != nil && now.Sub(cs.State.Running.StartedAt.Time) > initDeadline(spec, cs.Name) {
+			return cs.Name, cosmosv1.InitContainerDeadlineExceeded, true
+		}
+	}
+	return "", "", false
+}
+
+// initContainersDone returns true if all of the pod's init containers completed.
+func initContainersDone(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || len(pod.Status.InitContainerStatuses) == 0 {
+		return false
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+func initDeadline(spec *cosmosv1.InitContainerWatchdogSpec, container string) time.Duration {
+	if container == snapshotContainer {
+		if spec.SnapshotRestoreDeadline != nil {
+			return spec.SnapshotRestoreDeadline.Duration
+		}
+		return snapshotRestoreDeadlineDefault
+	}
+	if spec.Deadline != nil {
+		return spec.Deadline.Duration
+	}
+	return initDeadlineDefault
+}
+
+// initDownloadURLs returns the URLs an init container downloads from in order. Returns nil if the container does not
+// download from URLs.
+func initDownloadURLs(cfg cosmosv1.ChainSpec, container string) []string {
+	switch container {
+	case genesisContainer:
+		return genesisURLs(cfg)
+	case addrbookContainer:
+		return addrbookURLs(cfg)
+	case snapshotContainer:
+		return snapshotURLs(cfg)
+	}
+	return nil
+}
+
+// initURLOffset returns the index of the URL the pod's init container downloads from first.
+func initURLOffset(crd *cosmosv1.CosmosFullNode, podName, container string) int {
+	status := crd.Status.SelfHealing.InitContainers[podName]
+	if status == nil {
+		return 0
+	}
+	urls := initDownloadURLs(crd.Spec.ChainSpec, container)
+	offset := int(status.URLOffsets[container])
+	if offset >= len(urls) {
+		// The URLs changed since the offset was recorded.
+		return 0
+	}
+	return offset
+}
+
+// rotateDownloadURLs rotates the download URLs of a download init container so it starts from the URL at offset,
+// trying the remaining URLs in order.
+func rotateDownloadURLs(c *corev1.Container, offset int) {
+	// Download args are: -c <script> -s <url>...
+	const urlStart = 3
+	if offset == 0 || len(c.Args) <= urlStart+offset {
+		return
+	}
+	urls := c.Args[urlStart:]
+	c.Args = append(append(c.Args[:urlStart:urlStart], urls[offset:]...), urls[:offset]...)
+}
@@ -0,0 +1,259 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestInitContainerWatchdog_FindStuck(t *testing.T) {
+	t.Parallel()
+
+	type mockLister = mockClient[*corev1.Pod]
+
+	ctx := context.Background()
+	now := time.Now()
+
+	newCRD := func() *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "cosmoshub"
+		crd.Namespace = "default"
+		crd.Spec.Replicas = 5
+		crd.Spec.ChainSpec.GenesisURL = ptr("https://example.com/genesis.json")
+		crd.Spec.ChainSpec.GenesisFallbackURLs = []string{"https://mirror1.com/genesis.json", "https://mirror2.com/genesis.json"}
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{InitContainerWatchdog: &cosmosv1.InitContainerWatchdogSpec{}}
+		return &crd
+	}
+	initPod := func(name string, statuses ...corev1.ContainerStatus) corev1.Pod {
+		var pod corev1.Pod
+		pod.Name = name
+		pod.Namespace = "default"
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.InitContainerStatuses = append([]corev1.ContainerStatus{{Name: "clean-init", Ready: true}}, statuses...)
+		return pod
+	}
+	running := func(name string, elapsed time.Duration) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-elapsed))}},
+		}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			initPod("cosmoshub-0", running("genesis-init", 16*time.Minute)),
+			initPod("cosmoshub-1", running("genesis-init", 14*time.Minute)),
+			initPod("cosmoshub-2", running("snapshot-restore", 11*time.Hour)),
+			initPod("cosmoshub-3", corev1.ContainerStatus{Name: "chain-init", RestartCount: 3}),
+			{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-4"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		}}
+
+		crd := newCRD()
+		crd.Status.SelfHealing.InitContainers = map[string]*cosmosv1.InitContainerWatchdogStatus{
+			"cosmoshub-0": {Retries: 1, URLOffsets: map[string]int32{"genesis-init": 2, "addrbook-init": 1}},
+		}
+
+		var got cosmosv1.FullNodeStatus
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
+		})
+		watchdog := NewInitContainerWatchdog(&lister, syncer)
+		watchdog.now = func() time.Time { return now }
+
+		stuck, err := watchdog.FindStuck(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, stuck, 2)
+
+		require.Equal(t, "cosmoshub-0", stuck[0].Pod.Name)
+		require.Equal(t, "genesis-init", stuck[0].Container)
+		require.Equal(t, cosmosv1.InitContainerDeadlineExceeded, stuck[0].Reason)
+		// Wraps around to the primary URL.
+		require.Equal(t, "https://example.com/genesis.json", stuck[0].NextURL)
+
+		require.Equal(t, "cosmoshub-3", stuck[1].Pod.Name)
+		require.Equal(t, "chain-init", stuck[1].Container)
+		require.Equal(t, cosmosv1.InitContainerRepeatedFailure, stuck[1].Reason)
+		require.Empty(t, stuck[1].NextURL)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			require.Len(t, status.SelfHealing.InitContainers, 2)
+
+			genesis := status.SelfHealing.InitContainers["cosmoshub-0"]
+			require.Equal(t, "genesis-init", genesis.Container)
+			require.EqualValues(t, 2, genesis.Retries)
+			require.Equal(t, map[string]int32{"genesis-init": 0, "addrbook-init": 1}, genesis.URLOffsets)
+			require.Equal(t, now.Unix(), genesis.DetectedAt.Unix())
+
+			chainInit := status.SelfHealing.InitContainers["cosmoshub-3"]
+			require.EqualValues(t, 1, chainInit.Retries)
+			require.Empty(t, chainInit.URLOffsets)
+		}
+	})
+
+	t.Run("custom deadlines", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			initPod("cosmoshub-0", running("genesis-init", 2*time.Minute)),
+			initPod("cosmoshub-1", running("snapshot-restore", 2*time.Hour)),
+			initPod("cosmoshub-2", corev1.ContainerStatus{Name: "addrbook-init", RestartCount: 1}),
+		}}
+
+		crd := newCRD()
+		crd.Spec.SelfHeal.InitContainerWatchdog = &cosmosv1.InitContainerWatchdogSpec{
+			Deadline:                &metav1.Duration{Duration: time.Minute},
+			SnapshotRestoreDeadline: &metav1.Duration{Duration: time.Hour},
+			MaxFailures:             2,
+		}
+
+		watchdog := NewInitContainerWatchdog(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		watchdog.now = func() time.Time { return now }
+
+		stuck, err := watchdog.FindStuck(ctx, crd)
+		require.NoError(t, err)
+
+		got := lo.Map(stuck, func(s StuckInitContainer, _ int) string { return s.Container })
+		require.Equal(t, []string{"genesis-init", "snapshot-restore"}, got)
+		require.Equal(t, "https://mirror1.com/genesis.json", stuck[0].NextURL)
+	})
+
+	t.Run("completed and removed pods", func(t *testing.T) {
+		var lister mockLister
+		done := initPod("cosmoshub-0", corev1.ContainerStatus{Name: "genesis-init", Ready: true})
+		done.Status.Phase = corev1.PodRunning
+		doneNoURLs := initPod("cosmoshub-1", corev1.ContainerStatus{Name: "chain-init", Ready: true})
+		doneNoURLs.Status.Phase = corev1.PodRunning
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{
+			done,
+			doneNoURLs,
+			initPod("cosmoshub-2", running("genesis-init", time.Minute)),
+		}}
+
+		crd := newCRD()
+		crd.Spec.Replicas = 4
+		detected := metav1.NewTime(now.Add(-time.Hour))
+		crd.Status.SelfHealing.InitContainers = map[string]*cosmosv1.InitContainerWatchdogStatus{
+			"cosmoshub-0": {Container: "genesis-init", DetectedAt: detected, Retries: 2, URLOffsets: map[string]int32{"genesis-init": 1}},
+			"cosmoshub-1": {Container: "chain-init", DetectedAt: detected, Retries: 3},
+			// Still initializing.
+			"cosmoshub-2": {Container: "genesis-init", DetectedAt: detected, Retries: 1, URLOffsets: map[string]int32{"genesis-init": 1}},
+			// Deleted because it was stuck and not yet recreated.
+			"cosmoshub-3": {Container: "genesis-init", DetectedAt: detected, Retries: 1, URLOffsets: map[string]int32{"genesis-init": 2}},
+			// Scaled down.
+			"cosmoshub-4": {Container: "genesis-init", DetectedAt: detected, Retries: 1},
+		}
+		want := crd.Status.SelfHealing.InitContainers["cosmoshub-0"].DeepCopy()
+		want.Retries = 0
+
+		var got cosmosv1.FullNodeStatus
+		got.SelfHealing.InitContainers = crd.Status.DeepCopy().SelfHealing.InitContainers
+		watchdog := NewInitContainerWatchdog(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			update(&got)
+			return nil
+		}))
+		watchdog.now = func() time.Time { return now }
+
+		stuck, err := watchdog.FindStuck(ctx, crd)
+		require.NoError(t, err)
+		require.Empty(t, stuck)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			require.ElementsMatch(t, []string{"cosmoshub-0", "cosmoshub-2", "cosmoshub-3"}, lo.Keys(status.SelfHealing.InitContainers))
+			require.Equal(t, want, status.SelfHealing.InitContainers["cosmoshub-0"])
+			require.EqualValues(t, 1, status.SelfHealing.InitContainers["cosmoshub-2"].Retries)
+			require.EqualValues(t, 1, status.SelfHealing.InitContainers["cosmoshub-3"].Retries)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{initPod("cosmoshub-0", running("genesis-init", time.Hour))}}
+
+		crd := newCRD()
+		crd.Spec.SelfHeal.DryRun = true
+		watchdog := NewInitContainerWatchdog(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		watchdog.now = func() time.Time { return now }
+
+		stuck, err := watchdog.FindStuck(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, stuck, 1)
+		require.Equal(t, "https://mirror1.com/genesis.json", stuck[0].NextURL)
+		require.Empty(t, crd.Status.SelfHealing.InitContainers)
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{initPod("cosmoshub-0", running("genesis-init", time.Minute))}}
+
+		watchdog := NewInitContainerWatchdog(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		}))
+		watchdog.now = func() time.Time { return now }
+
+		stuck, err := watchdog.FindStuck(ctx, newCRD())
+		require.NoError(t, err)
+		require.Empty(t, stuck)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var lister mockLister
+		lister.ObjectList = corev1.PodList{Items: []corev1.Pod{initPod("cosmoshub-0", running("genesis-init", time.Hour))}}
+
+		watchdog := NewInitContainerWatchdog(&lister, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return errors.New("boom")
+		}))
+		_, err := watchdog.FindStuck(ctx, newCRD())
+		require.EqualError(t, err, "boom")
+
+		lister.ListErr = errors.New("list boom")
+		_, err = watchdog.FindStuck(ctx, newCRD())
+		require.EqualError(t, err, "list pods: list boom")
+	})
+}
+
+func TestInitURLOffset(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	crd.Spec.ChainSpec.GenesisURL = ptr("https://example.com/genesis.json")
+	crd.Spec.ChainSpec.GenesisFallbackURLs = []string{"https://mirror1.com/genesis.json", "https://mirror2.com/genesis.json"}
+	crd.Status.SelfHealing.InitContainers = map[string]*cosmosv1.InitContainerWatchdogStatus{
+		"osmosis-0": {URLOffsets: map[string]int32{"genesis-init": 1}},
+		"osmosis-1": {URLOffsets: map[string]int32{"genesis-init": 5}},
+	}
+
+	findGenesis := func(pod *corev1.Pod) corev1.Container {
+		c, ok := lo.Find(pod.Spec.InitContainers, func(c corev1.Container) bool { return c.Name == "genesis-init" })
+		require.True(t, ok)
+		return c
+	}
+
+	builder := NewPodBuilder(&crd)
+	pod, err := builder.WithOrdinal(0).Build()
+	require.NoError(t, err)
+	require.Equal(t, []string{"-s", "https://mirror1.com/genesis.json", "https://mirror2.com/genesis.json", "https://example.com/genesis.json"},
+		findGenesis(pod).Args[2:])
+
+	// Offset out of range because the URLs changed.
+	pod, err = builder.WithOrdinal(1).Build()
+	require.NoError(t, err)
+	require.Equal(t, []string{"-s", "https://example.com/genesis.json", "https://mirror1.com/genesis.json", "https://mirror2.com/genesis.json"},
+		findGenesis(pod).Args[2:])
+
+	require.Zero(t, initURLOffset(&crd, "osmosis-2", "genesis-init"))
+	require.Zero(t, initURLOffset(&crd, "osmosis-0", "addrbook-init"))
+}
@@ -0,0 +1,69 @@
+package fullnode
+
+import (
+	"context"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamadaHealther fetches the Namada-specific health from a pod's healthcheck sidecar.
+type NamadaHealther interface {
+	Namada(ctx context.Context, host string) (healthcheck.NamadaResponse, error)
+}
+
+// NamadaHealthCollector collects the Namada-specific health from each pod's healthcheck sidecar.
+type NamadaHealthCollector struct {
+	client    NamadaHealther
+	collector StatusCollector
+	timeout   time.Duration
+}
+
+func NewNamadaHealthCollector(client NamadaHealther, collector StatusCollector) *NamadaHealthCollector {
+	return &NamadaHealthCollector{
+		client:    client,
+		collector: collector,
+		timeout:   5 * time.Second,
+	}
+}
+
+// Collect sets the Namada health of each pod in syncInfo. Does nothing if the chain is not Namada.
+func (c NamadaHealthCollector) Collect(ctx context.Context, crd *cosmosv1.CosmosFullNode, syncInfo map[string]*cosmosv1.SyncInfoPodStatus) {
+	if crd.Spec.ChainSpec.ChainType != chainTypeNamada {
+		return
+	}
+
+	var eg errgroup.Group
+	for _, pod := range c.collector.Collect(ctx, client.ObjectKeyFromObject(crd)).Pods() {
+		pod := pod
+		stat := syncInfo[pod.Name]
+		if stat == nil || pod.Status.PodIP == "" {
+			continue
+		}
+		eg.Go(func() error {
+			cctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			var namada cosmosv1.NamadaPodStatus
+			resp, err := c.client.Namada(cctx, "http://"+pod.Status.PodIP)
+			if err != nil {
+				namada.Error = ptr(err.Error())
+			} else {
+				if resp.ShellHeight > 0 {
+					namada.ShellHeight = ptr(resp.ShellHeight)
+				}
+				if resp.ShellError != "" {
+					namada.ShellError = ptr(resp.ShellError)
+				}
+				if resp.OracleError != "" {
+					namada.OracleError = ptr(resp.OracleError)
+				}
+			}
+			stat.Namada = &namada
+			return nil
+		})
+	}
+	_ = eg.Wait()
+}
@@ -0,0 +1,80 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockNamadaHealther func(ctx context.Context, host string) (healthcheck.NamadaResponse, error)
+
+func (fn mockNamadaHealther) Namada(ctx context.Context, host string) (healthcheck.NamadaResponse, error) {
+	return fn(ctx, host)
+}
+
+func TestNamadaHealthCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var crd cosmosv1.CosmosFullNode
+	crd.Name = "namada"
+	crd.Namespace = "default"
+	crd.Spec.ChainSpec.ChainType = chainTypeNamada
+
+	newPod := func(name, ip string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		pod.Status.PodIP = ip
+		return pod
+	}
+	collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+		require.Equal(t, client.ObjectKey{Namespace: "default", Name: "namada"}, controller)
+		return cosmos.StatusCollection{
+			{Pod: newPod("namada-0", "10.0.0.1")},
+			{Pod: newPod("namada-1", "10.0.0.2")},
+			{Pod: newPod("namada-2", "10.0.0.3")},
+			{Pod: newPod("namada-3", "")},
+		}
+	}}
+	healther := mockNamadaHealther(func(ctx context.Context, host string) (healthcheck.NamadaResponse, error) {
+		_, ok := ctx.Deadline()
+		require.True(t, ok)
+		switch host {
+		case "http://10.0.0.1":
+			return healthcheck.NamadaResponse{ShellHeight: 100}, nil
+		case "http://10.0.0.2":
+			return healthcheck.NamadaResponse{ShellError: "timeout", OracleError: "connection refused"}, nil
+		}
+		return healthcheck.NamadaResponse{}, errors.New("boom")
+	})
+
+	syncInfo := map[string]*cosmosv1.SyncInfoPodStatus{
+		"namada-0": {},
+		"namada-1": {},
+		"namada-2": {},
+		"namada-3": {},
+	}
+	NewNamadaHealthCollector(healther, collector).Collect(ctx, &crd, syncInfo)
+
+	require.Equal(t, &cosmosv1.NamadaPodStatus{ShellHeight: ptr(uint64(100))}, syncInfo["namada-0"].Namada)
+	require.Equal(t, &cosmosv1.NamadaPodStatus{ShellError: ptr("timeout"), OracleError: ptr("connection refused")}, syncInfo["namada-1"].Namada)
+	require.Equal(t, &cosmosv1.NamadaPodStatus{Error: ptr("boom")}, syncInfo["namada-2"].Namada)
+	require.Nil(t, syncInfo["namada-3"].Namada)
+
+	t.Run("not namada", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.ChainSpec.ChainType = chainTypeCosmos
+		panicCollector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			panic("should not be called")
+		}}
+		NewNamadaHealthCollector(healther, panicCollector).Collect(ctx, crd, map[string]*cosmosv1.SyncInfoPodStatus{})
+	})
+}
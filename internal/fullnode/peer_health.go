@@ -0,0 +1,227 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	peerHealthMinPeersDefault         = 3
+	peerHealthGracePeriodDefault      = 5 * time.Minute
+	peerHealthBackoffDefault          = 10 * time.Minute
+	peerHealthMaxInjectedPeersDefault = 10
+)
+
+// PeerHealthAction is a remediation to apply to a pod starved of peers.
+type PeerHealthAction struct {
+	Pod   *corev1.Pod
+	Stage cosmosv1.PeerHealthStage
+	Peers int
+}
+
+// PeerHealth detects pods connected to too few peers and escalates their remediation.
+type PeerHealth struct {
+	available      func(pods []*corev1.Pod, minReady time.Duration, now time.Time) []*corev1.Pod
+	client         StatusSyncer
+	collector      StatusCollector
+	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int
+	now            func() time.Time
+}
+
+func NewPeerHealth(collector StatusCollector, client StatusSyncer) *PeerHealth {
+	return &PeerHealth{
+		available:      kube.AvailablePods,
+		client:         client,
+		collector:      collector,
+		computeRollout: kube.ComputeRollout,
+		now:            time.Now,
+	}
+}
+
+// Check records the peer count of each pod in status.selfHealing.peerHealth and returns the remediations due for
+// starved pods. Remediations are limited by the rollout strategy.
+// The caller is responsible for deleting the returned pods so each remediation takes effect.
+// Entries for pods which are no longer instances of the CosmosFullNode are removed, so an instance scaled away and
+// back does not get its stale injected peers.
+// In dry-run mode, only the peer counts are recorded so the returned remediations are only reported.
+// Assumes spec.selfHeal.peerHealth is set.
+func (h PeerHealth) Check(ctx context.Context, crd *cosmosv1.CosmosFullNode) ([]PeerHealthAction, error) {
+	var (
+		spec      = crd.Spec.SelfHeal.PeerHealth
+		minPeers  = int(lo.Ternary(spec.MinPeers > 0, spec.MinPeers, peerHealthMinPeersDefault))
+		maxInject = int(lo.Ternary(spec.MaxInjectedPeers > 0, spec.MaxInjectedPeers, peerHealthMaxInjectedPeersDefault))
+		dryRun    = DryRun(crd.Spec.SelfHeal, spec.DryRun)
+		grace     = durationOrDefault(spec.GracePeriod, peerHealthGracePeriodDefault)
+		backoff   = durationOrDefault(spec.Backoff, peerHealthBackoffDefault)
+		now       = h.now()
+		items     = h.collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+		goodPeers = knownGoodPeers(items, minPeers)
+		avail     = h.available(items.Synced().Pods(), 5*time.Second, now)
+		budget    = h.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), len(avail))
+		actions   []PeerHealthAction
+		patches   = make(map[string]*cosmosv1.PeerHealthStatus)
+		removed   []string
+		ts        = metav1.NewTime(now)
+	)
+
+	instances := make(map[string]bool)
+	for i := int32(0); i < crd.Spec.Replicas; i++ {
+		instances[instanceName(crd, i)] = true
+	}
+	for name := range crd.Status.SelfHealing.PeerHealth {
+		if !instances[name] {
+			removed = append(removed, name)
+		}
+	}
+
+	for _, item := range items {
+		if item.Err != nil || item.NetInfo == nil {
+			// The peer count is unknown.
+			continue
+		}
+		pod := item.GetPod()
+		if !instances[pod.Name] {
+			continue
+		}
+		count := item.NetInfo.PeerCount()
+		current := crd.Status.SelfHealing.PeerHealth[pod.Name]
+		status := current.DeepCopy()
+		if status == nil {
+			status = new(cosmosv1.PeerHealthStatus)
+		}
+		changed := current == nil || int(status.Peers) != count
+		status.Peers = int32(count)
+
+		switch {
+		case count >= minPeers:
+			if status.StarvedSince != nil || status.Stage != "" {
+				status.StarvedSince = nil
+				status.Stage = ""
+				status.LastRemediationTime = nil
+				status.Applied = false
+				changed = true
+			}
+		case status.StarvedSince == nil:
+			status.StarvedSince = &ts
+			changed = true
+		case now.Sub(status.StarvedSince.Time) < grace:
+		case status.LastRemediationTime != nil && now.Sub(status.LastRemediationTime.Time) < backoff:
+		case len(actions) >= budget:
+			// Remediated once other pods are available.
+		default:
+			stage := nextPeerHealthStage(status.Stage, len(goodPeers) > 0)
+			actions = append(actions, PeerHealthAction{Pod: pod, Stage: stage, Peers: count})
+			if dryRun {
+				break
+			}
+			status.Stage = stage
+			status.LastRemediationTime = &ts
+			status.Applied = false
+			if status.Stage == cosmosv1.PeerHealthInjectPeers {
+				status.InjectedPeers = lo.Slice(goodPeers, 0, maxInject)
+			}
+			changed = true
+		}
+
+		if changed {
+			patches[pod.Name] = status
+		}
+	}
+
+	if len(patches) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+
+	update := func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.PeerHealth == nil {
+			status.SelfHealing.PeerHealth = make(map[string]*cosmosv1.PeerHealthStatus)
+		}
+		for _, name := range removed {
+			delete(status.SelfHealing.PeerHealth, name)
+		}
+		for name, patch := range patches {
+			status.SelfHealing.PeerHealth[name] = patch
+		}
+	}
+	update(&crd.Status)
+	return actions, h.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
+
+// nextPeerHealthStage escalates the remediation. Injecting peers is skipped if no peers are known. Once all stages
+// are exhausted, the pod is restarted.
+func nextPeerHealthStage(stage cosmosv1.PeerHealthStage, hasPeers bool) cosmosv1.PeerHealthStage {
+	switch stage {
+	case "":
+		return cosmosv1.PeerHealthRefreshAddrbook
+	case cosmosv1.PeerHealthRefreshAddrbook:
+		if hasPeers {
+			return cosmosv1.PeerHealthInjectPeers
+		}
+	}
+	return cosmosv1.PeerHealthRestart
+}
+
+// knownGoodPeers returns the outbound peers of pods connected to at least minPeers, excluding the pods themselves,
+// as sorted <id>@<ip>:<port> addresses.
+func knownGoodPeers(items cosmos.StatusCollection, minPeers int) []string {
+	own := lo.SliceToMap(items, func(item cosmos.StatusItem) (string, bool) { return item.GetPod().Status.PodIP, true })
+	set := make(map[string]bool)
+	for _, item := range items {
+		if item.Err != nil || item.NetInfo == nil || item.NetInfo.PeerCount() < minPeers {
+			continue
+		}
+		for _, peer := range item.NetInfo.Peers {
+			if !peer.IsOutbound || peer.NodeInfo.ID == "" || peer.RemoteIP == "" || own[peer.RemoteIP] {
+				continue
+			}
+			set[fmt.Sprintf("%s@%s", peer.NodeInfo.ID, net.JoinHostPort(peer.RemoteIP, listenPort(peer.NodeInfo.ListenAddr)))] = true
+		}
+	}
+	peers := lo.Keys(set)
+	sort.Strings(peers)
+	return peers
+}
+
+// listenPort returns the port of a CometBFT listen address such as "tcp://0.0.0.0:26656".
+func listenPort(addr string) string {
+	if i := strings.LastIndex(addr, ":"); i >= 0 && i < len(addr)-1 {
+		return addr[i+1:]
+	}
+	return fmt.Sprint(p2pPort)
+}
+
+func durationOrDefault(d *metav1.Duration, def time.Duration) time.Duration {
+	if d == nil {
+		return def
+	}
+	return d.Duration
+}
+
+// PeerHealthPending returns true if the pod must remove its address book on startup.
+// Used by the pod's drift-remediation init container.
+func PeerHealthPending(crd *cosmosv1.CosmosFullNode, podName string) bool {
+	status := crd.Status.SelfHealing.PeerHealth[podName]
+	return status != nil && status.Stage == cosmosv1.PeerHealthRefreshAddrbook && !status.Applied
+}
+
+// injectedPeers returns the peers added to the instance's persistent peers because it was starved of peers.
+func injectedPeers(crd *cosmosv1.CosmosFullNode, instance string) *string {
+	status := crd.Status.SelfHealing.PeerHealth[instance]
+	if status == nil || len(status.InjectedPeers) == 0 {
+		return nil
+	}
+	return ptr(strings.Join(status.InjectedPeers, ","))
+}
@@ -0,0 +1,250 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPeerHealth_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	newCRD := func() *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "cosmoshub"
+		crd.Namespace = "default"
+		crd.Spec.Replicas = 3
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{PeerHealth: &cosmosv1.PeerHealthSpec{MinPeers: 2}}
+		return &crd
+	}
+	newItem := func(i int, peers ...cosmos.CometPeer) cosmos.StatusItem {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cosmoshub-%d", i), Namespace: "default"}}
+		pod.Status.PodIP = fmt.Sprintf("10.0.0.%d", i)
+		return cosmos.StatusItem{
+			Pod:     pod,
+			NetInfo: &cosmos.CometNetInfo{NPeers: fmt.Sprint(len(peers)), Peers: peers},
+		}
+	}
+	outbound := func(id, ip string) cosmos.CometPeer {
+		var peer cosmos.CometPeer
+		peer.NodeInfo.ID = id
+		peer.NodeInfo.ListenAddr = "tcp://0.0.0.0:26656"
+		peer.RemoteIP = ip
+		peer.IsOutbound = true
+		return peer
+	}
+	newChecker := func(coll cosmos.StatusCollection, syncer StatusSyncer) *PeerHealth {
+		checker := NewPeerHealth(mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			require.Equal(t, client.ObjectKey{Namespace: "default", Name: "cosmoshub"}, controller)
+			return coll
+		}}, syncer)
+		checker.available = func(pods []*corev1.Pod, minReady time.Duration, now time.Time) []*corev1.Pod { return pods }
+		checker.now = func() time.Time { return now }
+		return checker
+	}
+	nopSyncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+		return nil
+	})
+
+	t.Run("escalates", func(t *testing.T) {
+		coll := cosmos.StatusCollection{
+			newItem(0, outbound("aaa", "1.1.1.1"), outbound("bbb", "10.0.0.1"), cosmos.CometPeer{RemoteIP: "3.3.3.3"}),
+			newItem(1),
+			newItem(2, cosmos.CometPeer{RemoteIP: "4.4.4.4"}, cosmos.CometPeer{RemoteIP: "5.5.5.5"}),
+			{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-3"}}, Err: errors.New("no rpc")},
+		}
+		crd := newCRD()
+
+		var got cosmosv1.FullNodeStatus
+		checker := newChecker(coll, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
+		}))
+
+		// Starved, but within the grace period.
+		actions, err := checker.Check(ctx, crd)
+		require.NoError(t, err)
+		require.Empty(t, actions)
+		require.Len(t, got.SelfHealing.PeerHealth, 3)
+		require.EqualValues(t, 3, got.SelfHealing.PeerHealth["cosmoshub-0"].Peers)
+		require.Nil(t, got.SelfHealing.PeerHealth["cosmoshub-0"].StarvedSince)
+		require.Zero(t, got.SelfHealing.PeerHealth["cosmoshub-1"].Peers)
+		require.Equal(t, now.Unix(), got.SelfHealing.PeerHealth["cosmoshub-1"].StarvedSince.Unix())
+
+		var stages []cosmosv1.PeerHealthStage
+		for i := 0; i < 4; i++ {
+			now = now.Add(15 * time.Minute)
+			actions, err = checker.Check(ctx, crd)
+			require.NoError(t, err)
+			require.Len(t, actions, 1)
+			require.Equal(t, "cosmoshub-1", actions[0].Pod.Name)
+			stages = append(stages, actions[0].Stage)
+
+			// Within backoff.
+			actions, err = checker.Check(ctx, crd)
+			require.NoError(t, err)
+			require.Empty(t, actions)
+		}
+		require.Equal(t, []cosmosv1.PeerHealthStage{
+			cosmosv1.PeerHealthRefreshAddrbook,
+			cosmosv1.PeerHealthInjectPeers,
+			cosmosv1.PeerHealthRestart,
+			cosmosv1.PeerHealthRestart,
+		}, stages)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			// Excludes own pods and inbound peers.
+			require.Equal(t, []string{"aaa@1.1.1.1:26656"}, status.SelfHealing.PeerHealth["cosmoshub-1"].InjectedPeers)
+		}
+		require.Equal(t, "aaa@1.1.1.1:26656", *injectedPeers(crd, "cosmoshub-1"))
+		require.Nil(t, injectedPeers(crd, "cosmoshub-0"))
+
+		// Recovers, keeping the injected peers.
+		coll[1] = newItem(1, outbound("aaa", "1.1.1.1"), outbound("ccc", "2.2.2.2"))
+		actions, err = checker.Check(ctx, crd)
+		require.NoError(t, err)
+		require.Empty(t, actions)
+
+		recovered := crd.Status.SelfHealing.PeerHealth["cosmoshub-1"]
+		require.EqualValues(t, 2, recovered.Peers)
+		require.Nil(t, recovered.StarvedSince)
+		require.Empty(t, recovered.Stage)
+		require.Nil(t, recovered.LastRemediationTime)
+		require.NotEmpty(t, recovered.InjectedPeers)
+	})
+
+	t.Run("skips injecting unknown peers", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.Replicas = 1
+		starved := metav1.NewTime(now.Add(-time.Hour))
+		crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+			"cosmoshub-0": {StarvedSince: &starved, Stage: cosmosv1.PeerHealthRefreshAddrbook, LastRemediationTime: &starved, Applied: true},
+		}
+
+		actions, err := newChecker(cosmos.StatusCollection{newItem(0)}, nopSyncer).Check(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Equal(t, cosmosv1.PeerHealthRestart, actions[0].Stage)
+		require.False(t, crd.Status.SelfHealing.PeerHealth["cosmoshub-0"].Applied)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.Replicas = 1
+		crd.Spec.SelfHeal.PeerHealth.DryRun = ptr(true)
+		starved := metav1.NewTime(now.Add(-time.Hour))
+		crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+			"cosmoshub-0": {Peers: 1, StarvedSince: &starved},
+		}
+
+		var got cosmosv1.FullNodeStatus
+		checker := newChecker(cosmos.StatusCollection{newItem(0)}, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			update(&got)
+			return nil
+		}))
+		actions, err := checker.Check(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Equal(t, cosmosv1.PeerHealthRefreshAddrbook, actions[0].Stage)
+
+		// Only the peer count is recorded.
+		status := got.SelfHealing.PeerHealth["cosmoshub-0"]
+		require.Zero(t, status.Peers)
+		require.Equal(t, starved, *status.StarvedSince)
+		require.Empty(t, status.Stage)
+		require.Nil(t, status.LastRemediationTime)
+		require.False(t, PeerHealthPending(crd, "cosmoshub-0"))
+	})
+
+	t.Run("rollout strategy", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.RolloutStrategy.MaxUnavailable = ptr(intstr.FromInt(1))
+		starved := metav1.NewTime(now.Add(-time.Hour))
+		crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+			"cosmoshub-0": {StarvedSince: &starved},
+			"cosmoshub-1": {StarvedSince: &starved},
+		}
+
+		actions, err := newChecker(cosmos.StatusCollection{newItem(0), newItem(1), newItem(2)}, nopSyncer).Check(ctx, crd)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Empty(t, crd.Status.SelfHealing.PeerHealth["cosmoshub-1"].Stage)
+	})
+
+	t.Run("removes scaled away instances", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.Replicas = 1
+		crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+			"cosmoshub-0": {Peers: 2},
+			"cosmoshub-1": {Peers: 0, Stage: cosmosv1.PeerHealthInjectPeers, InjectedPeers: []string{"aaa@1.1.1.1:26656"}},
+		}
+
+		got := crd.Status.DeepCopy()
+		// The scaled away pod may still be collected until it is deleted.
+		coll := cosmos.StatusCollection{newItem(0, outbound("aaa", "1.1.1.1"), outbound("bbb", "2.2.2.2")), newItem(1)}
+		checker := newChecker(coll, mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			update(got)
+			return nil
+		}))
+		actions, err := checker.Check(ctx, crd)
+		require.NoError(t, err)
+		require.Empty(t, actions)
+
+		for _, status := range []cosmosv1.FullNodeStatus{*got, crd.Status} {
+			require.Equal(t, []string{"cosmoshub-0"}, lo.Keys(status.SelfHealing.PeerHealth))
+		}
+		require.Nil(t, injectedPeers(crd, "cosmoshub-1"))
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		crd := newCRD()
+		crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{"cosmoshub-0": {Peers: 2}}
+
+		panicSyncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		})
+		actions, err := newChecker(cosmos.StatusCollection{newItem(0, outbound("aaa", "1.1.1.1"), outbound("bbb", "2.2.2.2"))}, panicSyncer).Check(ctx, crd)
+		require.NoError(t, err)
+		require.Empty(t, actions)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			return errors.New("boom")
+		})
+		_, err := newChecker(cosmos.StatusCollection{newItem(0)}, syncer).Check(ctx, newCRD())
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestPeerHealthPending(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	require.False(t, PeerHealthPending(&crd, "cosmoshub-0"))
+
+	crd.Status.SelfHealing.PeerHealth = map[string]*cosmosv1.PeerHealthStatus{
+		"cosmoshub-0": {Stage: cosmosv1.PeerHealthRefreshAddrbook},
+		"cosmoshub-1": {Stage: cosmosv1.PeerHealthRefreshAddrbook, Applied: true},
+		"cosmoshub-2": {Stage: cosmosv1.PeerHealthRestart},
+	}
+	got := lo.Filter([]string{"cosmoshub-0", "cosmoshub-1", "cosmoshub-2"}, func(name string, _ int) bool {
+		return PeerHealthPending(&crd, name)
+	})
+	require.Equal(t, []string{"cosmoshub-0"}, got)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -25,6 +26,9 @@ const (
 	healthCheckPort    = healthcheck.Port
 	mainContainer      = "node"
 	chainInitContainer = "chain-init"
+	genesisContainer   = "genesis-init"
+	addrbookContainer  = "addrbook-init"
+	snapshotContainer  = "snapshot-restore"
 	chainTypeCosmos    = "cosmos"
 	chainTypeNamada    = "namada"
 )
@@ -49,10 +53,7 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 		probes              = podReadinessProbes(crd)
 	)
 
-	versionCheckCmd := []string{"/manager", "versioncheck", "-d"}
-	if crd.Spec.ChainSpec.DatabaseBackend != nil {
-		versionCheckCmd = append(versionCheckCmd, "-b", *crd.Spec.ChainSpec.DatabaseBackend)
-	}
+	versionCheckCmd := append([]string{"/manager", "versioncheck", "-d"}, versionCheckArgs(crd)...)
 
 	pod := corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -98,7 +99,7 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 					// Available images: https://github.com/orgs/strangelove-ventures/packages?repo_name=cosmos-operator
 					// IMPORTANT: Must use v0.6.2 or later.
 					Image:   "ghcr.io/bharvest-devops/cosmos-operator:" + version.DockerTag(),
-					Command: []string{"/manager", "healthcheck"},
+					Command: healthCheckCmd(crd),
 					Ports:   []corev1.ContainerPort{{ContainerPort: healthCheckPort, Protocol: corev1.ProtocolTCP}},
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
@@ -132,6 +133,11 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 		})
 	}
 
+	if crd.Spec.SelfHeal != nil && crd.Spec.SelfHeal.CrashLoopHealing != nil {
+		// The node's last output becomes the termination message so a crash loop can be classified.
+		pod.Spec.Containers[0].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	}
+
 	preserveMergeInto(pod.Labels, tpl.Metadata.Labels)
 	preserveMergeInto(pod.Annotations, tpl.Metadata.Annotations)
 
@@ -141,6 +147,73 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 	}
 }
 
+func healthCheckCmd(crd *cosmosv1.CosmosFullNode) []string {
+	// Only the chain's home directory is sized, so /disk cannot be used to walk other directories.
+	cmd := []string{"/manager", "healthcheck", "--disk-usage-dir", ChainHomeDir(crd)}
+	if apis := healthCheckAPIs(crd); len(apis) > 0 {
+		cmd = append(cmd, "--require-apis", strings.Join(apis, ","))
+	}
+	if crd.Spec.ChainSpec.ChainType == chainTypeNamada {
+		cmd = append(cmd, "--namada")
+		if oracle := namadaOracleRPC(crd); oracle != "" {
+			cmd = append(cmd, "--namada-oracle-rpc", oracle)
+		}
+	}
+	criteria := crd.Spec.PodTemplate.Probes.Readiness
+	if criteria == nil {
+		return cmd
+	}
+	if criteria.MaxBlockAge != nil {
+		cmd = append(cmd, "--max-block-age", criteria.MaxBlockAge.Duration.String())
+	}
+	if criteria.MinPeers > 0 {
+		cmd = append(cmd, "--min-peers", strconv.Itoa(int(criteria.MinPeers)))
+	}
+	if criteria.MaxHeightLag > 0 {
+		cmd = append(cmd, "--max-height-lag", strconv.FormatUint(uint64(criteria.MaxHeightLag), 10), "--fullnode", crd.Name)
+	}
+	return cmd
+}
+
+// namadaOracleRPC returns the Ethereum RPC endpoint the Ethereum bridge oracle queries, or empty if the oracle
+// does not query one.
+func namadaOracleRPC(crd *cosmosv1.CosmosFullNode) string {
+	namada := crd.Spec.ChainSpec.Namada
+	if namada == nil || namada.Ledger == nil || namada.Ledger.EthereumBridge == nil {
+		return ""
+	}
+	bridge := namada.Ledger.EthereumBridge
+	if bridge.Mode == nil {
+		return ""
+	}
+	switch *bridge.Mode {
+	case "RemoteEndpoint", "SelfHostedEndpoint":
+	default:
+		return ""
+	}
+	if bridge.OracleRPCEndpoint == nil || *bridge.OracleRPCEndpoint == "" {
+		// Namada's default.
+		return "http://127.0.0.1:8545"
+	}
+	return *bridge.OracleRPCEndpoint
+}
+
+func healthCheckAPIs(crd *cosmosv1.CosmosFullNode) []string {
+	if crd.Spec.ChainSpec.ChainType == chainTypeNamada {
+		return nil
+	}
+	var apis []string
+	for _, api := range lo.Uniq(crd.Spec.PodTemplate.Probes.APIs) {
+		switch api {
+		case cosmosv1.FullNodeProbeAPIGRPC:
+			apis = append(apis, "grpc")
+		case cosmosv1.FullNodeProbeAPIREST:
+			apis = append(apis, "rest")
+		}
+	}
+	return apis
+}
+
 func podReadinessProbes(crd *cosmosv1.CosmosFullNode) []*corev1.Probe {
 	if crd.Spec.PodTemplate.Probes.Strategy == cosmosv1.FullNodeProbeStrategyNone {
 		return []*corev1.Probe{nil, nil}
@@ -322,6 +395,11 @@ const (
 	systemTmpDir = "/tmp"
 )
 
+// CometDataDir is the filepath of the CometBFT data directory relative to the chain's home directory.
+func CometDataDir(crd *cosmosv1.CosmosFullNode) string {
+	return strings.TrimPrefix(path.Join(getCometbftDir(crd), "data"), "/")
+}
+
 // ChainHomeDir is the abs filepath for the chain's home directory.
 func ChainHomeDir(crd *cosmosv1.CosmosFullNode) string {
 	home := crd.Spec.ChainSpec.HomeDir
@@ -342,10 +420,13 @@ func envVars(crd *cosmosv1.CosmosFullNode) []corev1.EnvVar {
 		{Name: "GENESIS_FILE", Value: path.Join(home, getCometbftDir(crd)+"/config", "genesis.json")},
 		{Name: "ADDRBOOK_FILE", Value: path.Join(home, getCometbftDir(crd)+"/config", "addrbook.json")},
 		{Name: "CONFIG_DIR", Value: path.Join(home, getCometbftDir(crd), "/config")},
-		{Name: "DATA_DIR", Value: path.Join(home, getCometbftDir(crd), "/data")},
+		{Name: "DATA_DIR", Value: path.Join(home, CometDataDir(crd))},
 		{Name: "CHAIN_ID", Value: crd.Spec.ChainSpec.ChainID},
 		{Name: "CHAIN_TYPE", Value: crd.Spec.ChainSpec.ChainType},
 	}
+	if sha := crd.Spec.ChainSpec.GenesisSHA256; sha != nil {
+		envs = append(envs, corev1.EnvVar{Name: "GENESIS_SHA256", Value: *sha})
+	}
 	if len(crd.Spec.PodTemplate.Envs) != 0 {
 		for _, env := range crd.Spec.PodTemplate.Envs {
 			for k, v := range env {
@@ -421,9 +502,39 @@ cat "$CHAIN_HOME/$CHAIN_ID/config.toml" > "$HOME/.tmp/config/config.toml"
 	}
 }
 
+func getDriftRemediationContainer(env []corev1.EnvVar, tpl cosmosv1.PodSpec) corev1.Container {
+	return corev1.Container{
+		Name:    "drift-remediation",
+		Image:   "ghcr.io/bharvest-devops/cosmos-operator:" + version.DockerTag(),
+		Command: []string{"/manager", "driftremediate"},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+		},
+		Env:             env,
+		ImagePullPolicy: tpl.ImagePullPolicy,
+		WorkingDir:      workDir,
+	}
+}
+
+func getSnapshotRestoreContainer(env []corev1.EnvVar, tpl cosmosv1.PodSpec, cfg cosmosv1.ChainSpec) corev1.Container {
+	cmd, args := DownloadSnapshotCommand(cfg)
+	return corev1.Container{
+		Name:            snapshotContainer,
+		Image:           infraToolImage,
+		Command:         []string{cmd},
+		Args:            args,
+		Env:             env,
+		ImagePullPolicy: tpl.ImagePullPolicy,
+		WorkingDir:      workDir,
+	}
+}
+
 func getGenesisInitContainer(env []corev1.EnvVar, tpl cosmosv1.PodSpec, genesisCmd string, genesisArgs []string, genesisImage string) corev1.Container {
 	return corev1.Container{
-		Name:            "genesis-init",
+		Name:            genesisContainer,
 		Image:           genesisImage,
 		Command:         []string{genesisCmd},
 		Args:            genesisArgs,
@@ -435,7 +546,7 @@ func getGenesisInitContainer(env []corev1.EnvVar, tpl cosmosv1.PodSpec, genesisC
 
 func getAddrbookInitContainer(env []corev1.EnvVar, tpl cosmosv1.PodSpec, addrbookCmd string, addrbookArgs []string) corev1.Container {
 	return corev1.Container{
-		Name:            "addrbook-init",
+		Name:            addrbookContainer,
 		Image:           infraToolImage,
 		Command:         []string{addrbookCmd},
 		Args:            addrbookArgs,
@@ -498,18 +609,6 @@ func initContainers(crd *cosmosv1.CosmosFullNode, moniker string) []corev1.Conta
 		required = append(required, getAddrbookInitContainer(env, tpl, addrbookCmd, addrbookArgs))
 		required = append(required, getConfigMergeContainer(env, tpl))
 
-		if willRestoreFromSnapshot(crd) {
-			cmd, args := DownloadSnapshotCommand(crd.Spec.ChainSpec)
-			required = append(required, corev1.Container{
-				Name:            "snapshot-restore",
-				Image:           infraToolImage,
-				Command:         []string{cmd},
-				Args:            args,
-				Env:             env,
-				ImagePullPolicy: tpl.ImagePullPolicy,
-				WorkingDir:      workDir,
-			})
-		}
 	} else if crd.Spec.ChainSpec.ChainType == chainTypeNamada {
 		required = append(required, getCleanInitContainer(env, tpl))
 		required = append(required, getGenesisInitContainer(env, tpl, genesisCmd, genesisArgs, crd.Spec.PodTemplate.Image))
@@ -517,6 +616,16 @@ func initContainers(crd *cosmosv1.CosmosFullNode, moniker string) []corev1.Conta
 		required = append(required, getAddrbookInitContainer(env, tpl, addrbookCmd, addrbookArgs))
 		required = append(required, getConfigMergeContainer(env, tpl))
 	}
+	if willRestoreFromSnapshot(crd) {
+		required = append(required, getSnapshotRestoreContainer(env, tpl, crd.Spec.ChainSpec))
+	}
+	for i := range required {
+		rotateDownloadURLs(&required[i], initURLOffset(crd, moniker, required[i].Name))
+	}
+	if usesDriftRemediationInit(crd) {
+		// Runs before any init container which restores chain data or downloads the address book.
+		required = append(required[:1], append([]corev1.Container{getDriftRemediationContainer(env, tpl)}, required[1:]...)...)
+	}
 	allowPrivilege := false
 	for _, c := range required {
 		c.SecurityContext = &corev1.SecurityContext{
@@ -541,10 +650,7 @@ func initContainers(crd *cosmosv1.CosmosFullNode, moniker string) []corev1.Conta
 	//	})
 	//}
 
-	versionCheckCmd := []string{"/manager", "versioncheck"}
-	if crd.Spec.ChainSpec.DatabaseBackend != nil {
-		versionCheckCmd = append(versionCheckCmd, "-b", *crd.Spec.ChainSpec.DatabaseBackend)
-	}
+	versionCheckCmd := append([]string{"/manager", "versioncheck"}, versionCheckArgs(crd)...)
 
 	// Append version check after snapshot download, if applicable.
 	// That way the version check will be after the database is initialized.
@@ -570,6 +676,15 @@ func initContainers(crd *cosmosv1.CosmosFullNode, moniker string) []corev1.Conta
 	return required
 }
 
+// versionCheckArgs returns the flags telling versioncheck how to read the chain's height.
+func versionCheckArgs(crd *cosmosv1.CosmosFullNode) []string {
+	var args []string
+	if crd.Spec.ChainSpec.DatabaseBackend != nil {
+		args = append(args, "-b", *crd.Spec.ChainSpec.DatabaseBackend)
+	}
+	return args
+}
+
 func startCmdAndArgs(crd *cosmosv1.CosmosFullNode) (string, []string) {
 	var (
 		binary             = crd.Spec.ChainSpec.Binary
@@ -620,7 +735,8 @@ func startCommandArgs(crd *cosmosv1.CosmosFullNode) []string {
 }
 
 func willRestoreFromSnapshot(crd *cosmosv1.CosmosFullNode) bool {
-	return crd.Spec.ChainSpec.CosmosSDK.SnapshotURL != nil || crd.Spec.ChainSpec.CosmosSDK.SnapshotScript != nil
+	script, url := snapshotSource(crd.Spec.ChainSpec)
+	return script != nil || url != nil
 }
 
 func podPatch(crd *cosmosv1.CosmosFullNode) *corev1.Pod {
@@ -653,26 +769,45 @@ func PVCName(pod *corev1.Pod) string {
 	return found.PersistentVolumeClaim.ClaimName
 }
 
+// MainImage returns the image of the container running the chain process.
+func MainImage(pod *corev1.Pod) string {
+	found, ok := lo.Find(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == mainContainer })
+	if !ok {
+		return ""
+	}
+	return found.Image
+}
+
 const PRUNING_POD_IMAGE_DEFAULT = "ghcr.io/bharvest-devops/cosmos-pruner:latest"
 
-func (p *PrunerPod) BuildPruningContainer(crd *cosmosv1.CosmosFullNode) *corev1.Pod {
+// BuildPruningContainer replaces the pod's containers with a single container which prunes the pod's PVC.
+// Returns an error if the pruning spec's strategy is invalid for the chain and no pruningCommand is set.
+func (p *PrunerPod) BuildPruningContainer(crd *cosmosv1.CosmosFullNode) (*corev1.Pod, error) {
 	if p == nil {
-		return nil
+		return nil, nil
 	}
 
 	if crd.Spec.SelfHeal.PruningSpec == nil {
-		return nil
+		return nil, nil
 	}
 	var (
 		pruningImage   = crd.Spec.SelfHeal.PruningSpec.Image
 		pruningCommand = crd.Spec.SelfHeal.PruningSpec.PruningCommand
 		probes         = podReadinessProbes(crd)
 	)
+	strategy, err := NewPruningStrategy(crd)
+	switch {
+	case err != nil && pruningCommand == "":
+		return nil, err
+	case err != nil:
+		// The strategy is ignored when pruningCommand is set, so only its default image is used.
+		strategy = compactStrategy{newChainData(crd)}
+	}
 	if pruningImage == "" {
-		pruningImage = PRUNING_POD_IMAGE_DEFAULT
+		pruningImage = strategy.Image()
 	}
 	if pruningCommand == "" {
-		pruningCommand = "cosmos-pruner compact /home/operator/cosmos/data/ 2>&1"
+		pruningCommand = strategy.Command()
 	}
 
 	oldPod := ptr(corev1.Pod(*p)).DeepCopy()
@@ -684,16 +819,34 @@ func (p *PrunerPod) BuildPruningContainer(crd *cosmosv1.CosmosFullNode) *corev1.
 			Name:           GetPrunerPodName(p.Name),
 			Image:          pruningImage,
 			Command:        []string{"/bin/sh"},
-			Args:           []string{"-c", pruningCommand},
+			Args:           []string{"-c", pruningScript(pruningCommand)},
 			WorkingDir:     "/home/operator",
 			VolumeMounts:   oldPod.Spec.Containers[0].VolumeMounts,
 			ReadinessProbe: probes[0],
+			// If the script cannot write its termination message, e.g. the container is OOM killed, fall back to logs.
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
 	p.Spec.RestartPolicy = corev1.RestartPolicyNever
 
 	newPod := corev1.Pod(*p)
-	return ptr(newPod)
+	return ptr(newPod), nil
+}
+
+// pruningScript runs the pruning command in its own shell, so an exit within the command cannot skip recording its
+// exit code. Output is streamed to the container log and written to a file, whose last lines become the termination
+// message so the pruning controller can record them. The exit code is saved to a file because a pipeline only exits
+// with its last command's status.
+func pruningScript(pruningCommand string) string {
+	return fmt.Sprintf(`{ sh -c %s 2>&1; echo $? > /tmp/pruning.code; } | tee /tmp/pruning.log
+code=$(cat /tmp/pruning.code)
+tail -n 20 /tmp/pruning.log > /dev/termination-log
+exit $code`, shellQuote(pruningCommand))
+}
+
+// shellQuote quotes s as a single shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 func GetPrunerPodName(podName string) string {
@@ -1,8 +1,15 @@
 package fullnode
 
 import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
@@ -222,6 +229,20 @@ func TestPodBuilder(t *testing.T) {
 		require.Equal(t, []string{"start", "--home", "/home/operator/cosmos", "--foo", "bar"}, pod.Spec.Containers[0].Args)
 	})
 
+	t.Run("genesis checksum", func(t *testing.T) {
+		crd := defaultCRD()
+		require.NotContains(t, lo.Map(envVars(&crd), func(env corev1.EnvVar, _ int) string { return env.Name }), "GENESIS_SHA256")
+
+		const sha = "7a49293a495b5cad95a0f334aa30a7088975fdb3800e25d85142f1c36aac345a"
+		crd.Spec.ChainSpec.GenesisSHA256 = ptr(sha)
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		genesis, ok := lo.Find(pod.Spec.InitContainers, func(c corev1.Container) bool { return c.Name == "genesis-init" })
+		require.True(t, ok)
+		require.Contains(t, genesis.Env, corev1.EnvVar{Name: "GENESIS_SHA256", Value: sha})
+	})
+
 	t.Run("containers", func(t *testing.T) {
 		crd := defaultCRD()
 		const wantWrkDir = "/home/operator"
@@ -264,7 +285,7 @@ func TestPodBuilder(t *testing.T) {
 		require.Equal(t, "healthcheck", healthContainer.Name)
 		//require.Equal(t, "ghcr.io/strangelove-ventures/cosmos-operator:latest", healthContainer.Image)
 		require.Equal(t, "ghcr.io/bharvest-devops/cosmos-operator:latest", healthContainer.Image)
-		require.Equal(t, []string{"/manager", "healthcheck"}, healthContainer.Command)
+		require.Equal(t, []string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/cosmos"}, healthContainer.Command)
 		require.Empty(t, healthContainer.Args)
 		require.Empty(t, healthContainer.ImagePullPolicy)
 		require.NotEmpty(t, healthContainer.Resources)
@@ -351,6 +372,72 @@ func TestPodBuilder(t *testing.T) {
 		}
 	})
 
+	t.Run("containers - namada", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.ChainSpec.ChainID = "namada.5f5de2dd1b88cba30586420"
+		crd.Spec.ChainSpec.ChainType = chainTypeNamada
+		crd.Spec.ChainSpec.DatabaseBackend = ptr("goleveldb")
+		crd.Spec.ChainSpec.GenesisURL = ptr("https://example.com/namada-genesis.tar.gz")
+		crd.Spec.ChainSpec.Namada = &cosmosv1.NamadaConfig{SnapshotURL: ptr("https://example.com/namada.tar.lz4")}
+		crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{{Image: "namada:v1.0.0"}}
+		builder := NewPodBuilder(&crd)
+		pod, err := builder.WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		initConts := lo.Map(pod.Spec.InitContainers, func(c corev1.Container, _ int) string { return c.Name })
+		require.Equal(t, []string{"clean-init", "genesis-init", "chain-init", "addrbook-init", "config-merge", "snapshot-restore", "version-check"}, initConts)
+
+		restore := pod.Spec.InitContainers[5]
+		require.Contains(t, restore.Args[1], `export SNAPSHOT_DIR="$CHAIN_HOME/$CHAIN_ID"`)
+		require.Equal(t, "https://example.com/namada.tar.lz4", restore.Args[3])
+
+		wantArgs := []string{"-b", "goleveldb"}
+		require.Equal(t, append([]string{"/manager", "versioncheck"}, wantArgs...), pod.Spec.InitContainers[6].Command)
+		versionCheck, ok := lo.Find(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == "version-check-interval" })
+		require.True(t, ok)
+		require.Equal(t, append([]string{"/manager", "versioncheck", "-d"}, wantArgs...), versionCheck.Command)
+	})
+
+	t.Run("containers - drift remediation", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{
+				Remediation: []cosmosv1.DriftRemediationStep{{Action: cosmosv1.DriftRemediationRestart}},
+			},
+		}
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.NotContains(t, lo.Map(pod.Spec.InitContainers, func(c corev1.Container, _ int) string { return c.Name }), "drift-remediation")
+
+		crd.Spec.SelfHeal.HeightDriftMitigation.Remediation = append(crd.Spec.SelfHeal.HeightDriftMitigation.Remediation,
+			cosmosv1.DriftRemediationStep{Action: cosmosv1.DriftRemediationStateSync})
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		remediate := pod.Spec.InitContainers[1]
+		require.Equal(t, "drift-remediation", remediate.Name)
+		require.Equal(t, []string{"/manager", "driftremediate"}, remediate.Command)
+		require.Equal(t, envVars(&crd), remediate.Env)
+
+		// Peer health refreshes the address book.
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{PeerHealth: &cosmosv1.PeerHealthSpec{}}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.Contains(t, lo.Map(pod.Spec.InitContainers, func(c corev1.Container, _ int) string { return c.Name }), "drift-remediation")
+	})
+
+	t.Run("containers - crash loop healing", func(t *testing.T) {
+		crd := defaultCRD()
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.Empty(t, pod.Spec.Containers[0].TerminationMessagePolicy)
+
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{CrashLoopHealing: &cosmosv1.CrashLoopHealingSpec{}}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.Equal(t, corev1.TerminationMessageFallbackToLogsOnError, pod.Spec.Containers[0].TerminationMessagePolicy)
+	})
+
 	t.Run("volumes", func(t *testing.T) {
 		crd := defaultCRD()
 		builder := NewPodBuilder(&crd)
@@ -567,6 +654,70 @@ gaiad start --home /home/operator/cosmos`
 		require.Nil(t, sidecar.ReadinessProbe)
 	})
 
+	t.Run("readiness criteria", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.PodTemplate.Probes.Readiness = &cosmosv1.ReadinessCriteria{
+			MaxBlockAge:  &metav1.Duration{Duration: 90 * time.Second},
+			MinPeers:     3,
+			MaxHeightLag: 50,
+		}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		sidecar := pod.Spec.Containers[1]
+		require.Equal(t, "healthcheck", sidecar.Name)
+		want := []string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/cosmos", "--max-block-age", "1m30s", "--min-peers", "3", "--max-height-lag", "50", "--fullnode", "osmosis"}
+		require.Equal(t, want, sidecar.Command)
+
+		crd.Spec.PodTemplate.Probes.Readiness = &cosmosv1.ReadinessCriteria{MinPeers: 1}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+		require.Equal(t, []string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/cosmos", "--min-peers", "1"}, pod.Spec.Containers[1].Command)
+	})
+
+	t.Run("probe apis", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.PodTemplate.Probes.APIs = []cosmosv1.FullNodeProbeAPI{cosmosv1.FullNodeProbeAPIREST, cosmosv1.FullNodeProbeAPIGRPC, cosmosv1.FullNodeProbeAPIREST}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+		require.Equal(t, []string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/cosmos", "--require-apis", "rest,grpc"}, pod.Spec.Containers[1].Command)
+
+		crd.Spec.ChainSpec.ChainType = chainTypeNamada
+		crd.Spec.ChainSpec.GenesisURL = ptr("https://example.com/namada-genesis.tar.gz")
+		crd.Spec.ChainSpec.Namada = &cosmosv1.NamadaConfig{}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+		require.Equal(t, []string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/namada", "--namada"}, pod.Spec.Containers[1].Command)
+	})
+
+	t.Run("namada healthcheck", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.ChainSpec.ChainType = chainTypeNamada
+		crd.Spec.ChainSpec.GenesisURL = ptr("https://example.com/namada-genesis.tar.gz")
+		crd.Spec.ChainSpec.Namada = &cosmosv1.NamadaConfig{
+			Ledger: &cosmosv1.NamadaLedger{EthereumBridge: &cosmosv1.NamadaEthereumBridge{Mode: ptr("Off")}},
+		}
+
+		for _, tt := range []struct {
+			Mode     string
+			Endpoint *string
+			Want     []string
+		}{
+			{"Off", ptr("http://geth:8545"), nil},
+			{"EventsEndpoint", nil, nil},
+			{"RemoteEndpoint", nil, []string{"--namada-oracle-rpc", "http://127.0.0.1:8545"}},
+			{"SelfHostedEndpoint", ptr("http://geth:8545"), []string{"--namada-oracle-rpc", "http://geth:8545"}},
+		} {
+			crd.Spec.ChainSpec.Namada.Ledger.EthereumBridge = &cosmosv1.NamadaEthereumBridge{Mode: ptr(tt.Mode), OracleRPCEndpoint: tt.Endpoint}
+			pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+			require.NoError(t, err)
+			want := append([]string{"/manager", "healthcheck", "--disk-usage-dir", "/home/operator/namada", "--namada"}, tt.Want...)
+			require.Equal(t, want, pod.Spec.Containers[1].Command, tt.Mode)
+		}
+	})
+
 	t.Run("strategic merge fields", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Spec.PodTemplate.Volumes = []corev1.Volume{
@@ -673,3 +824,92 @@ func TestPVCName(t *testing.T) {
 
 	require.Equal(t, "pvc-osmosis-5", PVCName(pod))
 }
+
+func TestMainImage(t *testing.T) {
+	crd := defaultCRD()
+	appConfig := cosmosv1.SDKAppConfig{}
+	crd.Spec.ChainSpec.CosmosSDK = &appConfig
+	crd.Spec.PodTemplate.Image = "ghcr.io/cosmoshub:v1.0.0"
+	pod, err := NewPodBuilder(&crd).WithOrdinal(5).Build()
+	require.NoError(t, err)
+
+	require.Equal(t, "ghcr.io/cosmoshub:v1.0.0", MainImage(pod))
+
+	pod.Spec.Containers = pod.Spec.Containers[1:]
+
+	require.Empty(t, MainImage(pod))
+}
+
+func TestPruningScript(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+
+	for _, tt := range []struct {
+		Name     string
+		Command  string
+		WantCode int
+		WantLog  string
+	}{
+		{"success", "echo pruned", 0, "pruned\n"},
+		{"exit", "echo 'compacting \"data\"'; exit 3; echo unreachable", 3, "compacting \"data\"\n"},
+		{"failure", "echo failed >&2 && false", 1, "failed\n"},
+	} {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			script := pruningScript(tt.Command)
+			script = strings.ReplaceAll(script, "/tmp/pruning.", filepath.Join(dir, "pruning."))
+			script = strings.ReplaceAll(script, "/dev/termination-log", filepath.Join(dir, "termination-log"))
+
+			out, err := exec.Command("sh", "-c", script).Output()
+			var exitErr *exec.ExitError
+			if tt.WantCode == 0 {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.As(err, &exitErr), err)
+				require.Equal(t, tt.WantCode, exitErr.ExitCode())
+			}
+			require.Equal(t, tt.WantLog, string(out))
+
+			msg, err := os.ReadFile(filepath.Join(dir, "termination-log"))
+			require.NoError(t, err)
+			require.Equal(t, tt.WantLog, string(msg))
+		})
+	}
+
+	t.Run("streams output", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		script := pruningScript("echo compacting && sleep 30")
+		script = strings.ReplaceAll(script, "/tmp/pruning.", filepath.Join(dir, "pruning."))
+		script = strings.ReplaceAll(script, "/dev/termination-log", filepath.Join(dir, "termination-log"))
+
+		cmd := exec.Command("sh", "-c", script)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		stdout, err := cmd.StdoutPipe()
+		require.NoError(t, err)
+		require.NoError(t, cmd.Start())
+		t.Cleanup(func() {
+			_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			_ = cmd.Wait()
+		})
+
+		line := make(chan string, 1)
+		go func() {
+			got, _ := bufio.NewReader(stdout).ReadString('\n')
+			line <- got
+		}()
+		select {
+		case got := <-line:
+			require.Equal(t, "compacting\n", got)
+		case <-time.After(10 * time.Second):
+			t.Fatal("output not streamed while the command runs")
+		}
+	})
+}
@@ -59,7 +59,7 @@ func (pc PodControl) Reconcile(
 	); err != nil {
 		return false, kube.TransientError(fmt.Errorf("list existing pods: %w", err))
 	}
-	wantPods, err := BuildPods(crd, cksums)
+	wantPods, err := BuildPods(reporter, crd, cksums)
 	if err != nil {
 		return false, kube.UnrecoverableError(fmt.Errorf("build pods: %w", err))
 	}
@@ -131,9 +131,14 @@ func (pc PodControl) Reconcile(
 				if podName == update.Name {
 					if existing.Spec.Containers[0].Image != update.Spec.Containers[0].Image {
 						// awaiting upgrade
-						if !rpcReachable {
+						halted := UpgradeHalted(crd, podName, update.Spec.Containers[0].Image)
+						if !rpcReachable || halted {
 							updatedPods++
 							reporter.Info("Deleting pod for version upgrade", "name", podName)
+							if halted {
+								reporter.RecordInfo("UpgradeRestart", fmt.Sprintf("Recreating pod %s halted at upgrade height %d with image %s",
+									podName, crd.Status.Upgrades[podName].Height, update.Spec.Containers[0].Image))
+							}
 							// Because we should watch for deletes, we get a re-queued request, detect pod is missing, and re-create it.
 							if err := pc.client.Delete(ctx, update, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
 								return true, kube.TransientError(fmt.Errorf("upgrade pod version %q: %w", podName, err))
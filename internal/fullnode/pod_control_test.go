@@ -71,7 +71,7 @@ func TestPodControl_Reconcile(t *testing.T) {
 		appConfig := cosmosv1.SDKAppConfig{}
 		crd.Spec.ChainSpec.CosmosSDK = &appConfig
 
-		pods, err := BuildPods(&crd, nil)
+		pods, err := BuildPods(nopReporter, &crd, nil)
 		require.NoError(t, err)
 		existing := diff.New(nil, pods).Creates()
 
@@ -132,7 +132,7 @@ func TestPodControl_Reconcile(t *testing.T) {
 			MaxUnavailable: ptr(intstr.FromInt(2)),
 		}
 
-		pods, err := BuildPods(&crd, nil)
+		pods, err := BuildPods(nopReporter, &crd, nil)
 		require.NoError(t, err)
 
 		mClient := newMockPodClient(diff.New(nil, pods).Creates())
@@ -228,7 +228,7 @@ func TestPodControl_Reconcile(t *testing.T) {
 		}
 		crd.Status.Height = make(map[string]uint64)
 
-		pods, err := BuildPods(&crd, nil)
+		pods, err := BuildPods(nopReporter, &crd, nil)
 		require.NoError(t, err)
 		existing := diff.New(nil, pods).Creates()
 
@@ -399,6 +399,63 @@ func TestPodControl_Reconcile(t *testing.T) {
 		require.Equal(t, 5, mClient.DeleteCount)
 	})
 
+	t.Run("rollout version upgrade halted pods", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Name = "hub"
+		crd.Namespace = namespace
+		crd.Spec.Replicas = 3
+		crd.Spec.RolloutStrategy = cosmosv1.RolloutStrategy{
+			MaxUnavailable: ptr(intstr.FromInt(1)),
+		}
+		cometConfig := cosmosv1.CometBFTConfig{}
+		appConfig := cosmosv1.SDKAppConfig{}
+		crd.Spec.ChainSpec = cosmosv1.ChainSpec{
+			Versions: []cosmosv1.ChainVersion{
+				{Image: "image"},
+				{UpgradeHeight: 100, Image: "new-image"},
+			},
+			CometBFT:  &cometConfig,
+			CosmosSDK: &appConfig,
+		}
+
+		pods, err := BuildPods(nopReporter, &crd, nil)
+		require.NoError(t, err)
+		existing := diff.New(nil, pods).Creates()
+
+		mClient := newMockPodClient(existing)
+
+		// The rpc of a halted pod may still be reachable.
+		syncInfo := map[string]*cosmosv1.SyncInfoPodStatus{
+			"hub-0": {Height: ptr(uint64(99)), InSync: ptr(true)},
+			"hub-1": {Height: ptr(uint64(99)), InSync: ptr(true)},
+			"hub-2": {Height: ptr(uint64(99)), InSync: ptr(true)},
+		}
+
+		crd.Status.Height = map[string]uint64{"hub-0": 100, "hub-1": 100, "hub-2": 100}
+		crd.Status.Upgrades = map[string]*cosmosv1.UpgradeStatus{
+			"hub-0": {Phase: cosmosv1.UpgradePhaseHalted, Height: 100, Image: "new-image"},
+			"hub-1": {Phase: cosmosv1.UpgradePhaseHalted, Height: 100, Image: "new-image"},
+			"hub-2": {Phase: cosmosv1.UpgradePhasePending, Height: 100, Image: "new-image"},
+		}
+
+		control := NewPodControl(mClient, nil)
+		control.computeRollout = func(maxUnavail *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, 3, ready)
+			return kube.ComputeRollout(maxUnavail, desired, ready)
+		}
+
+		requeue, err := control.Reconcile(ctx, nopReporter, &crd, nil, syncInfo)
+		require.NoError(t, err)
+		require.True(t, requeue)
+
+		// Halted pods are recreated regardless of the rollout budget.
+		require.Zero(t, mClient.CreateCount)
+		require.Equal(t, 2, mClient.DeleteCount)
+		require.Equal(t, "version upgrade in progress", *syncInfo["hub-0"].Error)
+		require.Equal(t, "version upgrade in progress", *syncInfo["hub-1"].Error)
+		require.Nil(t, syncInfo["hub-2"].Error)
+	})
+
 	t.Run("rollout version upgrade halt", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Name = "hub"
@@ -425,7 +482,7 @@ func TestPodControl_Reconcile(t *testing.T) {
 		}
 		crd.Status.Height = make(map[string]uint64)
 
-		pods, err := BuildPods(&crd, nil)
+		pods, err := BuildPods(nopReporter, &crd, nil)
 		require.NoError(t, err)
 		existing := diff.New(nil, pods).Creates()
 
@@ -0,0 +1,133 @@
+package fullnode
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/samber/lo"
+)
+
+const (
+	pruningKeepRecentDefault = 100
+	pruningKeepBlocksDefault = 100_000
+)
+
+// PruningStrategy builds the command a pruner container runs to reclaim disk space.
+type PruningStrategy interface {
+	// Image is the default image for the strategy.
+	Image() string
+	// Command is the shell command run by the pruner container.
+	Command() string
+}
+
+// chainData are the database locations and backends of a chain's home directory.
+type chainData struct {
+	// Tendermint/CometBFT data. Contains blockstore.db, state.db, tx_index.db, and, for Cosmos SDK chains,
+	// application.db.
+	cometDir     string
+	cometBackend string
+	// Application database directory if separate from cometDir.
+	// cosmos-pruner cannot open it, so it is only removed by resyncStrategy.
+	appDir string
+}
+
+func newChainData(crd *cosmosv1.CosmosFullNode) chainData {
+	home := ChainHomeDir(crd)
+	backend := lo.FromPtr(crd.Spec.ChainSpec.DatabaseBackend)
+	if backend == "" {
+		backend = "goleveldb"
+	}
+	if crd.Spec.ChainSpec.ChainType == chainTypeNamada {
+		// The databaseBackend applies to Namada's CometBFT stores. The ledger's state is RocksDB with column families
+		// cosmos-pruner does not open.
+		return chainData{
+			cometDir:     path.Join(home, getCometbftDir(crd), "data"),
+			cometBackend: backend,
+			appDir:       path.Join(home, crd.Spec.ChainSpec.ChainID, "db"),
+		}
+	}
+	return chainData{cometDir: path.Join(home, "data"), cometBackend: backend}
+}
+
+// NewPruningStrategy returns the pruning strategy for the crd's pruning spec, chain type, and database backend.
+// Returns an error if the chain type does not support the strategy.
+func NewPruningStrategy(crd *cosmosv1.CosmosFullNode) (PruningStrategy, error) {
+	var spec cosmosv1.PruningSpec
+	if crd.Spec.SelfHeal != nil && crd.Spec.SelfHeal.PruningSpec != nil {
+		spec = *crd.Spec.SelfHeal.PruningSpec
+	}
+	data := newChainData(crd)
+
+	switch spec.Strategy {
+	case cosmosv1.PrunerStrategyCompact, "":
+		return compactStrategy{data}, nil
+	case cosmosv1.PrunerStrategyPruneAppState:
+		if data.appDir != "" {
+			return nil, fmt.Errorf("pruning strategy %s is not supported for chain type %s", spec.Strategy, crd.Spec.ChainSpec.ChainType)
+		}
+		return pruneAppStateStrategy{data: data, keepRecent: lo.Ternary(spec.KeepRecent != nil, lo.FromPtr(spec.KeepRecent), pruningKeepRecentDefault)}, nil
+	case cosmosv1.PrunerStrategyPruneBlocks:
+		return pruneBlocksStrategy{data: data, keepBlocks: lo.Ternary(spec.KeepBlocks != nil, lo.FromPtr(spec.KeepBlocks), pruningKeepBlocksDefault)}, nil
+	case cosmosv1.PrunerStrategyResync:
+		return resyncStrategy{data}, nil
+	}
+	return nil, fmt.Errorf("unknown pruning strategy %q", spec.Strategy)
+}
+
+func compactCommand(dir, backend string) string {
+	return fmt.Sprintf("cosmos-pruner compact %s --backend=%s", dir, backend)
+}
+
+// compactStrategy compacts the Tendermint/CometBFT databases in place without deleting data.
+// A separate application database, i.e. Namada's ledger state, is not compacted.
+type compactStrategy struct{ data chainData }
+
+func (s compactStrategy) Image() string { return PRUNING_POD_IMAGE_DEFAULT }
+
+func (s compactStrategy) Command() string {
+	return compactCommand(s.data.cometDir, s.data.cometBackend)
+}
+
+// pruneAppStateStrategy deletes old application state versions. Only Cosmos SDK chains are supported.
+type pruneAppStateStrategy struct {
+	data       chainData
+	keepRecent int64
+}
+
+func (s pruneAppStateStrategy) Image() string { return PRUNING_POD_IMAGE_DEFAULT }
+
+func (s pruneAppStateStrategy) Command() string {
+	return fmt.Sprintf("cosmos-pruner prune %s --backend=%s --cosmos-sdk=true --versions=%d --blocks=0 --compact=true",
+		s.data.cometDir, s.data.cometBackend, s.keepRecent)
+}
+
+// pruneBlocksStrategy deletes old blocks, states, and transaction indexes from the block store.
+type pruneBlocksStrategy struct {
+	data       chainData
+	keepBlocks int64
+}
+
+func (s pruneBlocksStrategy) Image() string { return PRUNING_POD_IMAGE_DEFAULT }
+
+func (s pruneBlocksStrategy) Command() string {
+	return fmt.Sprintf("cosmos-pruner prune %s --backend=%s --cosmos-sdk=false --blocks=%d --tx_index=true --compact=true",
+		s.data.cometDir, s.data.cometBackend, s.keepBlocks)
+}
+
+// resyncStrategy deletes all chain data so the node restores from a snapshot or state syncs on restart.
+// The validator's last signed state is kept to prevent double signing.
+type resyncStrategy struct{ data chainData }
+
+func (s resyncStrategy) Image() string { return infraToolImage }
+
+func (s resyncStrategy) Command() string {
+	cmds := []string{
+		fmt.Sprintf("find %s -mindepth 1 -maxdepth 1 ! -name priv_validator_state.json -exec rm -rf {} +", s.data.cometDir),
+	}
+	if s.data.appDir != "" {
+		cmds = append(cmds, fmt.Sprintf("rm -rf %s", s.data.appDir))
+	}
+	return strings.Join(cmds, " && ")
+}
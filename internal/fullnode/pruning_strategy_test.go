@@ -0,0 +1,100 @@
+package fullnode
+
+import (
+	"testing"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPruningStrategy(t *testing.T) {
+	t.Parallel()
+
+	newCRD := func(chainType, backend string, spec cosmosv1.PruningSpec) *cosmosv1.CosmosFullNode {
+		crd := defaultCRD()
+		crd.Spec.ChainSpec.ChainID = "namada-dryrun.abaaeaf7b78cb3ac"
+		crd.Spec.ChainSpec.ChainType = chainType
+		if backend != "" {
+			crd.Spec.ChainSpec.DatabaseBackend = ptr(backend)
+		}
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{PruningSpec: &spec}
+		return &crd
+	}
+
+	for _, tt := range []struct {
+		Name      string
+		CRD       *cosmosv1.CosmosFullNode
+		WantImage string
+		WantCmd   string
+	}{
+		{
+			"default",
+			newCRD("", "", cosmosv1.PruningSpec{}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner compact /home/operator/cosmos/data --backend=goleveldb",
+		},
+		{
+			"compact pebbledb",
+			newCRD("cosmos", "pebbledb", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyCompact}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner compact /home/operator/cosmos/data --backend=pebbledb",
+		},
+		{
+			"compact namada",
+			newCRD("namada", "", cosmosv1.PruningSpec{}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner compact /home/operator/namada/namada-dryrun.abaaeaf7b78cb3ac/cometbft/data --backend=goleveldb",
+		},
+		{
+			"prune app state",
+			newCRD("cosmos", "rocksdb", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneAppState, KeepRecent: ptr(int64(10))}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner prune /home/operator/cosmos/data --backend=rocksdb --cosmos-sdk=true --versions=10 --blocks=0 --compact=true",
+		},
+		{
+			"prune app state default",
+			newCRD("cosmos", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneAppState}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner prune /home/operator/cosmos/data --backend=goleveldb --cosmos-sdk=true --versions=100 --blocks=0 --compact=true",
+		},
+		{
+			"prune blocks",
+			newCRD("cosmos", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneBlocks}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner prune /home/operator/cosmos/data --backend=goleveldb --cosmos-sdk=false --blocks=100000 --tx_index=true --compact=true",
+		},
+		{
+			"prune blocks namada",
+			newCRD("namada", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneBlocks, KeepBlocks: ptr(int64(5000))}),
+			PRUNING_POD_IMAGE_DEFAULT,
+			"cosmos-pruner prune /home/operator/namada/namada-dryrun.abaaeaf7b78cb3ac/cometbft/data --backend=goleveldb --cosmos-sdk=false --blocks=5000 --tx_index=true --compact=true",
+		},
+		{
+			"resync",
+			newCRD("cosmos", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyResync}),
+			infraToolImage,
+			"find /home/operator/cosmos/data -mindepth 1 -maxdepth 1 ! -name priv_validator_state.json -exec rm -rf {} +",
+		},
+		{
+			"resync namada",
+			newCRD("namada", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyResync}),
+			infraToolImage,
+			"find /home/operator/namada/namada-dryrun.abaaeaf7b78cb3ac/cometbft/data -mindepth 1 -maxdepth 1 ! -name priv_validator_state.json -exec rm -rf {} + && " +
+				"rm -rf /home/operator/namada/namada-dryrun.abaaeaf7b78cb3ac/db",
+		},
+	} {
+		strategy, err := NewPruningStrategy(tt.CRD)
+
+		require.NoError(t, err, tt.Name)
+		require.Equal(t, tt.WantImage, strategy.Image(), tt.Name)
+		require.Equal(t, tt.WantCmd, strategy.Command(), tt.Name)
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewPruningStrategy(newCRD("namada", "", cosmosv1.PruningSpec{Strategy: cosmosv1.PrunerStrategyPruneAppState}))
+		require.EqualError(t, err, "pruning strategy PruneAppState is not supported for chain type namada")
+
+		_, err = NewPruningStrategy(newCRD("", "", cosmosv1.PruningSpec{Strategy: "bogus"}))
+		require.EqualError(t, err, `unknown pruning strategy "bogus"`)
+	})
+}
@@ -61,10 +61,23 @@ func BuildPVCs(
 		pvc.Name = name
 		pvc.Labels[kube.InstanceLabel] = instanceName(crd, i)
 
-		var dataSource *corev1.TypedLocalObjectReference
-		var existingSize resource.Quantity
+		var (
+			dataSource    *corev1.TypedLocalObjectReference
+			dataSourceRef *corev1.TypedObjectReference
+			existingSize  resource.Quantity
+		)
 		if ds, ok := dataSources[i]; ok && ds != nil {
-			dataSource = ds.ref
+			if ds.namespace != "" && ds.ref != nil {
+				// Cross-namespace data sources are only supported by dataSourceRef.
+				dataSourceRef = &corev1.TypedObjectReference{
+					APIGroup:  ds.ref.APIGroup,
+					Kind:      ds.ref.Kind,
+					Name:      ds.ref.Name,
+					Namespace: ptr(ds.namespace),
+				}
+			} else {
+				dataSource = ds.ref
+			}
 		} else {
 			for _, pvc := range currentPVCs {
 				if pvc.Name == name {
@@ -96,6 +109,7 @@ func BuildPVCs(
 
 		pvcs = append(pvcs, diff.Adapt(pvc, i))
 		pvc.Spec.DataSource = dataSource
+		pvc.Spec.DataSourceRef = dataSourceRef
 	}
 	return pvcs
 }
@@ -20,7 +20,7 @@ import (
 // Unlike StatefulSet, PVCControl will update volumes by deleting and recreating volumes.
 type PVCControl struct {
 	client               Client
-	recentVolumeSnapshot func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error)
+	recentVolumeSnapshot func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error)
 }
 
 // NewPVCControl returns a valid PVCControl
@@ -158,6 +158,8 @@ func (control PVCControl) shouldRetain(crd *cosmosv1.CosmosFullNode) bool {
 
 type dataSource struct {
 	ref *corev1.TypedLocalObjectReference
+	// If set, ref is in another namespace and must be referenced via the PVC's dataSourceRef.
+	namespace string
 
 	size resource.Quantity
 }
@@ -220,7 +222,19 @@ func (control PVCControl) findDataSourceWithPvcSpec(
 	if spec.MatchInstance {
 		selector[kube.InstanceLabel] = instanceName(crd, ordinal)
 	}
-	found, err := control.recentVolumeSnapshot(ctx, control.client, crd.Namespace, selector)
+	var filters []kube.VolumeSnapshotFilter
+	if spec.MinHeight != nil {
+		filters = append(filters, kube.MinHeightFilter(*spec.MinHeight))
+	}
+	if spec.MatchImage != "" {
+		filters = append(filters, kube.ImageFilter(spec.MatchImage))
+	}
+	namespace := crd.Namespace
+	if spec.Namespace != "" && spec.Namespace != crd.Namespace {
+		namespace = spec.Namespace
+		filters = append(filters, kube.NamespaceAllowedFilter(crd.Namespace))
+	}
+	found, err := control.recentVolumeSnapshot(ctx, control.client, namespace, selector, filters...)
 	if err != nil {
 		reporter.Error(err, "Failed to find VolumeSnapshot for AutoDataSource")
 		reporter.RecordError("AutoDataSourceFindSnapshot", err)
@@ -234,6 +248,7 @@ func (control PVCControl) findDataSourceWithPvcSpec(
 			Kind:     "VolumeSnapshot",
 			Name:     found.Name,
 		},
-		size: *found.Status.RestoreSize,
+		namespace: lo.Ternary(namespace != crd.Namespace, namespace, ""),
+		size:      *found.Status.RestoreSize,
 	}
 }
@@ -29,7 +29,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 
 	testPVCControl := func(client Client) PVCControl {
 		control := NewPVCControl(client)
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
 			panic("recentVolumeSnapshot should not be called")
 		}
 		return control
@@ -113,11 +113,12 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		}
 
 		var volCallCount int
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
 			require.NotNil(t, ctx)
 			require.Equal(t, &mClient, lister)
 			require.Equal(t, namespace, namespace)
 			require.Equal(t, map[string]string{"label": "vol-snapshot"}, selector)
+			require.Empty(t, filters)
 			var stub snapshotv1.VolumeSnapshot
 			stub.Name = "found-snapshot"
 			stub.Status = &snapshotv1.VolumeSnapshotStatus{
@@ -146,6 +147,92 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		}
 	})
 
+	t.Run("create - autoDataSource with filters", func(t *testing.T) {
+		var (
+			mClient mockPVCClient
+			crd     = defaultCRD()
+			control = testPVCControl(&mClient)
+		)
+		crd.Namespace = namespace
+		crd.Spec.Replicas = 1
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{
+			VolumeSnapshotSelector: map[string]string{"label": "vol-snapshot"},
+			MinHeight:              ptr(uint64(100)),
+			MatchImage:             "ghcr.io/cosmos/gaia:v1.0.0",
+		}
+
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
+			require.Len(t, filters, 2)
+
+			var match snapshotv1.VolumeSnapshot
+			match.Annotations = map[string]string{
+				kube.SnapshotHeightAnnotation: "100",
+				kube.SnapshotImageAnnotation:  "ghcr.io/cosmos/gaia:v1.0.0",
+			}
+			for _, filter := range filters {
+				require.True(t, filter(match))
+			}
+
+			var stub snapshotv1.VolumeSnapshot
+			stub.Name = "found-snapshot"
+			stub.Status = &snapshotv1.VolumeSnapshotStatus{
+				ReadyToUse:  ptr(true),
+				RestoreSize: ptr(resource.MustParse("100Gi")),
+			}
+			return &stub, nil
+		}
+		_, err := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, err)
+		require.Equal(t, 1, mClient.CreateCount)
+	})
+
+	t.Run("create - autoDataSource in another namespace", func(t *testing.T) {
+		var (
+			mClient mockPVCClient
+			crd     = defaultCRD()
+			control = testPVCControl(&mClient)
+		)
+		crd.Namespace = namespace
+		crd.Spec.Replicas = 1
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{
+			VolumeSnapshotSelector: map[string]string{"label": "vol-snapshot"},
+			Namespace:              "snapshots",
+		}
+
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, gotNamespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
+			require.Equal(t, "snapshots", gotNamespace)
+			require.Len(t, filters, 1)
+
+			var vs snapshotv1.VolumeSnapshot
+			vs.Namespace = "snapshots"
+			require.False(t, filters[0](vs))
+			vs.Annotations = map[string]string{kube.SnapshotAllowedNamespacesAnnotation: namespace}
+			require.True(t, filters[0](vs))
+
+			var stub snapshotv1.VolumeSnapshot
+			stub.Name = "found-snapshot"
+			stub.Status = &snapshotv1.VolumeSnapshotStatus{
+				ReadyToUse:  ptr(true),
+				RestoreSize: ptr(resource.MustParse("100Gi")),
+			}
+			return &stub, nil
+		}
+		_, err := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, err)
+		require.Equal(t, 1, mClient.CreateCount)
+
+		got := mClient.CreatedObjects[0]
+		require.Nil(t, got.Spec.DataSource)
+		want := corev1.TypedObjectReference{
+			APIGroup:  ptr("snapshot.storage.k8s.io"),
+			Kind:      "VolumeSnapshot",
+			Name:      "found-snapshot",
+			Namespace: ptr("snapshots"),
+		}
+		require.NotNil(t, got.Spec.DataSourceRef)
+		require.Equal(t, want, *got.Spec.DataSourceRef)
+	})
+
 	t.Run("create - autoDataSource dataSource already set", func(t *testing.T) {
 		var (
 			mClient mockPVCClient
@@ -164,7 +251,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		}
 		crd.Spec.VolumeClaimTemplate.DataSource = crdDataSource
 
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
 			panic("should not be called")
 		}
 
@@ -204,7 +291,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 			VolumeSnapshotSelector: map[string]string{"label": "vol-snapshot"},
 		}
 		var volCallCount int
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...kube.VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
 			volCallCount++
 			return nil, errors.New("boom")
 		}
@@ -14,6 +14,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,8 +25,16 @@ type DiskUsager interface {
 
 type PVCDiskUsage struct {
 	Name        string // pvc name
+	PodName     string
 	PercentUsed int
+	UsedBytes   int64
 	Capacity    resource.Quantity
+	// Zero if the filesystem does not report inodes.
+	PercentInodesUsed int
+	// Sizes of directories in the chain home dir, if computed yet by the healthcheck sidecar.
+	Dirs []healthcheck.DirUsage
+	// When the healthcheck sidecar computed Dirs.
+	DirsUpdatedAt *time.Time
 }
 
 type DiskUsageCollector struct {
@@ -91,10 +100,18 @@ func (c DiskUsageCollector) CollectDiskUsage(ctx context.Context, crd *cosmosv1.
 			}
 
 			found[i].Name = name
+			found[i].PodName = pod.Name
+			found[i].UsedBytes = int64(resp.AllBytes - resp.FreeBytes)
 			found[i].Capacity = pvc.Status.Capacity[corev1.ResourceStorage]
 			n := (float64(resp.AllBytes-resp.FreeBytes) / float64(resp.AllBytes)) * 100
 			n = math.Round(n)
 			found[i].PercentUsed = int(n)
+			if resp.AllInodes > 0 {
+				n = (float64(resp.AllInodes-resp.FreeInodes) / float64(resp.AllInodes)) * 100
+				found[i].PercentInodesUsed = int(math.Round(n))
+			}
+			found[i].Dirs = resp.Dirs
+			found[i].DirsUpdatedAt = resp.DirsUpdatedAt
 			return nil
 		})
 	}
@@ -108,5 +125,36 @@ func (c DiskUsageCollector) CollectDiskUsage(ctx context.Context, crd *cosmosv1.
 		return nil, errors.Join(errs...)
 	}
 
-	return lo.Compact(found), nil
+	return lo.Filter(found, func(item PVCDiskUsage, _ int) bool {
+		return item.Name != ""
+	}), nil
+}
+
+// DiskUsageStatus converts usage into status.selfHealing.diskUsage keyed by PVC name.
+// The result only changes when the usage changes, so it can be compared with the current status to avoid
+// needless status updates.
+func DiskUsageStatus(usage []PVCDiskUsage) map[string]*cosmosv1.DiskUsageStatus {
+	status := make(map[string]*cosmosv1.DiskUsageStatus, len(usage))
+	for _, u := range usage {
+		stat := &cosmosv1.DiskUsageStatus{
+			PodName:     u.PodName,
+			PercentUsed: int32(u.PercentUsed),
+		}
+		if u.PercentInodesUsed > 0 {
+			stat.PercentInodesUsed = ptr(int32(u.PercentInodesUsed))
+		}
+		if u.DirsUpdatedAt != nil {
+			// The status is serialized with second precision.
+			stat.DirectoriesUpdatedAt = ptr(metav1.NewTime(u.DirsUpdatedAt.Truncate(time.Second)))
+		}
+		for _, dir := range u.Dirs {
+			stat.Directories = append(stat.Directories, cosmosv1.DirectoryUsage{
+				Path:   dir.Path,
+				Size:   *resource.NewQuantity(int64(dir.Bytes), resource.BinarySI),
+				Inodes: int64(dir.Inodes),
+			})
+		}
+		status[u.Name] = stat
+	}
+	return status
 }
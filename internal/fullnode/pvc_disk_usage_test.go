@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/healthcheck"
@@ -74,8 +75,11 @@ func TestCollectDiskUsage(t *testing.T) {
 				panic(fmt.Errorf("unknown host: %s", host))
 			}
 			return healthcheck.DiskUsageResponse{
-				AllBytes:  1000,
-				FreeBytes: free,
+				AllBytes:   1000,
+				FreeBytes:  free,
+				AllInodes:  200,
+				FreeInodes: 50,
+				Dirs:       []healthcheck.DirUsage{{Path: "data", Bytes: 100, Inodes: 10}},
 			}, nil
 		})
 
@@ -103,8 +107,12 @@ func TestCollectDiskUsage(t *testing.T) {
 
 		result := got[0]
 		require.Equal(t, "pvc-cosmoshub-0", result.Name)
+		require.Equal(t, "cosmoshub-0", result.PodName)
 		require.Equal(t, 10, result.PercentUsed)
+		require.EqualValues(t, 100, result.UsedBytes)
 		require.Equal(t, resource.MustParse("500Gi"), result.Capacity)
+		require.Equal(t, 75, result.PercentInodesUsed)
+		require.Equal(t, []healthcheck.DirUsage{{Path: "data", Bytes: 100, Inodes: 10}}, result.Dirs)
 
 		result = got[1]
 		require.Equal(t, "pvc-cosmoshub-1", result.Name)
@@ -114,6 +122,7 @@ func TestCollectDiskUsage(t *testing.T) {
 		result = got[2]
 		require.Equal(t, "pvc-cosmoshub-2", result.Name)
 		require.Equal(t, 99, result.PercentUsed) // Tests rounding to be close to output of `df`
+		require.EqualValues(t, 985, result.UsedBytes)
 		require.Equal(t, resource.MustParse("500Gi"), result.Capacity)
 	})
 
@@ -222,3 +231,37 @@ func TestCollectDiskUsage(t *testing.T) {
 		require.Contains(t, err.Error(), "pod 2 /some/dir: boom")
 	})
 }
+
+func TestDiskUsageStatus(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 999, time.UTC)
+	got := DiskUsageStatus([]PVCDiskUsage{
+		{
+			Name:              "pvc-cosmoshub-0",
+			PodName:           "cosmoshub-0",
+			PercentUsed:       10,
+			PercentInodesUsed: 75,
+			Dirs:              []healthcheck.DirUsage{{Path: "data/application.db", Bytes: 2048, Inodes: 10}},
+			DirsUpdatedAt:     &updatedAt,
+		},
+		{Name: "pvc-cosmoshub-1", PodName: "cosmoshub-1", PercentUsed: 50},
+	})
+
+	want := map[string]*cosmosv1.DiskUsageStatus{
+		"pvc-cosmoshub-0": {
+			PodName:           "cosmoshub-0",
+			PercentUsed:       10,
+			PercentInodesUsed: ptr(int32(75)),
+			Directories: []cosmosv1.DirectoryUsage{
+				{Path: "data/application.db", Size: *resource.NewQuantity(2048, resource.BinarySI), Inodes: 10},
+			},
+			DirectoriesUpdatedAt: ptr(metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))),
+		},
+		"pvc-cosmoshub-1": {
+			PodName:     "cosmoshub-1",
+			PercentUsed: 50,
+		},
+	}
+	require.Equal(t, want, got)
+}
@@ -45,6 +45,34 @@ func NewPVCHealer(client StatusSyncer) *PVCHealer {
 //
 // Returns an error if patching unsuccessful.
 func (healer PVCHealer) SignalPVCResize(ctx context.Context, crd *cosmosv1.CosmosFullNode, results []PVCDiskUsage) (bool, error) {
+	patches, joinedErr := healer.resizePatches(crd, results)
+	if len(patches) == 0 {
+		return false, joinedErr
+	}
+
+	return true, errors.Join(joinedErr, healer.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.PVCAutoScale == nil {
+			status.SelfHealing.PVCAutoScale = patches
+			return
+		}
+		for k, v := range patches {
+			status.SelfHealing.PVCAutoScale[k] = v
+		}
+	}))
+}
+
+// PlanPVCResize returns the sizes SignalPVCResize would request keyed by PVC name, without patching the status.
+// Used in dry-run mode.
+func (healer PVCHealer) PlanPVCResize(crd *cosmosv1.CosmosFullNode, results []PVCDiskUsage) (map[string]resource.Quantity, error) {
+	patches, err := healer.resizePatches(crd, results)
+	sizes := make(map[string]resource.Quantity, len(patches))
+	for name, patch := range patches {
+		sizes[name] = patch.RequestedSize
+	}
+	return sizes, err
+}
+
+func (healer PVCHealer) resizePatches(crd *cosmosv1.CosmosFullNode, results []PVCDiskUsage) (map[string]*cosmosv1.PVCAutoScaleStatus, error) {
 	var (
 		spec    = crd.Spec.SelfHeal.PVCAutoScale
 		trigger = int(spec.UsedSpacePercentage)
@@ -95,19 +123,7 @@ func (healer PVCHealer) SignalPVCResize(ctx context.Context, crd *cosmosv1.Cosmo
 		}
 	}
 
-	if len(patches) == 0 {
-		return false, joinedErr
-	}
-
-	return true, errors.Join(joinedErr, healer.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
-		if status.SelfHealing.PVCAutoScale == nil {
-			status.SelfHealing.PVCAutoScale = patches
-			return
-		}
-		for k, v := range patches {
-			status.SelfHealing.PVCAutoScale[k] = v
-		}
-	}))
+	return patches, joinedErr
 }
 
 func (healer PVCHealer) calcNextCapacity(current resource.Quantity, increase string) (resource.Quantity, error) {
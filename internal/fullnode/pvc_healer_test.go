@@ -286,6 +286,37 @@ func TestPVCHealer_SignalPVCResize(t *testing.T) {
 	})
 }
 
+func TestPVCHealer_PlanPVCResize(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+		PVCAutoScale: &cosmosv1.PVCAutoScaleSpec{
+			UsedSpacePercentage: 80,
+			IncreaseQuantity:    "10%",
+		},
+	}
+	crd.Status.SelfHealing.PVCAutoScale = map[string]*cosmosv1.PVCAutoScaleStatus{
+		"pvc-1": {RequestedSize: resource.MustParse("110Gi")},
+	}
+
+	scaler := NewPVCHealer(mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+		panic("should not be called")
+	}))
+	usage := []PVCDiskUsage{
+		{Name: "pvc-0", PercentUsed: 80, Capacity: resource.MustParse("100Gi")},
+		{Name: "pvc-1", PercentUsed: 90, Capacity: resource.MustParse("100Gi")},
+		{Name: "pvc-2", PercentUsed: 79, Capacity: resource.MustParse("100Gi")},
+	}
+	got, err := scaler.PlanPVCResize(&crd, usage)
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	want := resource.MustParse("110Gi")
+	size := got["pvc-0"]
+	require.Equal(t, want.Value(), size.Value())
+}
+
 func TestPVCHealder_UpdatePodFailure(t *testing.T) {
 	t.Parallel()
 
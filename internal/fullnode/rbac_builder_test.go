@@ -73,6 +73,11 @@ func TestBuildRBAC(t *testing.T) {
 				Resources: []string{"cosmosfullnodes/status"},
 				Verbs:     []string{"patch"},
 			},
+			{
+				APIGroups: []string{""}, // core API group
+				Resources: []string{"events"},
+				Verbs:     []string{"create", "patch"},
+			},
 		}, role.Rules)
 
 		rbs := BuildRoleBindings(&crd)
@@ -1,10 +1,7 @@
 set -eu
 
 # $ADDRBOOK_FILE and $CONFIG_DIR already set via pod env vars.
-
-ADDRBOOK_URL="$1"
-
-echo "Downloading address book file $ADDRBOOK_URL to $ADDRBOOK_FILE..."
+# Each argument is a URL tried in order until a download succeeds.
 
 download_json() {
   echo "Downloading plain json..."
@@ -28,26 +25,40 @@ download_targz() {
 
 download_zip() {
   echo "Downloading and extracting zip..."
-  wget -c -O tmp_genesis.zip "$ADDRBOOK_URL"
-  unzip tmp_genesis.zip
-  rm tmp_genesis.zip
-  mv genesis.json "$ADDRBOOK_FILE"
+  wget -c -O tmp_genesis.zip "$ADDRBOOK_URL" &&
+    unzip -o tmp_genesis.zip &&
+    rm tmp_genesis.zip &&
+    mv genesis.json "$ADDRBOOK_FILE"
+}
+
+download() {
+  case "$ADDRBOOK_URL" in
+  *.json.gz) download_jsongz ;;
+  *.json) download_json ;;
+  *.tar.gz) download_targz ;;
+  *.tar.gzip) download_targz ;;
+  *.tar) download_tar ;;
+  *.zip) download_zip ;;
+  *)
+    echo "Unable to handle file extension for $ADDRBOOK_URL"
+    return 1
+    ;;
+  esac
+}
+
+download_first() {
+  for ADDRBOOK_URL in "$@"; do
+    echo "Downloading address book file $ADDRBOOK_URL to $ADDRBOOK_FILE..."
+    rm -f "$ADDRBOOK_FILE"
+    if download; then
+      echo "Saved address book file to $ADDRBOOK_FILE."
+      echo "Download address book file complete."
+      return 0
+    fi
+    echo "Failed to download address book file from $ADDRBOOK_URL."
+  done
+  echo "Unable to download address book file from any URL."
+  return 1
 }
 
-rm -f "$ADDRBOOK_FILE"
-
-case "$ADDRBOOK_URL" in
-*.json.gz) download_jsongz ;;
-*.json) download_json ;;
-*.tar.gz) download_targz ;;
-*.tar.gzip) download_targz ;;
-*.tar) download_tar ;;
-*.zip) download_zip ;;
-*)
-  echo "Unable to handle file extension for $ADDRBOOK_URL"
-  exit 1
-  ;;
-esac
-
-echo "Saved address book file to $ADDRBOOK_FILE."
-echo "Download address book file complete."
+download_first "$@"
@@ -1,10 +1,7 @@
 set -eu
 
 # $GENESIS_FILE and $CONFIG_DIR already set via pod env vars.
-
-GENESIS_URL="$1"
-
-echo "Downloading genesis file $GENESIS_URL to $GENESIS_FILE..."
+# Each argument is a URL tried in order until a download succeeds.
 
 download_json() {
   echo "Downloading plain json..."
@@ -28,26 +25,40 @@ download_targz() {
 
 download_zip() {
   echo "Downloading and extracting zip..."
-  wget -c -O tmp_genesis.zip "$GENESIS_URL"
-  unzip tmp_genesis.zip
-  rm tmp_genesis.zip
-  mv genesis.json "$GENESIS_FILE"
+  wget -c -O tmp_genesis.zip "$GENESIS_URL" &&
+    unzip -o tmp_genesis.zip &&
+    rm tmp_genesis.zip &&
+    mv genesis.json "$GENESIS_FILE"
+}
+
+download() {
+  case "$GENESIS_URL" in
+  *.json.gz) download_jsongz ;;
+  *.json) download_json ;;
+  *.tar.gz) download_targz ;;
+  *.tar.gzip) download_targz ;;
+  *.tar) download_tar ;;
+  *.zip) download_zip ;;
+  *)
+    echo "Unable to handle file extension for $GENESIS_URL"
+    return 1
+    ;;
+  esac
+}
+
+download_first() {
+  for GENESIS_URL in "$@"; do
+    echo "Downloading genesis file $GENESIS_URL to $GENESIS_FILE..."
+    rm -f "$GENESIS_FILE"
+    if download; then
+      echo "Saved genesis file to $GENESIS_FILE."
+      echo "Download genesis file complete."
+      return 0
+    fi
+    echo "Failed to download genesis file from $GENESIS_URL."
+  done
+  echo "Unable to download genesis file from any URL."
+  return 1
 }
 
-rm -f "$GENESIS_FILE"
-
-case "$GENESIS_URL" in
-*.json.gz) download_jsongz ;;
-*.json) download_json ;;
-*.tar.gz) download_targz ;;
-*.tar.gzip) download_targz ;;
-*.tar) download_tar ;;
-*.zip) download_zip ;;
-*)
-  echo "Unable to handle file extension for $GENESIS_URL"
-  exit 1
-  ;;
-esac
-
-echo "Saved genesis file to $GENESIS_FILE."
-echo "Download genesis file complete."
+download_first "$@"
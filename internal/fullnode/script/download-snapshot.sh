@@ -1,35 +1,87 @@
 set -eu
 
 # $CHAIN_HOME already set via pod env vars.
+# $SNAPSHOT_DIR optionally overrides where the archive is extracted.
+# Each argument is a URL tried in order until a download succeeds.
 
-SNAPSHOT_URL="$1"
+SNAPSHOT_DIR="${SNAPSHOT_DIR:-$CHAIN_HOME}"
+mkdir -p "$SNAPSHOT_DIR"
 
-echo "Downloading snapshot archive $SNAPSHOT_URL to $CHAIN_HOME..."
+# Archives are extracted into a staging directory and only moved into place once complete, so a failed attempt
+# does not leave partial chain data behind for the next URL.
+STAGING_DIR="$SNAPSHOT_DIR/.snapshot-download"
+# Without pipefail, the exit status of a pipeline is the status of tar. Record wget failures separately.
+WGET_FAILED="$SNAPSHOT_DIR/.snapshot-download-failed"
+
+fetch() {
+  if ! wget -c -O - "$SNAPSHOT_URL"; then
+    touch "$WGET_FAILED"
+  fi
+}
 
 download_tar() {
   echo "Downloading and extracting tar..."
-  wget -c -O - "$SNAPSHOT_URL" | tar -x -C "$CHAIN_HOME"
+  fetch | tar -x -C "$STAGING_DIR"
 }
 
 download_targz() {
   echo "Downloading and extracting compressed tar..."
-  wget -c -O - "$SNAPSHOT_URL" | tar -xz -C "$CHAIN_HOME"
+  fetch | tar -xz -C "$STAGING_DIR"
 }
 
 download_lz4() {
   echo "Downloading and extracting lz4..."
-  wget -c -O - "$SNAPSHOT_URL" | lz4 -c -d | tar -x -C "$CHAIN_HOME"
+  fetch | lz4 -c -d | tar -x -C "$STAGING_DIR"
+}
+
+download() {
+  rm -rf "$STAGING_DIR" "$WGET_FAILED"
+  mkdir -p "$STAGING_DIR"
+  case "$SNAPSHOT_URL" in
+  *.tar.lz4) download_lz4 || return 1 ;;
+  *.tar.gzip) download_targz || return 1 ;;
+  *.tar.gz) download_targz || return 1 ;;
+  *.tar) download_tar || return 1 ;;
+  *)
+    echo "Unable to handle file extension for $SNAPSHOT_URL"
+    return 1
+    ;;
+  esac
+  if [ -e "$WGET_FAILED" ]; then
+    echo "wget failed to download $SNAPSHOT_URL"
+    return 1
+  fi
+}
+
+# move_into moves the entries of $1 into $2, merging directories which already exist (e.g. data/ holding
+# priv_validator_state.json).
+move_into() {
+  for entry in "$1"/* "$1"/.[!.]* "$1"/..?*; do
+    [ -e "$entry" ] || [ -L "$entry" ] || continue
+    target="$2/$(basename "$entry")"
+    if [ -d "$entry" ] && [ ! -L "$entry" ] && [ -d "$target" ] && [ ! -L "$target" ]; then
+      # Subshell, because sh has no local variables.
+      (move_into "$entry" "$target") || return 1
+    else
+      rm -rf "$target" && mv "$entry" "$target" || return 1
+    fi
+  done
+}
+
+download_first() {
+  for SNAPSHOT_URL in "$@"; do
+    echo "Downloading snapshot archive $SNAPSHOT_URL to $SNAPSHOT_DIR..."
+    if download; then
+      move_into "$STAGING_DIR" "$SNAPSHOT_DIR"
+      rm -rf "$STAGING_DIR"
+      echo "Download and extract snapshot complete."
+      return 0
+    fi
+    echo "Failed to download snapshot archive from $SNAPSHOT_URL."
+  done
+  rm -rf "$STAGING_DIR" "$WGET_FAILED"
+  echo "Unable to download snapshot archive from any URL."
+  return 1
 }
 
-case "$SNAPSHOT_URL" in
-*.tar.lz4) download_lz4 ;;
-*.tar.gzip) download_targz ;;
-*.tar.gz) download_targz ;;
-*.tar) download_tar ;;
-*)
-  echo "Unable to handle file extension for $SNAPSHOT_URL"
-  exit 1
-  ;;
-esac
-
-echo "Download and extract snapshot complete."
+download_first "$@"
@@ -0,0 +1,22 @@
+# $GENESIS_FILE and $CHAIN_ID already set via pod env vars.
+# $GENESIS_SHA256 is set if spec.chain.genesisSHA256 is set.
+
+echo "Verifying genesis file $GENESIS_FILE..."
+
+# Streaming avoids loading very large genesis files into memory.
+GENESIS_CHAIN_ID=$(jq -rn --stream 'first(inputs | select(length == 2 and .[0] == ["chain_id"]) | .[1])' "$GENESIS_FILE")
+if [ "$GENESIS_CHAIN_ID" != "$CHAIN_ID" ]; then
+  echo "Genesis chain_id \"$GENESIS_CHAIN_ID\" does not match chain ID \"$CHAIN_ID\"."
+  exit 1
+fi
+
+if [ -n "${GENESIS_SHA256:-}" ]; then
+  WANT_SHA256=$(echo "$GENESIS_SHA256" | tr 'A-F' 'a-f')
+  GOT_SHA256=$(sha256sum "$GENESIS_FILE" | cut -d ' ' -f 1)
+  if [ "$GOT_SHA256" != "$WANT_SHA256" ]; then
+    echo "Genesis SHA-256 $GOT_SHA256 does not match expected $WANT_SHA256."
+    exit 1
+  fi
+fi
+
+echo "Verified genesis chain_id $GENESIS_CHAIN_ID."
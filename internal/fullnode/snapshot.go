@@ -25,17 +25,48 @@ fi
 echo "$DATA_DIR initialized."
 `
 
+// snapshotSource returns the snapshot script and URL for the chain type.
+func snapshotSource(cfg cosmosv1.ChainSpec) (script, url *string) {
+	if cfg.ChainType == chainTypeNamada {
+		if cfg.Namada == nil {
+			return nil, nil
+		}
+		return cfg.Namada.SnapshotScript, cfg.Namada.SnapshotURL
+	}
+	if cfg.CosmosSDK == nil {
+		return nil, nil
+	}
+	return cfg.CosmosSDK.SnapshotScript, cfg.CosmosSDK.SnapshotURL
+}
+
 // DownloadSnapshotCommand returns a command and args for downloading and restoring from a snapshot.
 func DownloadSnapshotCommand(cfg cosmosv1.ChainSpec) (string, []string) {
 	args := []string{"-c"}
+	script, url := snapshotSource(cfg)
 	switch {
-	case cfg.CosmosSDK.SnapshotScript != nil:
-		args = append(args, fmt.Sprintf(snapshotScriptWrapper, *cfg.CosmosSDK.SnapshotScript))
-	case cfg.CosmosSDK.SnapshotURL != nil:
-		args = append(args, fmt.Sprintf(snapshotScriptWrapper, scriptDownloadSnapshot), "-s", *cfg.CosmosSDK.SnapshotURL)
+	case script != nil:
+		args = append(args, fmt.Sprintf(snapshotScriptWrapper, *script))
+	case url != nil:
+		body := scriptDownloadSnapshot
+		if cfg.ChainType == chainTypeNamada {
+			// Namada snapshots contain the chain directory's ledger and CometBFT databases.
+			body = `export SNAPSHOT_DIR="$CHAIN_HOME/$CHAIN_ID"` + "\n" + body
+		}
+		args = append(args, fmt.Sprintf(snapshotScriptWrapper, body), "-s")
+		args = append(args, snapshotURLs(cfg)...)
 	default:
 		panic(errors.New("attempted to restore from a snapshot but snapshots are not configured"))
 	}
 
 	return "sh", args
 }
+
+// snapshotURLs returns the URLs the snapshot download tries in order.
+// Returns nil if the snapshot is not downloaded from a URL.
+func snapshotURLs(cfg cosmosv1.ChainSpec) []string {
+	script, url := snapshotSource(cfg)
+	if script != nil || url == nil {
+		return nil
+	}
+	return append([]string{*url}, cfg.SnapshotFallbackURLs...)
+}
@@ -1,9 +1,19 @@
 package fullnode
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,6 +48,16 @@ fi`
 		require.Equal(t, testURL, args[3])
 	})
 
+	t.Run("snapshot url with fallbacks", func(t *testing.T) {
+		var cfg cosmosv1.ChainSpec
+		cfg.CosmosSDK = &cosmosv1.SDKAppConfig{SnapshotURL: ptr(testURL)}
+		cfg.SnapshotFallbackURLs = []string{"https://mirror.com/snapshot.tar.lz4"}
+
+		_, args := DownloadSnapshotCommand(cfg)
+
+		require.Equal(t, []string{"-s", testURL, "https://mirror.com/snapshot.tar.lz4"}, args[2:])
+	})
+
 	t.Run("snapshot script", func(t *testing.T) {
 		var cfg cosmosv1.ChainSpec
 		appConfig := cosmosv1.SDKAppConfig{}
@@ -56,6 +76,26 @@ fi`
 		require.Contains(t, got, "echo hello")
 	})
 
+	t.Run("namada", func(t *testing.T) {
+		var cfg cosmosv1.ChainSpec
+		cfg.ChainType = chainTypeNamada
+		cfg.CosmosSDK = &cosmosv1.SDKAppConfig{SnapshotURL: ptr("https://example.com/ignored.tar")}
+		cfg.Namada = &cosmosv1.NamadaConfig{SnapshotURL: ptr(testURL)}
+
+		_, args := DownloadSnapshotCommand(cfg)
+		require.Len(t, args, 4)
+
+		script := args[1]
+		require.Contains(t, script, wantIfStatement)
+		require.Contains(t, script, `export SNAPSHOT_DIR="$CHAIN_HOME/$CHAIN_ID"`)
+		require.Equal(t, testURL, args[3])
+
+		cfg.Namada.SnapshotScript = ptr("echo namada")
+		_, args = DownloadSnapshotCommand(cfg)
+		require.Len(t, args, 2)
+		require.Contains(t, args[1], "echo namada")
+	})
+
 	t.Run("zero state", func(t *testing.T) {
 		var cfg cosmosv1.ChainSpec
 		require.Panics(t, func() {
@@ -63,3 +103,104 @@ fi`
 		})
 	})
 }
+
+func tarArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, body := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadSnapshotScript(t *testing.T) {
+	t.Parallel()
+
+	for _, bin := range []string{"sh", "wget", "tar"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+
+	partial := tarArchive(t, map[string]string{"data/partial.db/000001.log": string(make([]byte, 4096))})
+	var complete bytes.Buffer
+	gz := gzip.NewWriter(&complete)
+	_, err := gz.Write(tarArchive(t, map[string]string{
+		"data/application.db/000001.log": "application",
+		"wasm/wasm/state.wasm":           "wasm",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/truncated.tar", func(w http.ResponseWriter, r *http.Request) {
+		// Only send part of the archive, so tar fails mid extraction.
+		_, _ = w.Write(partial[:1024])
+	})
+	mux.HandleFunc("/aborted.tar", func(w http.ResponseWriter, r *http.Request) {
+		// Send a complete archive but drop the connection before the promised length, so only wget fails.
+		archive := tarArchive(t, map[string]string{"data/aborted.db/000001.log": "aborted"})
+		w.Header().Set("Content-Length", strconv.Itoa(2*len(archive)))
+		_, _ = w.Write(archive)
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	})
+	mux.HandleFunc("/snapshot.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(complete.Bytes())
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// Don't retry dropped connections.
+	wgetrc := filepath.Join(t.TempDir(), "wgetrc")
+	require.NoError(t, os.WriteFile(wgetrc, []byte("tries = 1\n"), 0644))
+	env := append(os.Environ(), "WGETRC="+wgetrc)
+
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "data"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "data", "priv_validator_state.json"), []byte("{}"), 0644))
+
+	cmd := exec.Command("sh", "-c", scriptDownloadSnapshot, "-s",
+		srv.URL+"/missing.tar",
+		srv.URL+"/truncated.tar",
+		srv.URL+"/aborted.tar",
+		srv.URL+"/snapshot.tar.gz",
+	)
+	cmd.Env = append(env, "CHAIN_HOME="+home)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	for _, path := range []string{"/missing.tar", "/truncated.tar", "/aborted.tar"} {
+		require.Contains(t, string(out), "Failed to download snapshot archive from "+srv.URL+path)
+	}
+	require.Contains(t, string(out), "wget failed to download "+srv.URL+"/aborted.tar")
+
+	got, err := os.ReadFile(filepath.Join(home, "data", "application.db", "000001.log"))
+	require.NoError(t, err)
+	require.Equal(t, "application", string(got))
+	require.FileExists(t, filepath.Join(home, "wasm", "wasm", "state.wasm"))
+	require.FileExists(t, filepath.Join(home, "data", "priv_validator_state.json"))
+	require.NoDirExists(t, filepath.Join(home, "data", "partial.db"))
+	require.NoDirExists(t, filepath.Join(home, "data", "aborted.db"))
+
+	entries, err := os.ReadDir(home)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"data", "wasm"}, lo.Map(entries, func(e os.DirEntry, _ int) string { return e.Name() }))
+
+	t.Run("all urls fail", func(t *testing.T) {
+		home := t.TempDir()
+		cmd := exec.Command("sh", "-c", scriptDownloadSnapshot, "-s", srv.URL+"/truncated.tar", srv.URL+"/aborted.tar")
+		cmd.Env = append(env, "CHAIN_HOME="+home)
+		out, err := cmd.CombinedOutput()
+		require.Error(t, err)
+		require.Contains(t, string(out), "Unable to download snapshot archive from any URL.")
+
+		entries, err := os.ReadDir(home)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
@@ -2,8 +2,15 @@ package fullnode
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,6 +27,11 @@ type StatusCollector interface {
 	Collect(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection
 }
 
+// ReferenceHeighter returns the maximum height of the trusted nodes in spec.chain.reference, or 0 if none.
+type ReferenceHeighter interface {
+	ReferenceHeight(ctx context.Context, crd *cosmosv1.CosmosFullNode) uint64
+}
+
 // SyncInfoStatus returns the status of the full node's sync info.
 func SyncInfoStatus(
 	ctx context.Context,
@@ -51,6 +63,10 @@ func SyncInfoStatus(
 
 			stat.Height = ptr(comet.LatestBlockHeight())
 			stat.InSync = ptr(!comet.Result.SyncInfo.CatchingUp)
+			if h := comet.EarliestBlockHeight(); h > 0 {
+				stat.EarliestHeight = ptr(h)
+			}
+			stat.Node = nodeInfoStatus(comet)
 
 			if beforeStat != nil &&
 				beforeStat.Height != nil &&
@@ -73,3 +89,73 @@ func SyncInfoStatus(
 
 	return status
 }
+
+func nodeInfoStatus(comet cosmos.CometStatus) *cosmosv1.NodeInfoPodStatus {
+	info := comet.Result.NodeInfo
+	if info.ID == "" {
+		return nil
+	}
+	stat := &cosmosv1.NodeInfoPodStatus{
+		ID:               info.ID,
+		Network:          info.Network,
+		Moniker:          info.Moniker,
+		Version:          info.Version,
+		AppVersion:       info.ProtocolVersion.App,
+		ValidatorAddress: comet.Result.ValidatorInfo.Address,
+	}
+	if power, err := strconv.ParseInt(comet.Result.ValidatorInfo.VotingPower, 10, 64); err == nil {
+		stat.VotingPower = ptr(power)
+	}
+	return stat
+}
+
+// CheckNodeInfo compares the node info of each pod with the node ID of its node key Secret and with
+// spec.chain.chainID. Mismatches are added to the pod's status and reported as warning events.
+// Pods without node info or peer information are skipped.
+// Returns an unrecoverable error if any pod is on another network, because it syncs the wrong chain until a human
+// fixes the genesis or chain data.
+func CheckNodeInfo(reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, syncInfo map[string]*cosmosv1.SyncInfoPodStatus, peers Peers) kube.ReconcileError {
+	var wrongNetwork []error
+	podNames := lo.Keys(syncInfo)
+	sort.Strings(podNames)
+	for _, podName := range podNames {
+		stat := syncInfo[podName]
+		if stat.Node == nil {
+			continue
+		}
+		var mismatches []string
+		if peer, ok := peers[client.ObjectKey{Namespace: crd.Namespace, Name: podName}]; ok && peer.NodeID != "" && peer.NodeID != stat.Node.ID {
+			mismatches = append(mismatches, fmt.Sprintf("node ID %s does not match node key secret %s", stat.Node.ID, peer.NodeID))
+		}
+		if chainID := crd.Spec.ChainSpec.ChainID; chainID != "" && stat.Node.Network != chainID {
+			msg := fmt.Sprintf("network %q does not match chain ID %q", stat.Node.Network, chainID)
+			mismatches = append(mismatches, msg)
+			wrongNetwork = append(wrongNetwork, fmt.Errorf("%s: %s", podName, msg))
+		}
+		stat.Node.Mismatches = mismatches
+		for _, msg := range mismatches {
+			err := fmt.Errorf("%s: %s", podName, msg)
+			reporter.Error(err, "Node info mismatch")
+			reporter.RecordError("NodeInfoMismatch", err)
+		}
+	}
+	if len(wrongNetwork) > 0 {
+		return kube.UnrecoverableError(errors.Join(wrongNetwork...))
+	}
+	return nil
+}
+
+// ReferenceHeight returns the maximum of the trusted height and the heights of in-sync pods.
+// Returns false if the trusted height is 0 and no pod is in sync.
+func ReferenceHeight(syncInfo map[string]*cosmosv1.SyncInfoPodStatus, trusted uint64) (uint64, bool) {
+	max := trusted
+	for _, stat := range syncInfo {
+		if stat.Error != nil || stat.Height == nil || stat.InSync == nil || !*stat.InSync {
+			continue
+		}
+		if *stat.Height > max {
+			max = *stat.Height
+		}
+	}
+	return max, max > 0
+}
@@ -8,6 +8,7 @@ import (
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/bharvest-devops/cosmos-operator/internal/test"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,6 +63,14 @@ func TestSyncInfoStatus(t *testing.T) {
 
 		var inSync cosmos.CometStatus
 		inSync.Result.SyncInfo.LatestBlockHeight = "10000"
+		inSync.Result.SyncInfo.EarliestBlockHeight = "9000"
+		inSync.Result.NodeInfo.ID = "abc123"
+		inSync.Result.NodeInfo.Network = "agoric-3"
+		inSync.Result.NodeInfo.Moniker = "agoric-1"
+		inSync.Result.NodeInfo.Version = "0.38.5"
+		inSync.Result.NodeInfo.ProtocolVersion.App = "0"
+		inSync.Result.ValidatorInfo.Address = "VALADDR"
+		inSync.Result.ValidatorInfo.VotingPower = "0"
 
 		return cosmos.StatusCollection{
 			// Purposefully out of order to test sorting.
@@ -88,6 +97,16 @@ func TestSyncInfoStatus(t *testing.T) {
 			Height:             ptr(uint64(10000)),
 			InSync:             ptr(true),
 			HeightRetainTime:   ptr(metav1.Duration{Duration: wantTS.Sub(wantTS.Time)}),
+			EarliestHeight:     ptr(uint64(9000)),
+			Node: &cosmosv1.NodeInfoPodStatus{
+				ID:               "abc123",
+				Network:          "agoric-3",
+				Moniker:          "agoric-1",
+				Version:          "0.38.5",
+				AppVersion:       "0",
+				ValidatorAddress: "VALADDR",
+				VotingPower:      ptr(int64(0)),
+			},
 		},
 		"pod-2": {
 			Timestamp:          wantTS,
@@ -100,3 +119,84 @@ func TestSyncInfoStatus(t *testing.T) {
 	status := SyncInfoStatus(context.Background(), &crd, collector)
 	require.Equal(t, want, status)
 }
+
+type mockReporter struct {
+	test.NopReporter
+	Errors []string
+}
+
+func (m *mockReporter) RecordError(reason string, err error) {
+	m.Errors = append(m.Errors, reason+": "+err.Error())
+}
+
+func TestCheckNodeInfo(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	crd.Name = "agoric"
+	crd.Namespace = "default"
+	crd.Spec.ChainSpec.ChainID = "agoric-3"
+
+	peers := Peers{
+		client.ObjectKey{Namespace: "default", Name: "agoric-0"}: {NodeID: "id0"},
+		client.ObjectKey{Namespace: "default", Name: "agoric-1"}: {NodeID: "id1"},
+		client.ObjectKey{Namespace: "default", Name: "agoric-2"}: {NodeID: "id2"},
+	}
+	syncInfo := map[string]*cosmosv1.SyncInfoPodStatus{
+		"agoric-0": {Node: &cosmosv1.NodeInfoPodStatus{ID: "id0", Network: "agoric-3"}},
+		"agoric-1": {Node: &cosmosv1.NodeInfoPodStatus{ID: "other", Network: "agoriclocal"}},
+		"agoric-2": {Error: ptr("no status")},
+		// No peer information, e.g. if the node key secret is missing.
+		"agoric-3": {Node: &cosmosv1.NodeInfoPodStatus{ID: "id3", Network: "agoric-3"}},
+	}
+
+	var reporter mockReporter
+	err := CheckNodeInfo(&reporter, &crd, syncInfo, peers)
+	require.Error(t, err)
+	require.False(t, err.IsTransient())
+	require.EqualError(t, err, `agoric-1: network "agoriclocal" does not match chain ID "agoric-3"`)
+
+	require.Empty(t, syncInfo["agoric-0"].Node.Mismatches)
+	require.Equal(t, []string{
+		"node ID other does not match node key secret id1",
+		`network "agoriclocal" does not match chain ID "agoric-3"`,
+	}, syncInfo["agoric-1"].Node.Mismatches)
+	require.Nil(t, syncInfo["agoric-2"].Node)
+	require.Empty(t, syncInfo["agoric-3"].Node.Mismatches)
+
+	require.Equal(t, []string{
+		"NodeInfoMismatch: agoric-1: node ID other does not match node key secret id1",
+		`NodeInfoMismatch: agoric-1: network "agoriclocal" does not match chain ID "agoric-3"`,
+	}, reporter.Errors)
+
+	// Mismatches are cleared once resolved.
+	syncInfo["agoric-1"].Node.ID = "id1"
+	syncInfo["agoric-1"].Node.Network = "agoric-3"
+	require.NoError(t, CheckNodeInfo(&reporter, &crd, syncInfo, peers))
+	require.Empty(t, syncInfo["agoric-1"].Node.Mismatches)
+}
+
+func TestReferenceHeight(t *testing.T) {
+	t.Parallel()
+
+	_, ok := ReferenceHeight(nil, 0)
+	require.False(t, ok)
+
+	syncInfo := map[string]*cosmosv1.SyncInfoPodStatus{
+		"pod-0": {Height: ptr(uint64(100)), InSync: ptr(true)},
+		"pod-1": {Height: ptr(uint64(120)), InSync: ptr(false)},
+		"pod-2": {Height: ptr(uint64(110)), InSync: ptr(true)},
+		"pod-3": {Error: ptr("boom")},
+	}
+	got, ok := ReferenceHeight(syncInfo, 0)
+	require.True(t, ok)
+	require.EqualValues(t, 110, got)
+
+	got, ok = ReferenceHeight(syncInfo, 130)
+	require.True(t, ok)
+	require.EqualValues(t, 130, got)
+
+	got, ok = ReferenceHeight(nil, 130)
+	require.True(t, ok)
+	require.EqualValues(t, 130, got)
+}
@@ -0,0 +1,55 @@
+package fullnode
+
+import (
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+)
+
+// VersionForHeight returns the spec.chain.versions entry in effect at height, or false if none is.
+func VersionForHeight(versions []cosmosv1.ChainVersion, height uint64) (cosmosv1.ChainVersion, bool) {
+	var (
+		found cosmosv1.ChainVersion
+		ok    bool
+	)
+	for _, version := range versions {
+		if height < version.UpgradeHeight {
+			break
+		}
+		found, ok = version, true
+	}
+	return found, ok
+}
+
+// NextUpgrade returns the upgrade progress of a pod running image which will process height next.
+// The pod is halted if image is not the image in effect at height. The pod is pending an upgrade if the next version
+// with a different image takes effect within pendingBlocks of height.
+// Returns nil if no upgrade is due. The caller is responsible for setting the transition time.
+func NextUpgrade(versions []cosmosv1.ChainVersion, height uint64, image string, pendingBlocks uint64) *cosmosv1.UpgradeStatus {
+	current, ok := VersionForHeight(versions, height)
+	if ok && current.Image != image {
+		return &cosmosv1.UpgradeStatus{
+			Phase:  cosmosv1.UpgradePhaseHalted,
+			Height: current.UpgradeHeight,
+			Image:  current.Image,
+		}
+	}
+	for _, version := range versions {
+		if version.UpgradeHeight <= height || version.Image == image {
+			continue
+		}
+		if version.UpgradeHeight-height > pendingBlocks {
+			return nil
+		}
+		return &cosmosv1.UpgradeStatus{
+			Phase:  cosmosv1.UpgradePhasePending,
+			Height: version.UpgradeHeight,
+			Image:  version.Image,
+		}
+	}
+	return nil
+}
+
+// UpgradeHalted returns true if the version check sidecar reported the pod halted at an upgrade to image.
+func UpgradeHalted(crd *cosmosv1.CosmosFullNode, podName, image string) bool {
+	upgrade := crd.Status.Upgrades[podName]
+	return upgrade != nil && upgrade.Phase == cosmosv1.UpgradePhaseHalted && upgrade.Image == image
+}
@@ -0,0 +1,84 @@
+package fullnode
+
+import (
+	"testing"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionForHeight(t *testing.T) {
+	t.Parallel()
+
+	versions := []cosmosv1.ChainVersion{
+		{Image: "v1"},
+		{UpgradeHeight: 100, Image: "v2"},
+		{UpgradeHeight: 200, Image: "v3"},
+	}
+
+	for _, tt := range []struct {
+		Height uint64
+		Want   string
+	}{
+		{0, "v1"},
+		{99, "v1"},
+		{100, "v2"},
+		{199, "v2"},
+		{1000, "v3"},
+	} {
+		got, ok := VersionForHeight(versions, tt.Height)
+		require.True(t, ok)
+		require.Equal(t, tt.Want, got.Image, tt)
+	}
+
+	_, ok := VersionForHeight([]cosmosv1.ChainVersion{{UpgradeHeight: 100, Image: "v2"}}, 99)
+	require.False(t, ok)
+
+	_, ok = VersionForHeight(nil, 99)
+	require.False(t, ok)
+}
+
+func TestNextUpgrade(t *testing.T) {
+	t.Parallel()
+
+	versions := []cosmosv1.ChainVersion{
+		{Image: "v1"},
+		{UpgradeHeight: 100, Image: "v1", SetHaltHeight: true},
+		{UpgradeHeight: 200, Image: "v2"},
+	}
+
+	for _, tt := range []struct {
+		Name   string
+		Height uint64
+		Image  string
+		Want   *cosmosv1.UpgradeStatus
+	}{
+		{"far from upgrade", 50, "v1", nil},
+		{"same image", 95, "v1", nil},
+		{"approaching", 150, "v1", &cosmosv1.UpgradeStatus{Phase: cosmosv1.UpgradePhasePending, Height: 200, Image: "v2"}},
+		{"at upgrade height", 200, "v1", &cosmosv1.UpgradeStatus{Phase: cosmosv1.UpgradePhaseHalted, Height: 200, Image: "v2"}},
+		{"upgraded", 200, "v2", nil},
+		{"wrong image", 50, "v2", &cosmosv1.UpgradeStatus{Phase: cosmosv1.UpgradePhaseHalted, Height: 0, Image: "v1"}},
+	} {
+		got := NextUpgrade(versions, tt.Height, tt.Image, 50)
+		require.Equal(t, tt.Want, got, tt.Name)
+	}
+
+	require.Nil(t, NextUpgrade(nil, 100, "v1", 50))
+}
+
+func TestUpgradeHalted(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	require.False(t, UpgradeHalted(&crd, "osmosis-0", "v2"))
+
+	crd.Status.Upgrades = map[string]*cosmosv1.UpgradeStatus{
+		"osmosis-0": {Phase: cosmosv1.UpgradePhaseHalted, Image: "v2"},
+		"osmosis-1": {Phase: cosmosv1.UpgradePhasePending, Image: "v2"},
+	}
+	require.True(t, UpgradeHalted(&crd, "osmosis-0", "v2"))
+	require.False(t, UpgradeHalted(&crd, "osmosis-0", "v3"))
+	require.False(t, UpgradeHalted(&crd, "osmosis-1", "v2"))
+	require.False(t, UpgradeHalted(&crd, "osmosis-2", "v2"))
+}
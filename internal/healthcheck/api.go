@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// LatestBlock is the latest block reported by the gRPC or REST API.
+type LatestBlock struct {
+	Height uint64
+	Time   time.Time
+}
+
+// LatestBlocker queries the Cosmos SDK tendermint service for the latest block.
+type LatestBlocker interface {
+	LatestBlock(ctx context.Context) (LatestBlock, error)
+}
+
+type apiResponse struct {
+	Address   string    `json:"address"`
+	Height    uint64    `json:"height,omitempty"`
+	BlockTime time.Time `json:"block_time,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// API checks the gRPC or REST API serves the latest block. Either API can be broken while CometBFT RPC is fine,
+// e.g. the API is disabled in app.toml or the gRPC port conflicts with another process.
+type API struct {
+	address    string
+	client     LatestBlocker
+	lastStatus int32
+	logger     logr.Logger
+	timeout    time.Duration
+}
+
+func NewAPI(logger logr.Logger, client LatestBlocker, address string, timeout time.Duration) *API {
+	return &API{
+		address: address,
+		client:  client,
+		logger:  logger,
+		timeout: timeout,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := apiResponse{Address: h.address}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	block, err := h.client.LatestBlock(ctx)
+	if err != nil {
+		resp.Error = err.Error()
+		h.writeResponse(http.StatusServiceUnavailable, w, resp)
+		return
+	}
+	resp.Height = block.Height
+	resp.BlockTime = block.Time
+	h.writeResponse(http.StatusOK, w, resp)
+}
+
+func (h *API) writeResponse(code int, w http.ResponseWriter, resp apiResponse) {
+	w.WriteHeader(code)
+	w.Header().Set("Content-Type", "application/json")
+	mustJSONEncode(resp, w)
+	// Only log when status code changes, so we don't spam logs.
+	if atomic.SwapInt32(&h.lastStatus, int32(code)) != int32(code) {
+		h.logger.Info("API health state change", "statusCode", code, "response", resp)
+	}
+}
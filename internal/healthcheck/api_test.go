@@ -0,0 +1,61 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockLatestBlocker func(ctx context.Context) (LatestBlock, error)
+
+func (fn mockLatestBlocker) LatestBlock(ctx context.Context) (LatestBlock, error) {
+	return fn(ctx)
+}
+
+func TestAPI_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	stubReq := httptest.NewRequest("GET", "/grpc", nil)
+	const testAddr = "localhost:9090"
+
+	t.Run("happy path", func(t *testing.T) {
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		client := mockLatestBlocker(func(ctx context.Context) (LatestBlock, error) {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			return LatestBlock{Height: 123, Time: ts}, nil
+		})
+
+		h := NewAPI(nopLogger, client, testAddr, 10*time.Second)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var got apiResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, apiResponse{Address: testAddr, Height: 123, BlockTime: ts}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := mockLatestBlocker(func(ctx context.Context) (LatestBlock, error) {
+			return LatestBlock{}, errors.New("boom")
+		})
+
+		h := NewAPI(nopLogger, client, testAddr, 10*time.Second)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var got apiResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, apiResponse{Address: testAddr, Error: "boom"}, got)
+	})
+}
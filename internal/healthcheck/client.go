@@ -58,3 +58,30 @@ func (c Client) DiskUsage(ctx context.Context, host, homeDir string) (DiskUsageR
 	}
 	return diskResp, nil
 }
+
+// Namada returns the Namada-specific checks or an error if unable to obtain.
+// Do not include the port in the host.
+func (c Client) Namada(ctx context.Context, host string) (NamadaResponse, error) {
+	var namadaResp NamadaResponse
+	u, err := url.Parse(host)
+	if err != nil {
+		return namadaResp, fmt.Errorf("url parse: %w", err)
+	}
+	u.Host = net.JoinHostPort(u.Host, strconv.Itoa(Port))
+	u.Path = "/namada"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return namadaResp, fmt.Errorf("new request: %w", err)
+	}
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return namadaResp, fmt.Errorf("http do: %w", err)
+	}
+	defer resp.Body.Close()
+	// A failed check is reported in the body with a non-200 status code.
+	if err = json.NewDecoder(resp.Body).Decode(&namadaResp); err != nil {
+		return namadaResp, fmt.Errorf("malformed json: %w", err)
+	}
+	return namadaResp, nil
+}
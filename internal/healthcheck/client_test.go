@@ -113,3 +113,47 @@ func TestClient_DiskUsage(t *testing.T) {
 		require.EqualError(t, err, "invalid response: 0 free bytes")
 	})
 }
+
+func TestClient_Namada(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		httpClient = &http.Client{}
+	)
+	const host = "http://10.1.1.1"
+
+	t.Run("happy path", func(t *testing.T) {
+		client := NewClient(httpClient)
+		want := NamadaResponse{ShellHeight: 100, OracleRPC: "http://127.0.0.1:8545", OracleError: "connection refused"}
+
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "http://10.1.1.1:1251/namada", req.URL.String())
+			require.Equal(t, "GET", req.Method)
+
+			b, err := json.Marshal(want)
+			if err != nil {
+				panic(err)
+			}
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(bytes.NewReader(b))}, nil
+		}
+
+		got, err := client.Namada(ctx, host)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := NewClient(httpClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		_, err := client.Namada(ctx, host)
+		require.EqualError(t, err, "http do: boom")
+
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404 page not found"))}, nil
+		}
+		_, err = client.Namada(ctx, host)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "malformed json")
+	})
+}
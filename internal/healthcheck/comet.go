@@ -5,7 +5,9 @@ package healthcheck
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -18,27 +20,73 @@ type Statuser interface {
 	Status(ctx context.Context, rpcHost string) (cosmos.CometStatus, error)
 }
 
+// CometClient can query the Comet status and net_info endpoints.
+type CometClient interface {
+	Statuser
+	NetInfo(ctx context.Context, rpcHost string) (cosmos.CometNetInfo, error)
+}
+
+// ReferenceHeighter returns the height the node's height is compared against.
+type ReferenceHeighter interface {
+	ReferenceHeight(ctx context.Context) (uint64, error)
+}
+
+// Criteria are the conditions the node must meet to be healthy in addition to not catching up.
+// Zero values are not checked.
+type Criteria struct {
+	MaxBlockAge  time.Duration
+	MinPeers     int
+	MaxHeightLag uint64
+	// Required if MaxHeightLag is set.
+	Reference ReferenceHeighter
+	// APIs which must serve the latest block, keyed by name, e.g. "grpc".
+	APIs map[string]LatestBlocker
+	// Set for Namada chains. Only the ledger shell check affects readiness.
+	Namada NamadaChecker
+}
+
+// NamadaChecker runs the Namada-specific checks.
+type NamadaChecker interface {
+	Check(ctx context.Context) NamadaResponse
+}
+
 type healthResponse struct {
 	Address string `json:"address"`
 	InSync  bool   `json:"in_sync"`
 	Error   string `json:"error,omitempty"`
+
+	Height          uint64 `json:"height,omitempty"`
+	BlockAge        string `json:"block_age,omitempty"`
+	Peers           *int   `json:"peers,omitempty"`
+	ReferenceHeight uint64 `json:"reference_height,omitempty"`
+	// The lag check is skipped if the reference height is unavailable.
+	ReferenceError string `json:"reference_error,omitempty"`
+
+	Namada *NamadaResponse `json:"namada,omitempty"`
+	// Explains why the node is not healthy.
+	FailedChecks []string `json:"failed_checks,omitempty"`
 }
 
 // Comet checks the CometBFT status endpoint to determine if the node is in-sync or not.
+// If configured, it also checks the gRPC and REST APIs so the node is not ready while they are broken.
 type Comet struct {
-	client     Statuser
+	client     CometClient
+	criteria   Criteria
 	lastStatus int32
 	logger     logr.Logger
+	now        func() time.Time
 	rpcHost    string
 	timeout    time.Duration
 }
 
-func NewComet(logger logr.Logger, client Statuser, rpcHost string, timeout time.Duration) *Comet {
+func NewComet(logger logr.Logger, client CometClient, rpcHost string, timeout time.Duration, criteria Criteria) *Comet {
 	return &Comet{
-		client:  client,
-		logger:  logger,
-		rpcHost: rpcHost,
-		timeout: timeout,
+		client:   client,
+		criteria: criteria,
+		logger:   logger,
+		now:      time.Now,
+		rpcHost:  rpcHost,
+		timeout:  timeout,
 	}
 }
 
@@ -58,7 +106,68 @@ func (h *Comet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp.InSync = !status.Result.SyncInfo.CatchingUp
+	resp.Height = status.LatestBlockHeight()
 	if !resp.InSync {
+		resp.FailedChecks = append(resp.FailedChecks, "catching up")
+	}
+
+	if max := h.criteria.MaxBlockAge; max > 0 {
+		age := h.now().Sub(status.Result.SyncInfo.LatestBlockTime)
+		resp.BlockAge = age.Round(time.Second).String()
+		if age > max {
+			resp.FailedChecks = append(resp.FailedChecks, fmt.Sprintf("block age %s exceeds %s", resp.BlockAge, max))
+		}
+	}
+
+	if min := h.criteria.MinPeers; min > 0 {
+		info, err := h.client.NetInfo(ctx, h.rpcHost)
+		if err != nil {
+			resp.Error = err.Error()
+			h.writeResponse(http.StatusServiceUnavailable, w, resp)
+			return
+		}
+		peers := info.PeerCount()
+		resp.Peers = &peers
+		if peers < min {
+			resp.FailedChecks = append(resp.FailedChecks, fmt.Sprintf("%d peers is fewer than %d", peers, min))
+		}
+	}
+
+	if max := h.criteria.MaxHeightLag; max > 0 {
+		ref, err := h.criteria.Reference.ReferenceHeight(ctx)
+		if err != nil {
+			resp.ReferenceError = err.Error()
+		} else {
+			resp.ReferenceHeight = ref
+			if ref > resp.Height && ref-resp.Height > max {
+				resp.FailedChecks = append(resp.FailedChecks,
+					fmt.Sprintf("height %d lags reference height %d by more than %d blocks", resp.Height, ref, max))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(h.criteria.APIs))
+	for name := range h.criteria.APIs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := h.criteria.APIs[name].LatestBlock(ctx); err != nil {
+			resp.FailedChecks = append(resp.FailedChecks, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+
+	if h.criteria.Namada != nil {
+		namada := h.criteria.Namada.Check(ctx)
+		resp.Namada = &namada
+		// The Ethereum bridge oracle depends on an external Ethereum RPC endpoint. It is reported, but does not
+		// affect readiness, so an Ethereum outage does not make every replica NotReady at once.
+		if namada.ShellError != "" {
+			resp.FailedChecks = append(resp.FailedChecks, "ledger shell: "+namada.ShellError)
+		}
+	}
+
+	if len(resp.FailedChecks) > 0 {
 		h.writeResponse(http.StatusUnprocessableEntity, w, resp)
 		return
 	}
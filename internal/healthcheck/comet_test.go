@@ -20,6 +20,36 @@ func (fn mockClient) Status(ctx context.Context, rpcHost string) (cosmos.CometSt
 	return fn(ctx, rpcHost)
 }
 
+func (fn mockClient) NetInfo(ctx context.Context, rpcHost string) (cosmos.CometNetInfo, error) {
+	panic("should not be called")
+}
+
+type mockCometClient struct {
+	StatusResp  cosmos.CometStatus
+	NetInfoResp cosmos.CometNetInfo
+	NetInfoErr  error
+}
+
+func (m mockCometClient) Status(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+	return m.StatusResp, nil
+}
+
+func (m mockCometClient) NetInfo(ctx context.Context, rpcHost string) (cosmos.CometNetInfo, error) {
+	return m.NetInfoResp, m.NetInfoErr
+}
+
+type mockNamadaChecker func(ctx context.Context) NamadaResponse
+
+func (fn mockNamadaChecker) Check(ctx context.Context) NamadaResponse {
+	return fn(ctx)
+}
+
+type mockReference func(ctx context.Context) (uint64, error)
+
+func (fn mockReference) ReferenceHeight(ctx context.Context) (uint64, error) {
+	return fn(ctx)
+}
+
 var nopLogger = logr.Discard()
 
 func TestComet_ServeHTTP(t *testing.T) {
@@ -37,7 +67,7 @@ func TestComet_ServeHTTP(t *testing.T) {
 			return cosmos.CometStatus{}, nil
 		})
 
-		h := NewComet(nopLogger, client, testRPC, 10*time.Second)
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{})
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, stubReq)
 
@@ -60,7 +90,7 @@ func TestComet_ServeHTTP(t *testing.T) {
 			return stub, nil
 		})
 
-		h := NewComet(nopLogger, client, testRPC, 10*time.Second)
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{})
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, stubReq)
 
@@ -70,18 +100,176 @@ func TestComet_ServeHTTP(t *testing.T) {
 		require.NoError(t, err)
 
 		want := healthResponse{
-			Address: testRPC,
-			InSync:  false,
+			Address:      testRPC,
+			InSync:       false,
+			FailedChecks: []string{"catching up"},
 		}
 		require.Equal(t, want, got)
 	})
 
+	t.Run("criteria", func(t *testing.T) {
+		now := time.Now()
+		var status cosmos.CometStatus
+		status.Result.SyncInfo.LatestBlockHeight = "100"
+		status.Result.SyncInfo.LatestBlockTime = now.Add(-time.Minute)
+		var netInfo cosmos.CometNetInfo
+		netInfo.NPeers = "2"
+
+		criteria := Criteria{
+			MaxBlockAge:  time.Minute,
+			MinPeers:     2,
+			MaxHeightLag: 10,
+			Reference: mockReference(func(ctx context.Context) (uint64, error) {
+				require.NotNil(t, ctx)
+				return 110, nil
+			}),
+		}
+
+		for _, tt := range []struct {
+			Name     string
+			Criteria func(c Criteria) Criteria
+			WantCode int
+			Want     healthResponse
+		}{
+			{
+				"healthy",
+				func(c Criteria) Criteria { return c },
+				http.StatusOK,
+				healthResponse{Address: testRPC, InSync: true, Height: 100, BlockAge: "1m0s", Peers: ptr(2), ReferenceHeight: 110},
+			},
+			{
+				"old block",
+				func(c Criteria) Criteria { c.MaxBlockAge = 30 * time.Second; return c },
+				http.StatusUnprocessableEntity,
+				healthResponse{Address: testRPC, InSync: true, Height: 100, BlockAge: "1m0s", Peers: ptr(2), ReferenceHeight: 110,
+					FailedChecks: []string{"block age 1m0s exceeds 30s"}},
+			},
+			{
+				"too few peers",
+				func(c Criteria) Criteria { c.MinPeers = 3; return c },
+				http.StatusUnprocessableEntity,
+				healthResponse{Address: testRPC, InSync: true, Height: 100, BlockAge: "1m0s", Peers: ptr(2), ReferenceHeight: 110,
+					FailedChecks: []string{"2 peers is fewer than 3"}},
+			},
+			{
+				"lags reference",
+				func(c Criteria) Criteria { c.MaxHeightLag = 9; return c },
+				http.StatusUnprocessableEntity,
+				healthResponse{Address: testRPC, InSync: true, Height: 100, BlockAge: "1m0s", Peers: ptr(2), ReferenceHeight: 110,
+					FailedChecks: []string{"height 100 lags reference height 110 by more than 9 blocks"}},
+			},
+			{
+				"reference error",
+				func(c Criteria) Criteria {
+					c.Reference = mockReference(func(ctx context.Context) (uint64, error) { return 0, errors.New("no reference") })
+					return c
+				},
+				http.StatusOK,
+				healthResponse{Address: testRPC, InSync: true, Height: 100, BlockAge: "1m0s", Peers: ptr(2), ReferenceError: "no reference"},
+			},
+		} {
+			client := mockCometClient{StatusResp: status, NetInfoResp: netInfo}
+			h := NewComet(nopLogger, client, testRPC, 10*time.Second, tt.Criteria(criteria))
+			h.now = func() time.Time { return now }
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, stubReq)
+
+			require.Equal(t, tt.WantCode, w.Code, tt.Name)
+			var got healthResponse
+			err := json.NewDecoder(w.Body).Decode(&got)
+			require.NoError(t, err)
+			require.Equal(t, tt.Want, got, tt.Name)
+		}
+	})
+
+	t.Run("apis", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, nil
+		})
+		healthy := mockLatestBlocker(func(ctx context.Context) (LatestBlock, error) {
+			require.NotNil(t, ctx)
+			return LatestBlock{Height: 1}, nil
+		})
+		broken := mockLatestBlocker(func(ctx context.Context) (LatestBlock, error) {
+			return LatestBlock{}, errors.New("connection refused")
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{APIs: map[string]LatestBlocker{"grpc": healthy, "rest": healthy}})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		h = NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{APIs: map[string]LatestBlocker{"rest": broken, "grpc": broken}})
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, []string{"grpc: connection refused", "rest: connection refused"}, got.FailedChecks)
+	})
+
+	t.Run("namada", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, nil
+		})
+		namada := mockNamadaChecker(func(ctx context.Context) NamadaResponse {
+			require.NotNil(t, ctx)
+			return NamadaResponse{ShellError: "boom", OracleRPC: "http://127.0.0.1:8545", OracleError: "connection refused"}
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{Namada: namada})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, &NamadaResponse{ShellError: "boom", OracleRPC: "http://127.0.0.1:8545", OracleError: "connection refused"}, got.Namada)
+		require.Equal(t, []string{"ledger shell: boom"}, got.FailedChecks)
+	})
+
+	t.Run("namada oracle unreachable", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, nil
+		})
+		namada := mockNamadaChecker(func(ctx context.Context) NamadaResponse {
+			return NamadaResponse{ShellHeight: 100, OracleRPC: "http://127.0.0.1:8545", OracleError: "connection refused"}
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{Namada: namada})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, "connection refused", got.Namada.OracleError)
+		require.Empty(t, got.FailedChecks)
+	})
+
+	t.Run("net info error", func(t *testing.T) {
+		client := mockCometClient{NetInfoErr: errors.New("boom")}
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{MinPeers: 1})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, "boom", got.Error)
+	})
+
 	t.Run("status error", func(t *testing.T) {
 		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
 			return cosmos.CometStatus{}, errors.New("boom")
 		})
 
-		h := NewComet(nopLogger, client, testRPC, 10*time.Second)
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second, Criteria{})
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, stubReq)
 
@@ -104,7 +292,7 @@ func TestComet_ServeHTTP(t *testing.T) {
 			return cosmos.CometStatus{}, nil
 		})
 
-		h := NewComet(nopLogger, client, testRPC, time.Nanosecond)
+		h := NewComet(nopLogger, client, testRPC, time.Nanosecond, Criteria{})
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, stubReq)
 
@@ -116,3 +304,7 @@ func TestComet_ServeHTTP(t *testing.T) {
 		}
 	})
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,130 @@
+package healthcheck
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DirUsage is the size of a directory.
+type DirUsage struct {
+	// Path relative to the directory queried.
+	Path   string `json:"path"`
+	Bytes  uint64 `json:"bytes"`
+	Inodes uint64 `json:"inodes"`
+}
+
+// DirSizer computes the sizes of the directories below a single root in the background and caches them.
+// Walking a chain's data directory may take minutes, far longer than a request should.
+type DirSizer struct {
+	root     string
+	depth    int
+	interval time.Duration
+	now      func() time.Time
+	walk     func(root string, depth int) ([]DirUsage, error)
+
+	mu        sync.Mutex
+	dirs      []DirUsage
+	updatedAt time.Time
+	checkedAt time.Time
+	running   bool
+}
+
+// NewDirSizer returns a DirSizer which reports directories up to depth levels below root, typically the chain's
+// home directory, and recomputes their sizes at most once per interval.
+func NewDirSizer(root string, depth int, interval time.Duration) *DirSizer {
+	return &DirSizer{
+		root:     filepath.Clean(root),
+		depth:    depth,
+		interval: interval,
+		now:      time.Now,
+		walk:     walkDirs,
+	}
+}
+
+// Sizes returns the most recently computed directory sizes below dir and when they were computed.
+// Only the root's sizes are computed, so callers cannot walk arbitrary directories. For any other dir, or if sizes
+// have not been computed yet, the returned time is zero.
+// If the sizes are missing or stale, they are recomputed in the background.
+func (s *DirSizer) Sizes(dir string) ([]DirUsage, time.Time) {
+	if filepath.Clean(dir) != s.root {
+		return nil, time.Time{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running && (s.checkedAt.IsZero() || s.now().Sub(s.checkedAt) >= s.interval) {
+		s.running = true
+		go s.refresh()
+	}
+	return s.dirs, s.updatedAt
+}
+
+func (s *DirSizer) refresh() {
+	dirs, err := s.walk(s.root, s.depth)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	// Back off on errors too, so a missing directory is not walked on every request.
+	s.checkedAt = s.now()
+	if err != nil {
+		return
+	}
+	s.dirs = dirs
+	s.updatedAt = s.checkedAt
+}
+
+// walkDirs sums the bytes and inodes of each directory up to depth levels below root, sorted by path.
+func walkDirs(root string, depth int) ([]DirUsage, error) {
+	root = filepath.Clean(root)
+	found := make(map[string]*DirUsage)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Files come and go while the node runs, e.g. during database compaction.
+			return nil
+		}
+		if path == root {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(rel, string(filepath.Separator))
+		for i := 1; i <= len(parts) && i <= depth; i++ {
+			if i == len(parts) && !d.IsDir() {
+				break
+			}
+			key := filepath.Join(parts[:i]...)
+			usage := found[key]
+			if usage == nil {
+				usage = &DirUsage{Path: key}
+				found[key] = usage
+			}
+			usage.Bytes += uint64(info.Size())
+			usage.Inodes++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]DirUsage, 0, len(found))
+	for _, usage := range found {
+		dirs = append(dirs, *usage)
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Path < dirs[j].Path })
+	return dirs, nil
+}
@@ -0,0 +1,190 @@
+package healthcheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirSizer_Sizes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("computes in background", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			calls int
+			now   = time.Now()
+			want  = []DirUsage{{Path: "data", Bytes: 10, Inodes: 2}}
+		)
+		sizer := NewDirSizer("/home/test/", 2, time.Minute)
+		sizer.now = func() time.Time { return now }
+		sizer.walk = func(root string, depth int) ([]DirUsage, error) {
+			require.Equal(t, "/home/test", root)
+			require.Equal(t, 2, depth)
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return want, nil
+		}
+
+		dirs, updatedAt := sizer.Sizes("/home/test")
+		require.Empty(t, dirs)
+		require.Zero(t, updatedAt)
+
+		require.Eventually(t, func() bool {
+			_, updatedAt := sizer.Sizes("/home/test")
+			return !updatedAt.IsZero()
+		}, time.Second, time.Millisecond)
+
+		dirs, updatedAt = sizer.Sizes("/home/test")
+		require.Equal(t, want, dirs)
+		require.Equal(t, now, updatedAt)
+
+		mu.Lock()
+		require.Equal(t, 1, calls)
+		mu.Unlock()
+	})
+
+	t.Run("other directories", func(t *testing.T) {
+		sizer := NewDirSizer("/home/test", 2, time.Minute)
+		sizer.walk = func(root string, depth int) ([]DirUsage, error) {
+			panic("should not be called")
+		}
+
+		for _, dir := range []string{"/", "/home", "/home/test/data", "/home/other"} {
+			dirs, updatedAt := sizer.Sizes(dir)
+			require.Nil(t, dirs, dir)
+			require.Zero(t, updatedAt, dir)
+		}
+	})
+
+	t.Run("recomputes after interval", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			calls int
+			now   = time.Now()
+		)
+		sizer := NewDirSizer("/home/test", 1, time.Minute)
+		sizer.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		}
+		sizer.walk = func(root string, depth int) ([]DirUsage, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return []DirUsage{{Path: "data", Bytes: uint64(calls)}}, nil
+		}
+		sizeOf := func() uint64 {
+			dirs, _ := sizer.Sizes("/home/test")
+			if len(dirs) == 0 {
+				return 0
+			}
+			return dirs[0].Bytes
+		}
+
+		require.Eventually(t, func() bool { return sizeOf() == 1 }, time.Second, time.Millisecond)
+
+		mu.Lock()
+		now = now.Add(time.Minute)
+		mu.Unlock()
+
+		require.Eventually(t, func() bool { return sizeOf() == 2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("error keeps previous sizes", func(t *testing.T) {
+		var (
+			mu  sync.Mutex
+			err error
+			now = time.Now()
+		)
+		sizer := NewDirSizer("/home/test", 1, time.Minute)
+		sizer.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		}
+		sizer.walk = func(root string, depth int) ([]DirUsage, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return []DirUsage{{Path: "data"}}, err
+		}
+
+		require.Eventually(t, func() bool {
+			_, updatedAt := sizer.Sizes("/home/test")
+			return !updatedAt.IsZero()
+		}, time.Second, time.Millisecond)
+		start := now
+
+		mu.Lock()
+		err = errors.New("boom")
+		now = now.Add(time.Minute)
+		mu.Unlock()
+
+		sizer.Sizes("/home/test")
+		require.Eventually(t, func() bool {
+			sizer.mu.Lock()
+			defer sizer.mu.Unlock()
+			return !sizer.running
+		}, time.Second, time.Millisecond)
+
+		dirs, updatedAt := sizer.Sizes("/home/test")
+		require.Equal(t, []DirUsage{{Path: "data"}}, dirs)
+		require.Equal(t, start, updatedAt)
+	})
+}
+
+func TestWalkDirs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	mustWrite := func(path string, size int) {
+		full := filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, make([]byte, size), 0o644))
+	}
+	mustWrite("config/config.toml", 10)
+	mustWrite("data/application.db/000001.ldb", 100)
+	mustWrite("data/application.db/000002.ldb", 200)
+	mustWrite("data/blockstore.db/deep/000003.ldb", 50)
+	mustWrite("data/priv_validator_state.json", 5)
+	mustWrite("top-level-file", 1000)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "wasm"), 0o755))
+
+	dirSize := func(path string) uint64 {
+		info, err := os.Stat(filepath.Join(root, path))
+		require.NoError(t, err)
+		return uint64(info.Size())
+	}
+
+	got, err := walkDirs(root, 2)
+	require.NoError(t, err)
+
+	want := []DirUsage{
+		{Path: "config", Bytes: dirSize("config") + 10, Inodes: 2},
+		{
+			Path: "data",
+			Bytes: dirSize("data") + dirSize("data/application.db") + dirSize("data/blockstore.db") +
+				dirSize("data/blockstore.db/deep") + 100 + 200 + 50 + 5,
+			Inodes: 8,
+		},
+		{Path: "data/application.db", Bytes: dirSize("data/application.db") + 300, Inodes: 3},
+		{Path: "data/blockstore.db", Bytes: dirSize("data/blockstore.db") + dirSize("data/blockstore.db/deep") + 50, Inodes: 3},
+		{Path: "wasm", Bytes: dirSize("wasm"), Inodes: 1},
+	}
+	require.Equal(t, want, got)
+
+	got, err = walkDirs(root, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"config", "data", "wasm"}, []string{got[0].Path, got[1].Path, got[2].Path})
+	require.Len(t, got, 3)
+
+	_, err = walkDirs(filepath.Join(root, "does-not-exist"), 1)
+	require.Error(t, err)
+}
@@ -6,19 +6,39 @@ import (
 	"net/http"
 	"path/filepath"
 	"syscall"
+	"time"
 )
 
 // DiskUsageResponse returns disk statistics in bytes.
 type DiskUsageResponse struct {
-	Dir       string `json:"dir"`
-	AllBytes  uint64 `json:"all_bytes,omitempty"`
-	FreeBytes uint64 `json:"free_bytes,omitempty"`
-	Error     string `json:"error,omitempty"`
+	Dir        string `json:"dir"`
+	AllBytes   uint64 `json:"all_bytes,omitempty"`
+	FreeBytes  uint64 `json:"free_bytes,omitempty"`
+	AllInodes  uint64 `json:"all_inodes,omitempty"`
+	FreeInodes uint64 `json:"free_inodes,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// Sizes of the directories below Dir. Only present once computed in the background.
+	Dirs          []DirUsage `json:"dirs,omitempty"`
+	DirsUpdatedAt *time.Time `json:"dirs_updated_at,omitempty"`
 }
 
 // DiskUsage returns a handler which responds with disk statistics in JSON.
 // Path is the filesystem path from which to check disk usage.
 func DiskUsage(w http.ResponseWriter, r *http.Request) {
+	diskUsage(nil, w, r)
+}
+
+// NewDiskUsage returns a handler like DiskUsage which also responds with the sizes of the directories below the
+// path if it is the sizer's root. Sizes are computed in the background by sizer, so early responses may not
+// include them.
+func NewDiskUsage(sizer *DirSizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		diskUsage(sizer, w, r)
+	}
+}
+
+func diskUsage(sizer *DirSizer, w http.ResponseWriter, r *http.Request) {
 	var resp DiskUsageResponse
 	dir := r.URL.Query().Get("dir")
 	if dir == "" {
@@ -45,6 +65,15 @@ func DiskUsage(w http.ResponseWriter, r *http.Request) {
 	)
 	resp.AllBytes = all
 	resp.FreeBytes = free
+	resp.AllInodes = fs.Files
+	resp.FreeInodes = fs.Ffree
+	if sizer != nil {
+		dirs, updatedAt := sizer.Sizes(filepath.Clean(dir))
+		if !updatedAt.IsZero() {
+			resp.Dirs = dirs
+			resp.DirsUpdatedAt = &updatedAt
+		}
+	}
 	mustJSONEncode(resp, w)
 }
 
@@ -3,7 +3,10 @@ package healthcheck
 import (
 	"encoding/json"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -30,8 +33,52 @@ func TestDiskUsage(t *testing.T) {
 		require.NotZero(t, got.AllBytes)
 		require.NotZero(t, got.FreeBytes)
 		require.True(t, got.AllBytes >= got.FreeBytes, "free bytes should not be more than all bytes")
+		require.True(t, got.AllInodes >= got.FreeInodes, "free inodes should not be more than all inodes")
 
 		require.NotContains(t, w.Body.String(), "error")
+		require.NotContains(t, w.Body.String(), "dirs")
+	})
+
+	t.Run("directory sizes", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "data"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "state.db"), make([]byte, 10), 0o644))
+
+		sizer := NewDirSizer(dir, 1, time.Hour)
+		handler := NewDiskUsage(sizer)
+		get := func(dir string) DiskUsageResponse {
+			var (
+				w = httptest.NewRecorder()
+				r = httptest.NewRequest("GET", "/ignored", nil)
+			)
+			q := r.URL.Query()
+			q.Set("dir", dir)
+			r.URL.RawQuery = q.Encode()
+
+			handler(w, r)
+
+			require.Equal(t, 200, w.Code)
+			var got DiskUsageResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			return got
+		}
+
+		require.Eventually(t, func() bool {
+			return get(dir).DirsUpdatedAt != nil
+		}, time.Second, time.Millisecond)
+
+		got := get(dir)
+		require.NotZero(t, got.AllBytes)
+		require.Len(t, got.Dirs, 1)
+		require.Equal(t, "data", got.Dirs[0].Path)
+		require.Equal(t, uint64(2), got.Dirs[0].Inodes)
+		require.Greater(t, got.Dirs[0].Bytes, uint64(10))
+
+		// Other directories only report filesystem statistics.
+		got = get(filepath.Join(dir, "data"))
+		require.NotZero(t, got.AllBytes)
+		require.Empty(t, got.Dirs)
+		require.Nil(t, got.DirsUpdatedAt)
 	})
 
 	t.Run("statfs error", func(t *testing.T) {
@@ -0,0 +1,190 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	grpcLatestBlockMethod = "/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock"
+
+	// The response contains the full block, which exceeds gRPC's default 4 MiB limit on busy chains.
+	// Comfortably above the largest max block size chains configure in practice.
+	grpcMaxRecvMsgSize = 256 << 20
+)
+
+// GRPC queries the latest block from the Cosmos SDK gRPC server.
+// To avoid depending on the Cosmos SDK, the response is decoded from the protobuf wire format.
+type GRPC struct {
+	conn *grpc.ClientConn
+}
+
+// NewGRPC returns a valid GRPC. Addr is the gRPC server's host and port, e.g. localhost:9090.
+// The connection is established lazily.
+func NewGRPC(addr string) (*GRPC, error) {
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxRecvMsgSize)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPC{conn: conn}, nil
+}
+
+// Close closes the connection.
+func (c *GRPC) Close() error {
+	return c.conn.Close()
+}
+
+// LatestBlock implements LatestBlocker.
+func (c *GRPC) LatestBlock(ctx context.Context) (LatestBlock, error) {
+	// GetLatestBlockRequest has no fields.
+	req := []byte{}
+	var resp []byte
+	if err := c.conn.Invoke(ctx, grpcLatestBlockMethod, &req, &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return LatestBlock{}, err
+	}
+	return decodeLatestBlock(resp)
+}
+
+// rawCodec passes messages through as bytes.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unsupported type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// Name is "proto" so the content type matches what the server expects.
+func (rawCodec) Name() string { return "proto" }
+
+// Field numbers of the messages in cosmos/base/tendermint/v1beta1/query.proto and tendermint/types/types.proto.
+const (
+	latestBlockBlockField    protowire.Number = 2 // deprecated in Cosmos SDK v0.47
+	latestBlockSDKBlockField protowire.Number = 3
+	blockHeaderField         protowire.Number = 1
+	headerHeightField        protowire.Number = 3
+	headerTimeField          protowire.Number = 4
+	timestampSecondsField    protowire.Number = 1
+	timestampNanosField      protowire.Number = 2
+)
+
+// decodeLatestBlock decodes a GetLatestBlockResponse.
+func decodeLatestBlock(resp []byte) (LatestBlock, error) {
+	var latest LatestBlock
+	block, ok, err := protoBytesField(resp, latestBlockSDKBlockField)
+	if err == nil && !ok {
+		block, ok, err = protoBytesField(resp, latestBlockBlockField)
+	}
+	if err != nil {
+		return latest, err
+	}
+	if !ok {
+		return latest, errors.New("missing block")
+	}
+
+	header, ok, err := protoBytesField(block, blockHeaderField)
+	if err != nil {
+		return latest, err
+	}
+	if !ok {
+		return latest, errors.New("missing block header")
+	}
+	if latest.Height, _, err = protoVarintField(header, headerHeightField); err != nil {
+		return latest, err
+	}
+
+	ts, ok, err := protoBytesField(header, headerTimeField)
+	if err != nil || !ok {
+		return latest, err
+	}
+	secs, _, err := protoVarintField(ts, timestampSecondsField)
+	if err != nil {
+		return latest, err
+	}
+	nanos, _, err := protoVarintField(ts, timestampNanosField)
+	if err != nil {
+		return latest, err
+	}
+	latest.Time = time.Unix(int64(secs), int64(nanos)).UTC()
+	return latest, nil
+}
+
+// protoBytesField returns the last occurrence of the length-delimited field.
+func protoBytesField(b []byte, field protowire.Number) ([]byte, bool, error) {
+	var (
+		found []byte
+		ok    bool
+	)
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != field || typ != protowire.BytesType {
+			return nil
+		}
+		v, n := protowire.ConsumeBytes(value)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		found, ok = v, true
+		return nil
+	})
+	return found, ok, err
+}
+
+// protoVarintField returns the last occurrence of the varint field.
+func protoVarintField(b []byte, field protowire.Number) (uint64, bool, error) {
+	var (
+		found uint64
+		ok    bool
+	)
+	err := protoRange(b, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != field || typ != protowire.VarintType {
+			return nil
+		}
+		v, n := protowire.ConsumeVarint(value)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		found, ok = v, true
+		return nil
+	})
+	return found, ok, err
+}
+
+// protoRange calls fn with the encoded value of each field in the message.
+func protoRange(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("malformed protobuf: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return fmt.Errorf("malformed protobuf: %w", protowire.ParseError(n))
+		}
+		if err := fn(num, typ, b[:n]); err != nil {
+			return fmt.Errorf("malformed protobuf: %w", err)
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// latestBlockResponse encodes a GetLatestBlockResponse with a block at the given height and time in the given field.
+func latestBlockResponse(field protowire.Number, height uint64, ts time.Time) []byte {
+	var timestamp []byte
+	timestamp = appendVarint(timestamp, timestampSecondsField, uint64(ts.Unix()))
+	timestamp = appendVarint(timestamp, timestampNanosField, uint64(ts.Nanosecond()))
+
+	var header []byte
+	header = appendMessage(header, 1, []byte("version"))
+	header = appendMessage(header, 2, []byte("cosmoshub-4"))
+	header = appendVarint(header, headerHeightField, height)
+	header = appendMessage(header, headerTimeField, timestamp)
+
+	var block []byte
+	block = appendMessage(block, blockHeaderField, header)
+	block = appendMessage(block, 2, []byte("data"))
+
+	var resp []byte
+	resp = appendMessage(resp, 1, []byte("block id"))
+	return appendMessage(resp, field, block)
+}
+
+func TestDecodeLatestBlock(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	t.Run("sdk block", func(t *testing.T) {
+		resp := latestBlockResponse(latestBlockSDKBlockField, 456, ts)
+		// The deprecated block is ignored.
+		resp = append(resp, latestBlockResponse(latestBlockBlockField, 123, ts)...)
+
+		got, err := decodeLatestBlock(resp)
+		require.NoError(t, err)
+		require.Equal(t, LatestBlock{Height: 456, Time: ts}, got)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		got, err := decodeLatestBlock(latestBlockResponse(latestBlockBlockField, 123, ts))
+		require.NoError(t, err)
+		require.Equal(t, LatestBlock{Height: 123, Time: ts}, got)
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		_, err := decodeLatestBlock(nil)
+		require.EqualError(t, err, "missing block")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := decodeLatestBlock([]byte{0xff})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "malformed protobuf")
+	})
+}
+
+func TestGRPC_LatestBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var gotMethod string
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			gotMethod, _ = grpc.MethodFromServerStream(stream)
+			var req []byte
+			if err := stream.RecvMsg(&req); err != nil {
+				return err
+			}
+			if len(req) > 0 {
+				return status.Error(codes.InvalidArgument, "unexpected request")
+			}
+			resp := latestBlockResponse(latestBlockSDKBlockField, 789, ts)
+			// Larger than gRPC's default 4 MiB receive limit, like blocks of busy chains.
+			resp = appendMessage(resp, 100, make([]byte, 8<<20))
+			return stream.SendMsg(&resp)
+		}),
+	)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	client, err := NewGRPC(lis.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	got, err := client.LatestBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, LatestBlock{Height: 789, Time: ts}, got)
+	require.Equal(t, grpcLatestBlockMethod, gotMethod)
+}
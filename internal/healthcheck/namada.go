@@ -0,0 +1,133 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+)
+
+// ABCIInfoer can query the CometBFT abci_info endpoint.
+type ABCIInfoer interface {
+	ABCIInfo(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error)
+}
+
+// NamadaResponse is the result of the Namada-specific checks.
+type NamadaResponse struct {
+	// The ledger shell's last block height, from abci_info.
+	ShellHeight uint64 `json:"shell_height,omitempty"`
+	// Set if the ledger shell is unresponsive.
+	ShellError string `json:"shell_error,omitempty"`
+	// Empty if the Ethereum bridge oracle is not checked.
+	OracleRPC string `json:"oracle_rpc,omitempty"`
+	// Set if the Ethereum bridge oracle's RPC endpoint is unreachable.
+	OracleError string `json:"oracle_error,omitempty"`
+}
+
+// FailedChecks returns why the Namada node is unhealthy, if it is.
+func (resp NamadaResponse) FailedChecks() []string {
+	var failed []string
+	if resp.ShellError != "" {
+		failed = append(failed, "ledger shell: "+resp.ShellError)
+	}
+	if resp.OracleError != "" {
+		failed = append(failed, "ethereum bridge oracle: "+resp.OracleError)
+	}
+	return failed
+}
+
+// Namada checks the Namada ledger process independently of CometBFT. The ledger shell and the Ethereum bridge
+// oracle can be unhealthy while CometBFT's status looks fine.
+type Namada struct {
+	comet     ABCIInfoer
+	httpDo    func(req *http.Request) (*http.Response, error)
+	oracleRPC string
+	rpcHost   string
+	timeout   time.Duration
+}
+
+// NewNamada returns a valid Namada. If oracleRPC is empty, the Ethereum bridge oracle is not checked.
+func NewNamada(comet ABCIInfoer, client *http.Client, rpcHost, oracleRPC string, timeout time.Duration) *Namada {
+	return &Namada{
+		comet:     comet,
+		httpDo:    client.Do,
+		oracleRPC: oracleRPC,
+		rpcHost:   rpcHost,
+		timeout:   timeout,
+	}
+}
+
+// Check runs the Namada-specific checks.
+func (n *Namada) Check(ctx context.Context) NamadaResponse {
+	var resp NamadaResponse
+	// CometBFT answers abci_info by querying the ledger shell.
+	info, err := n.comet.ABCIInfo(ctx, n.rpcHost)
+	if err != nil {
+		resp.ShellError = err.Error()
+	} else {
+		resp.ShellHeight = info.LastHeight()
+	}
+
+	if n.oracleRPC != "" {
+		resp.OracleRPC = n.oracleRPC
+		if err = n.ethBlockNumber(ctx); err != nil {
+			resp.OracleError = err.Error()
+		}
+	}
+	return resp
+}
+
+// ServeHTTP implements http.Handler.
+func (n *Namada) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), n.timeout)
+	defer cancel()
+
+	resp := n.Check(ctx)
+	code := http.StatusOK
+	if len(resp.FailedChecks()) > 0 {
+		code = http.StatusServiceUnavailable
+	}
+	w.WriteHeader(code)
+	w.Header().Set("Content-Type", "application/json")
+	mustJSONEncode(resp, w)
+}
+
+// ethBlockNumber calls the Ethereum JSON-RPC eth_blockNumber method, which the oracle relies on.
+func (n *Namada) ethBlockNumber(ctx context.Context) error {
+	body := []byte(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`)
+	req, err := http.NewRequestWithContext(ctx, "POST", n.oracleRPC, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.httpDo(req)
+	if err != nil {
+		return fmt.Errorf("http do: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var rpcResp struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return errors.New(rpcResp.Error.Message)
+	}
+	if rpcResp.Result == "" {
+		return errors.New("missing block number")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+type mockABCIInfoer func(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error)
+
+func (fn mockABCIInfoer) ABCIInfo(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error) {
+	return fn(ctx, rpcHost)
+}
+
+func TestNamada_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const (
+		testRPC    = "http://localhost:26657"
+		testOracle = "http://127.0.0.1:8545"
+	)
+
+	healthyShell := mockABCIInfoer(func(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error) {
+		require.Equal(t, testRPC, rpcHost)
+		return cosmos.ABCIInfo{LastBlockHeight: "100"}, nil
+	})
+	stubOracle := func(code int, body string) func(req *http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "POST", req.Method)
+			require.Equal(t, testOracle, req.URL.String())
+			b, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(b), `"method":"eth_blockNumber"`)
+			return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		n := NewNamada(healthyShell, http.DefaultClient, testRPC, testOracle, time.Second)
+		n.httpDo = stubOracle(http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":"0x12a05f200"}`)
+
+		got := n.Check(ctx)
+		require.Equal(t, NamadaResponse{ShellHeight: 100, OracleRPC: testOracle}, got)
+		require.Empty(t, got.FailedChecks())
+	})
+
+	t.Run("oracle not checked", func(t *testing.T) {
+		n := NewNamada(healthyShell, http.DefaultClient, testRPC, "", time.Second)
+		n.httpDo = func(req *http.Request) (*http.Response, error) {
+			panic("should not be called")
+		}
+
+		require.Equal(t, NamadaResponse{ShellHeight: 100}, n.Check(ctx))
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		unresponsive := mockABCIInfoer(func(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error) {
+			return cosmos.ABCIInfo{}, errors.New("context deadline exceeded")
+		})
+
+		for _, tt := range []struct {
+			Code       int
+			Body       string
+			WantOracle string
+		}{
+			{http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`, "header not found"},
+			{http.StatusOK, `{"jsonrpc":"2.0","id":1}`, "missing block number"},
+			{http.StatusBadGateway, ``, "unexpected status code 502"},
+		} {
+			n := NewNamada(unresponsive, http.DefaultClient, testRPC, testOracle, time.Second)
+			n.httpDo = stubOracle(tt.Code, tt.Body)
+
+			got := n.Check(ctx)
+			require.Equal(t, NamadaResponse{ShellError: "context deadline exceeded", OracleRPC: testOracle, OracleError: tt.WantOracle}, got)
+			require.Equal(t, []string{
+				"ledger shell: context deadline exceeded",
+				"ethereum bridge oracle: " + tt.WantOracle,
+			}, got.FailedChecks())
+		}
+	})
+}
+
+func TestNamada_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	stubReq := httptest.NewRequest("GET", "/namada", nil)
+
+	for _, tt := range []struct {
+		Shell    mockABCIInfoer
+		WantCode int
+	}{
+		{func(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error) {
+			return cosmos.ABCIInfo{LastBlockHeight: "5"}, nil
+		}, http.StatusOK},
+		{func(ctx context.Context, rpcHost string) (cosmos.ABCIInfo, error) {
+			return cosmos.ABCIInfo{}, errors.New("boom")
+		}, http.StatusServiceUnavailable},
+	} {
+		n := NewNamada(tt.Shell, http.DefaultClient, "http://localhost:26657", "", time.Second)
+		w := httptest.NewRecorder()
+		n.ServeHTTP(w, stubReq)
+
+		require.Equal(t, tt.WantCode, w.Code)
+		var got NamadaResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	}
+}
@@ -0,0 +1,60 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Getter gets kubernetes objects.
+type Getter interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+}
+
+// StatusReference reads the reference height from the CosmosFullNode's status.referenceHeight.
+// The height is cached so probes do not query the API server each time.
+type StatusReference struct {
+	client Getter
+	key    client.ObjectKey
+	now    func() time.Time
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	height    uint64
+	fetchedAt time.Time
+}
+
+func NewStatusReference(client Getter, key client.ObjectKey, ttl time.Duration) *StatusReference {
+	return &StatusReference{
+		client: client,
+		key:    key,
+		now:    time.Now,
+		ttl:    ttl,
+	}
+}
+
+// ReferenceHeight implements ReferenceHeighter.
+func (r *StatusReference) ReferenceHeight(ctx context.Context) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if !r.fetchedAt.IsZero() && now.Sub(r.fetchedAt) < r.ttl {
+		return r.height, nil
+	}
+
+	var crd cosmosv1.CosmosFullNode
+	if err := r.client.Get(ctx, r.key, &crd); err != nil {
+		return 0, err
+	}
+	if crd.Status.ReferenceHeight == nil {
+		return 0, errors.New("reference height not yet recorded")
+	}
+	r.height = *crd.Status.ReferenceHeight
+	r.fetchedAt = now
+	return r.height, nil
+}
@@ -0,0 +1,82 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockGetter struct {
+	Height  *uint64
+	Err     error
+	GotKey  client.ObjectKey
+	GetCall int
+}
+
+func (m *mockGetter) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GetCall++
+	m.GotKey = key
+	obj.(*cosmosv1.CosmosFullNode).Status.ReferenceHeight = m.Height
+	return m.Err
+}
+
+func TestStatusReference_ReferenceHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "default", Name: "cosmoshub"}
+
+	t.Run("happy path", func(t *testing.T) {
+		now := time.Now()
+		height := uint64(100)
+		getter := &mockGetter{Height: &height}
+		ref := NewStatusReference(getter, key, time.Minute)
+		ref.now = func() time.Time { return now }
+
+		got, err := ref.ReferenceHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, got)
+		require.Equal(t, key, getter.GotKey)
+
+		// Cached.
+		height = 200
+		got, err = ref.ReferenceHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, got)
+		require.Equal(t, 1, getter.GetCall)
+
+		now = now.Add(time.Minute)
+		got, err = ref.ReferenceHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 200, got)
+		require.Equal(t, 2, getter.GetCall)
+	})
+
+	t.Run("not recorded", func(t *testing.T) {
+		getter := &mockGetter{}
+		ref := NewStatusReference(getter, key, time.Minute)
+
+		_, err := ref.ReferenceHeight(ctx)
+		require.EqualError(t, err, "reference height not yet recorded")
+
+		// Not cached.
+		_, err = ref.ReferenceHeight(ctx)
+		require.Error(t, err)
+		require.Equal(t, 2, getter.GetCall)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		ref := NewStatusReference(&mockGetter{Err: errors.New("boom")}, key, time.Minute)
+
+		_, err := ref.ReferenceHeight(ctx)
+		require.EqualError(t, err, "boom")
+	})
+}
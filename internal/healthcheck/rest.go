@@ -0,0 +1,79 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const restLatestBlockPath = "/cosmos/base/tendermint/v1beta1/blocks/latest"
+
+// REST queries the latest block from the Cosmos SDK REST API.
+type REST struct {
+	host   string
+	httpDo func(req *http.Request) (*http.Response, error)
+}
+
+// NewREST returns a valid REST. Host is the API's base URL, e.g. http://localhost:1317.
+func NewREST(client *http.Client, host string) *REST {
+	return &REST{
+		host:   host,
+		httpDo: client.Do,
+	}
+}
+
+type restBlockHeader struct {
+	Height string    `json:"height"`
+	Time   time.Time `json:"time"`
+}
+
+type restLatestBlockResponse struct {
+	Block *struct {
+		Header restBlockHeader `json:"header"`
+	} `json:"block"`
+	// Added in Cosmos SDK v0.47.
+	SDKBlock *struct {
+		Header restBlockHeader `json:"header"`
+	} `json:"sdk_block"`
+}
+
+// LatestBlock implements LatestBlocker.
+func (c REST) LatestBlock(ctx context.Context) (LatestBlock, error) {
+	var block LatestBlock
+	req, err := http.NewRequestWithContext(ctx, "GET", c.host+restLatestBlockPath, nil)
+	if err != nil {
+		return block, fmt.Errorf("new request: %w", err)
+	}
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return block, fmt.Errorf("http do: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return block, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var body restLatestBlockResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return block, fmt.Errorf("malformed json: %w", err)
+	}
+	var header restBlockHeader
+	switch {
+	case body.SDKBlock != nil:
+		header = body.SDKBlock.Header
+	case body.Block != nil:
+		header = body.Block.Header
+	default:
+		return block, errors.New("missing block")
+	}
+	block.Height, err = strconv.ParseUint(header.Height, 10, 64)
+	if err != nil {
+		return block, fmt.Errorf("malformed height: %w", err)
+	}
+	block.Time = header.Time
+	return block, nil
+}
@@ -0,0 +1,66 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestREST_LatestBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := NewREST(http.DefaultClient, "http://localhost:1317")
+
+	stubResponse := func(code int, body string) func(req *http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "GET", req.Method)
+			require.Equal(t, "http://localhost:1317/cosmos/base/tendermint/v1beta1/blocks/latest", req.URL.String())
+			return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		for _, tt := range []struct {
+			Body       string
+			WantHeight uint64
+		}{
+			{`{"block":{"header":{"height":"123","time":"2024-01-02T03:04:05Z"}}}`, 123},
+			{`{"block":{"header":{"height":"123","time":"2024-01-02T03:04:05Z"}},"sdk_block":{"header":{"height":"456","time":"2024-01-02T03:04:05Z"}}}`, 456},
+		} {
+			client.httpDo = stubResponse(http.StatusOK, tt.Body)
+			got, err := client.LatestBlock(ctx)
+			require.NoError(t, err, tt)
+			require.Equal(t, LatestBlock{Height: tt.WantHeight, Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, got, tt)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			Code    int
+			Body    string
+			WantErr string
+		}{
+			{http.StatusNotImplemented, `{}`, "unexpected status code 501"},
+			{http.StatusOK, `{`, "malformed json: unexpected EOF"},
+			{http.StatusOK, `{}`, "missing block"},
+			{http.StatusOK, `{"block":{"header":{"height":"abc"}}}`, `malformed height: strconv.ParseUint: parsing "abc": invalid syntax`},
+		} {
+			client.httpDo = stubResponse(tt.Code, tt.Body)
+			_, err := client.LatestBlock(ctx)
+			require.EqualError(t, err, tt.WantErr, tt)
+		}
+
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}
+		_, err := client.LatestBlock(ctx)
+		require.EqualError(t, err, "http do: connection refused")
+	})
+}
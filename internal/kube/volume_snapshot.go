@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -11,6 +13,73 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Metadata recorded on VolumeSnapshots created by the operator.
+const (
+	// SnapshotHeightAnnotation is the block height of the source pod when the snapshot was taken.
+	SnapshotHeightAnnotation = "cosmos.bharvest/height"
+	// SnapshotImageAnnotation is the image of the source pod's chain container.
+	SnapshotImageAnnotation = "cosmos.bharvest/image"
+	// SnapshotAppVersionAnnotation is the application's protocol version reported by the source pod's RPC /status.
+	SnapshotAppVersionAnnotation = "cosmos.bharvest/app-version"
+	// SnapshotAllowedNamespacesAnnotation is a comma separated list of namespaces which may restore PVCs from the
+	// snapshot. "*" allows all namespaces.
+	SnapshotAllowedNamespacesAnnotation = "cosmos.bharvest/allowed-namespaces"
+	// SnapshotDatabaseBackendLabel is the database backend of the source chain. E.g. goleveldb, rocksdb, pebbledb.
+	SnapshotDatabaseBackendLabel = "cosmos.bharvest/database-backend"
+	// SnapshotVerifiedLabel is "true" if the snapshot's chain data was verified or "false" if verification failed.
+	SnapshotVerifiedLabel = "cosmos.bharvest/verified"
+)
+
+// VolumeSnapshotFilter returns true if the VolumeSnapshot is a suitable candidate.
+type VolumeSnapshotFilter func(vs snapshotv1.VolumeSnapshot) bool
+
+// MinHeightFilter matches VolumeSnapshots recorded at or above height.
+// VolumeSnapshots without a valid height annotation never match.
+func MinHeightFilter(height uint64) VolumeSnapshotFilter {
+	return func(vs snapshotv1.VolumeSnapshot) bool {
+		got, ok := VolumeSnapshotHeight(vs)
+		return ok && got >= height
+	}
+}
+
+// VolumeSnapshotHeight returns the block height recorded on the VolumeSnapshot.
+// Returns false if the height annotation is missing or malformed.
+func VolumeSnapshotHeight(vs snapshotv1.VolumeSnapshot) (uint64, bool) {
+	got, err := strconv.ParseUint(vs.Annotations[SnapshotHeightAnnotation], 10, 64)
+	return got, err == nil
+}
+
+// ImageFilter matches VolumeSnapshots taken from a pod running image.
+func ImageFilter(image string) VolumeSnapshotFilter {
+	return func(vs snapshotv1.VolumeSnapshot) bool {
+		return vs.Annotations[SnapshotImageAnnotation] == image
+	}
+}
+
+// NamespaceAllowedFilter matches VolumeSnapshots which allow PVCs in namespace to use them as a data source.
+// VolumeSnapshots in the same namespace always match.
+func NamespaceAllowedFilter(namespace string) VolumeSnapshotFilter {
+	return func(vs snapshotv1.VolumeSnapshot) bool {
+		if vs.Namespace == namespace {
+			return true
+		}
+		allowed := VolumeSnapshotAllowedNamespaces(vs)
+		return lo.Contains(allowed, namespace) || lo.Contains(allowed, "*")
+	}
+}
+
+// VolumeSnapshotAllowedNamespaces returns the namespaces recorded on the VolumeSnapshot which may use it as a
+// data source.
+func VolumeSnapshotAllowedNamespaces(vs snapshotv1.VolumeSnapshot) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(vs.Annotations[SnapshotAllowedNamespacesAnnotation], ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // VolumeSnapshotIsReady returns true if the snapshot is ready to use.
 func VolumeSnapshotIsReady(status *snapshotv1.VolumeSnapshotStatus) bool {
 	if status == nil {
@@ -27,10 +96,10 @@ type Lister interface {
 	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
 }
 
-// RecentVolumeSnapshot finds the most recent, ready to use VolumeSnapshot.
+// RecentVolumeSnapshot finds the most recent, ready to use VolumeSnapshot matching all filters.
 // This function may not work well given very large lists and therefore assumes a reasonable number of VolumeSnapshots.
 // If you must search among many VolumeSnapshots, consider refactoring to use Limit and Continue features of listing.
-func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string, selector map[string]string, filters ...VolumeSnapshotFilter) (*snapshotv1.VolumeSnapshot, error) {
 	var snapshots snapshotv1.VolumeSnapshotList
 	err := lister.List(ctx,
 		&snapshots,
@@ -42,7 +111,15 @@ func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string,
 	}
 
 	filtered := lo.Filter(snapshots.Items, func(s snapshotv1.VolumeSnapshot, _ int) bool {
-		return VolumeSnapshotIsReady(s.Status)
+		if !VolumeSnapshotIsReady(s.Status) {
+			return false
+		}
+		for _, filter := range filters {
+			if !filter(s) {
+				return false
+			}
+		}
+		return true
 	})
 	if len(filtered) == 0 {
 		return nil, errors.New("no ready to use VolumeSnapshots found")
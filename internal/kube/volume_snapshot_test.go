@@ -154,4 +154,102 @@ func TestRecentVolumeSnapshot(t *testing.T) {
 			require.Equal(t, "found", got.Name)
 		}
 	})
+	t.Run("filters", func(t *testing.T) {
+		now := metav1.Now()
+		var old snapshotv1.VolumeSnapshot
+		old.Name = "old"
+		old.Annotations = map[string]string{
+			SnapshotHeightAnnotation: "100",
+			SnapshotImageAnnotation:  "chain:v1",
+		}
+		old.Status = &snapshotv1.VolumeSnapshotStatus{
+			CreationTime: ptr(metav1.NewTime(now.Add(-time.Hour))),
+			ReadyToUse:   ptr(true),
+		}
+
+		recent := *old.DeepCopy()
+		recent.Name = "recent"
+		recent.Annotations = map[string]string{
+			SnapshotHeightAnnotation: "50",
+			SnapshotImageAnnotation:  "chain:v2",
+		}
+		recent.Status.CreationTime = ptr(now)
+
+		unknown := *old.DeepCopy()
+		unknown.Name = "unknown"
+		unknown.Annotations = nil
+		unknown.Status.CreationTime = ptr(metav1.NewTime(now.Add(time.Hour)))
+
+		lister := mockLister(func(ctx context.Context, inList client.ObjectList, opts ...client.ListOption) error {
+			ref := inList.(*snapshotv1.VolumeSnapshotList)
+			ref.Items = []snapshotv1.VolumeSnapshot{old, recent, unknown}
+			return nil
+		})
+
+		got, err := RecentVolumeSnapshot(ctx, lister, namespace, selector)
+		require.NoError(t, err)
+		require.Equal(t, "unknown", got.Name)
+
+		got, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, MinHeightFilter(50))
+		require.NoError(t, err)
+		require.Equal(t, "recent", got.Name)
+
+		got, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, MinHeightFilter(51))
+		require.NoError(t, err)
+		require.Equal(t, "old", got.Name)
+
+		got, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, ImageFilter("chain:v1"))
+		require.NoError(t, err)
+		require.Equal(t, "old", got.Name)
+
+		_, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, MinHeightFilter(51), ImageFilter("chain:v2"))
+		require.EqualError(t, err, "no ready to use VolumeSnapshots found")
+	})
+}
+
+func TestVolumeSnapshotHeight(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Annotations map[string]string
+		Want        uint64
+		WantOK      bool
+	}{
+		{nil, 0, false},
+		{map[string]string{SnapshotHeightAnnotation: ""}, 0, false},
+		{map[string]string{SnapshotHeightAnnotation: "nope"}, 0, false},
+		{map[string]string{SnapshotHeightAnnotation: "-1"}, 0, false},
+		{map[string]string{SnapshotHeightAnnotation: "0"}, 0, true},
+		{map[string]string{SnapshotHeightAnnotation: "12345"}, 12345, true},
+	} {
+		var vs snapshotv1.VolumeSnapshot
+		vs.Annotations = tt.Annotations
+		got, ok := VolumeSnapshotHeight(vs)
+
+		require.Equal(t, tt.WantOK, ok, tt)
+		require.Equal(t, tt.Want, got, tt)
+	}
+}
+
+func TestNamespaceAllowedFilter(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Namespace string
+		Allowed   string
+		Want      bool
+	}{
+		{"source", "", true},
+		{"other", "", false},
+		{"other", "foo,bar", false},
+		{"other", "foo, other", true},
+		{"other", "*", true},
+		{"other", " , ", false},
+	} {
+		var vs snapshotv1.VolumeSnapshot
+		vs.Namespace = "source"
+		vs.Annotations = map[string]string{SnapshotAllowedNamespacesAnnotation: tt.Allowed}
+
+		require.Equal(t, tt.Want, NamespaceAllowedFilter(tt.Namespace)(vs), tt)
+	}
 }
@@ -2,11 +2,11 @@ package prune
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,182 +14,275 @@ import (
 	"time"
 )
 
-const NO_CANDIDATES_ERR = "there are no candidates"
-
 type StatusSyncer interface {
 	SyncUpdate(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error
 }
 
-// FullNodeControl manages a ScheduledVolumeSnapshot's spec.fullNodeRef.
+// FullNodeControl manages pruning candidates within a CosmosFullNode's status.
 type FullNodeControl struct {
 	client       client.Reader
 	statusClient StatusSyncer
+	now          func() time.Time
 }
 
 func NewFullNodeControl(statusClient StatusSyncer, client client.Reader) *FullNodeControl {
-	return &FullNodeControl{client: client, statusClient: statusClient}
+	return &FullNodeControl{client: client, statusClient: statusClient, now: time.Now}
 }
 
-func (control FullNodeControl) SignalPodReplace(ctx context.Context, crd *cosmosv1.CosmosFullNode, pods []*corev1.Pod) error {
-	for _, candidate := range pods {
-		key := control.sourceKey(candidate.Name, candidate.Namespace)
-		objKey := client.ObjectKey{Name: crd.Name, Namespace: crd.Namespace}
-		if err := control.statusClient.SyncUpdate(ctx, objKey, func(status *cosmosv1.FullNodeStatus) {
-			if status.SelfHealing.CosmosPruningStatus == nil {
-				status.SelfHealing.CosmosPruningStatus = new(cosmosv1.CosmosPruningStatus)
-			}
-			if status.SelfHealing.CosmosPruningStatus.Candidates == nil {
-				status.SelfHealing.CosmosPruningStatus.Candidates = make(map[string]cosmosv1.SelfHealingCandidate)
-			}
-			status.SelfHealing.CosmosPruningStatus.Candidates[key] = cosmosv1.SelfHealingCandidate{PodName: candidate.Name, Namespace: candidate.Namespace}
-			status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseWaitingForPodReplaced
-
-			if status.SelfHealing.CosmosPruningStatus.PodPruningStatus == nil {
-				status.SelfHealing.CosmosPruningStatus.PodPruningStatus = make(map[string]cosmosv1.PodPruningStatus)
-			}
-			status.SelfHealing.CosmosPruningStatus.PodPruningStatus[key] = cosmosv1.PodPruningStatus{LastPruneTime: ptr(metav1.NewTime(time.Now()))}
-		}); err != nil {
-			return err
-		}
-	}
-	return nil
+// phaseOrder lists candidate phases from least to most advanced.
+var phaseOrder = []cosmosv1.CosmosPruningPhase{
+	cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+	cosmosv1.CosmosPruningPhaseWaitingForComplete,
+	cosmosv1.CosmosPruningPhasePruningFailed,
+	cosmosv1.CosmosPruningPhaseRestorePod,
+	cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
 }
 
-// ConfirmPodReplaced ConfirmPodDeletion returns a nil error if the pod is replaced.
-// Any non-nil error is transient, including if the pod has not been replaced yet.
-// If CosmosPruning.Status.Candidates are no, reconciler will be misunderstood it's working good.
-func (control FullNodeControl) ConfirmPodReplaced(ctx context.Context, crd *cosmosv1.CosmosFullNode) error {
-	var (
-		pods      corev1.PodList
-		joinedErr error
-	)
-	if err := control.client.List(ctx, &pods,
-		client.InNamespace(crd.Namespace),
-		client.MatchingFields{kube.ControllerOwnerField: crd.Name},
-	); err != nil {
-		return fmt.Errorf("list pods: %w", err)
-	}
-
-	var existsCandidate bool
-	for _, pod := range pods.Items {
-		for _, c := range crd.Status.SelfHealing.CosmosPruningStatus.Candidates {
-			if pod.Name == c.PodName {
-				return fmt.Errorf("pod %s not replaced yet", pod.Name)
-			} else {
-				existsCandidate = true
+// SignalPodReplace adds the pods as pruning candidates. The CosmosFullNode controller replaces each candidate
+// with a pruner pod. Records each pod's disk usage before pruning.
+func (control FullNodeControl) SignalPodReplace(ctx context.Context, crd *cosmosv1.CosmosFullNode, pods []*corev1.Pod, usage []fullnode.PVCDiskUsage) error {
+	now := metav1.NewTime(control.now())
+	return control.update(ctx, crd, func(status *cosmosv1.FullNodeStatus) {
+		pruningStatus := status.SelfHealing.CosmosPruningStatus
+		for _, candidate := range pods {
+			key := control.sourceKey(candidate.Name, candidate.Namespace)
+			pruningStatus.Candidates[key] = cosmosv1.PruningCandidate{
+				SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: candidate.Name, Namespace: candidate.Namespace},
+				Phase:                cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
 			}
+			podStatus := cosmosv1.PodPruningStatus{LastPruneTime: ptr(now)}
+			if u, ok := lo.Find(usage, func(u fullnode.PVCDiskUsage) bool { return u.PodName == candidate.Name }); ok {
+				podStatus.UsedBytesBefore = ptr(u.UsedBytes)
+			}
+			pruningStatus.PodPruningStatus[key] = podStatus
 		}
-	}
-	pruningPhase := cosmosv1.CosmosPruningPhaseWaitingForComplete
+	})
+}
 
-	if !existsCandidate {
-		pruningPhase = cosmosv1.CosmosPruningPhaseFindingCandidate
-		joinedErr = errors.New(NO_CANDIDATES_ERR)
-	}
-	return errors.Join(joinedErr, control.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
-		status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = pruningPhase
-	}))
+// PruningResult is the outcome of a pruner container.
+type PruningResult struct {
+	PodName  string
+	ExitCode int32
+	Duration time.Duration
 }
 
-func (control FullNodeControl) CheckPruningComplete(ctx context.Context, crd *cosmosv1.CosmosFullNode) (bool, error) {
-	var (
-		pods            corev1.PodList
-		existsCandidate bool
-		pruningStatus   = crd.Status.SelfHealing.CosmosPruningStatus
-	)
-	if pruningStatus == nil {
-		return false, errors.New(NO_CANDIDATES_ERR)
-	} else if pruningStatus.Candidates == nil {
-		return false, errors.New(NO_CANDIDATES_ERR)
+// Succeeded returns true if the pruner container exited successfully.
+func (r PruningResult) Succeeded() bool { return r.ExitCode == 0 }
+
+// AdvanceCandidates moves each candidate to its next phase once the CosmosFullNode's pods reflect its current phase.
+// Candidates progress independently of each other:
+//
+//	WaitingForPodReplaced -> WaitingForComplete -> [PruningFailed ->] RestoringPod -> ConfirmPodRestoration -> (removed)
+//
+// A candidate whose pruner container failed remains in PruningFailed until the spec's restoreOnFailure is true.
+// Returns the results of pruner containers which finished. Any error can be treated as transient.
+func (control FullNodeControl) AdvanceCandidates(ctx context.Context, crd *cosmosv1.CosmosFullNode) ([]PruningResult, error) {
+	pruningStatus := crd.Status.SelfHealing.CosmosPruningStatus
+	if pruningStatus == nil || len(pruningStatus.Candidates) == 0 {
+		return nil, nil
 	}
 
+	var pods corev1.PodList
 	if err := control.client.List(ctx, &pods,
 		client.InNamespace(crd.Namespace),
 		client.MatchingFields{kube.ControllerOwnerField: crd.Name},
 	); err != nil {
-		return false, fmt.Errorf("list pods: %w", err)
+		return nil, fmt.Errorf("list pods: %w", err)
 	}
+	podsByName := lo.SliceToMap(pods.Items, func(pod corev1.Pod) (string, corev1.Pod) { return pod.Name, pod })
+
+	var (
+		restoreOnFailure = crd.Spec.SelfHeal != nil && crd.Spec.SelfHeal.PruningSpec != nil && crd.Spec.SelfHeal.PruningSpec.RestoreOnFailure
+		next             = make(map[string]cosmosv1.CosmosPruningPhase)
+		terminated       = make(map[string]*corev1.ContainerStateTerminated)
+		results          []PruningResult
+	)
+	for key, candidate := range pruningStatus.Candidates {
+		_, podExists := podsByName[candidate.PodName]
+		prunerPod, prunerExists := podsByName[fullnode.GetPrunerPodName(candidate.PodName)]
 
-	var existsPod bool
-	for _, pruningCandidate := range pruningStatus.Candidates {
-		for _, p := range pods.Items {
-			if p.Name == fullnode.GetPrunerPodName(pruningCandidate.PodName) {
-				for _, status := range p.Status.ContainerStatuses {
-					if status.State.Terminated != nil {
-						return true, control.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
-							status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseRestorePod
-						})
-					}
-				}
-				existsCandidate = true
+		switch candidate.Phase {
+		case cosmosv1.CosmosPruningPhaseWaitingForPodReplaced, "":
+			if !podExists {
+				next[key] = cosmosv1.CosmosPruningPhaseWaitingForComplete
+			}
+		case cosmosv1.CosmosPruningPhaseWaitingForComplete:
+			if !prunerExists {
+				// If the pruner pod is gone, there is nothing left to wait for.
+				next[key] = cosmosv1.CosmosPruningPhaseRestorePod
+				continue
+			}
+			state := prunerTerminated(prunerPod)
+			if state == nil {
+				continue
+			}
+			terminated[key] = state
+			result := PruningResult{PodName: candidate.PodName, ExitCode: state.ExitCode}
+			if !state.StartedAt.IsZero() && !state.FinishedAt.IsZero() {
+				result.Duration = state.FinishedAt.Sub(state.StartedAt.Time)
+			}
+			results = append(results, result)
+			next[key] = cosmosv1.CosmosPruningPhaseRestorePod
+			if !result.Succeeded() && !restoreOnFailure {
+				next[key] = cosmosv1.CosmosPruningPhasePruningFailed
 			}
-			if strings.Contains(p.Name, pruningCandidate.PodName) {
-				existsPod = true
+		case cosmosv1.CosmosPruningPhasePruningFailed:
+			if restoreOnFailure {
+				next[key] = cosmosv1.CosmosPruningPhaseRestorePod
+			}
+		case cosmosv1.CosmosPruningPhaseRestorePod:
+			next[key] = cosmosv1.CosmosPruningPhaseConfirmPodRestoration
+		case cosmosv1.CosmosPruningPhaseConfirmPodRestoration:
+			if podExists {
+				next[key] = ""
 			}
 		}
 	}
 
-	if !existsPod {
-		return true, control.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
-			status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseRestorePod
-		})
+	if len(next) == 0 {
+		return nil, nil
 	}
 
-	if !existsCandidate {
-		return false, errors.New(NO_CANDIDATES_ERR)
+	now := metav1.NewTime(control.now())
+	return results, control.update(ctx, crd, func(status *cosmosv1.FullNodeStatus) {
+		pruningStatus := status.SelfHealing.CosmosPruningStatus
+		for key, state := range terminated {
+			podStatus := pruningStatus.PodPruningStatus[key]
+			podStatus.FinishedAt = ptr(now)
+			if !state.FinishedAt.IsZero() {
+				podStatus.FinishedAt = ptr(state.FinishedAt)
+			}
+			if !state.StartedAt.IsZero() && !state.FinishedAt.IsZero() {
+				podStatus.Duration = &metav1.Duration{Duration: state.FinishedAt.Sub(state.StartedAt.Time)}
+			}
+			podStatus.ExitCode = ptr(state.ExitCode)
+			podStatus.Logs = state.Message
+			pruningStatus.PodPruningStatus[key] = podStatus
+		}
+
+		candidates := pruningStatus.Candidates
+		for key, phase := range next {
+			candidate, ok := candidates[key]
+			if !ok {
+				continue
+			}
+			if phase == "" {
+				delete(candidates, key)
+				continue
+			}
+			candidate.Phase = phase
+			candidates[key] = candidate
+		}
+	})
+}
+
+func prunerTerminated(pod corev1.Pod) *corev1.ContainerStateTerminated {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return status.State.Terminated
+		}
 	}
-	return false, nil
+	return nil
 }
 
-// SignalPodRestoration updates the LocalFullNodeRef's status to indicate it should recreate the pod candidate.
-// Any error returned can be treated as transient and retried.
-func (control FullNodeControl) SignalPodRestoration(ctx context.Context, crd *cosmosv1.CosmosFullNode) error {
-	var pruningStatus = crd.Status.SelfHealing.CosmosPruningStatus
+// ReclaimedBytes is the disk space reclaimed by pruning a pod.
+type ReclaimedBytes struct {
+	PodName string
+	Bytes   int64
+}
 
+// RecordUsageAfter records the disk usage of pods restored after pruning and the bytes reclaimed.
+// Only records usage once per prune. Returns the pods whose reclaimed bytes were recorded.
+func (control FullNodeControl) RecordUsageAfter(ctx context.Context, crd *cosmosv1.CosmosFullNode, usage []fullnode.PVCDiskUsage) ([]ReclaimedBytes, error) {
+	pruningStatus := crd.Status.SelfHealing.CosmosPruningStatus
 	if pruningStatus == nil {
-		return errors.New(NO_CANDIDATES_ERR)
+		return nil, nil
 	}
 
-	for _, candidate := range pruningStatus.Candidates {
-		key := control.sourceKey(candidate.PodName, candidate.Namespace)
-		objKey := client.ObjectKey{Name: crd.Name, Namespace: crd.Namespace}
-		return control.statusClient.SyncUpdate(ctx, objKey, func(status *cosmosv1.FullNodeStatus) {
-			status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseConfirmPodRestoration
-			delete(status.SelfHealing.CosmosPruningStatus.Candidates, key)
-		})
-	}
-	return errors.New(NO_CANDIDATES_ERR)
-}
-
-// ConfirmPodRestoration verifies the pod has been restored.
-func (control FullNodeControl) ConfirmPodRestoration(ctx context.Context, crd *cosmosv1.CosmosFullNode) error {
 	var (
-		existsCandidate bool
-		pods            corev1.PodList
+		after     = make(map[string]int64)
+		reclaimed []ReclaimedBytes
 	)
+	for _, u := range usage {
+		key := control.sourceKey(u.PodName, crd.Namespace)
+		if _, pruning := pruningStatus.Candidates[key]; pruning {
+			continue
+		}
+		podStatus, ok := pruningStatus.PodPruningStatus[key]
+		if !ok || podStatus.FinishedAt == nil || podStatus.UsedBytesAfter != nil {
+			continue
+		}
+		after[key] = u.UsedBytes
+		if podStatus.UsedBytesBefore != nil {
+			reclaimed = append(reclaimed, ReclaimedBytes{PodName: u.PodName, Bytes: *podStatus.UsedBytesBefore - u.UsedBytes})
+		}
+	}
 
-	if err := control.client.List(ctx, &pods,
-		client.InNamespace(crd.Namespace),
-		client.MatchingFields{kube.ControllerOwnerField: crd.Name}); err != nil {
-		return fmt.Errorf("list pods: %w", err)
+	if len(after) == 0 {
+		return nil, nil
 	}
 
-	for _, pod := range pods.Items {
-		if _, exists := crd.Status.SelfHealing.CosmosPruningStatus.Candidates[control.sourceKey(pod.Name, pod.Namespace)]; exists {
-			return fmt.Errorf("pod %s not restored yet", pod.Name)
+	return reclaimed, control.update(ctx, crd, func(status *cosmosv1.FullNodeStatus) {
+		pruningStatus := status.SelfHealing.CosmosPruningStatus
+		for key, used := range after {
+			podStatus, ok := pruningStatus.PodPruningStatus[key]
+			if !ok {
+				continue
+			}
+			podStatus.UsedBytesAfter = ptr(used)
+			if podStatus.UsedBytesBefore != nil {
+				podStatus.ReclaimedBytes = ptr(*podStatus.UsedBytesBefore - used)
+			}
+			pruningStatus.PodPruningStatus[key] = podStatus
 		}
-		existsCandidate = true
-	}
-	if !existsCandidate {
-		return errors.New(NO_CANDIDATES_ERR)
+	})
+}
+
+// update applies fn to both the crd's status and the latest status via the StatusSyncer, so callers may
+// continue to use the crd within the same reconcile.
+// Ensures status maps are initialized and updates the overall pruning phase.
+func (control FullNodeControl) update(ctx context.Context, crd *cosmosv1.CosmosFullNode, fn func(status *cosmosv1.FullNodeStatus)) error {
+	update := func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.CosmosPruningStatus == nil {
+			status.SelfHealing.CosmosPruningStatus = new(cosmosv1.CosmosPruningStatus)
+		}
+		pruningStatus := status.SelfHealing.CosmosPruningStatus
+		if pruningStatus.Candidates == nil {
+			pruningStatus.Candidates = make(map[string]cosmosv1.PruningCandidate)
+		}
+		if pruningStatus.PodPruningStatus == nil {
+			pruningStatus.PodPruningStatus = make(map[string]cosmosv1.PodPruningStatus)
+		}
+		fn(status)
+		pruningStatus.CosmosPruningPhase = OverallPhase(pruningStatus.Candidates)
 	}
+	update(&crd.Status)
+	return control.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), update)
+}
 
-	return control.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
-		status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase = cosmosv1.CosmosPruningPhaseFindingCandidate
-	})
+// OverallPhase returns FindingCandidate if there are no candidates, otherwise the phase of the least
+// advanced candidate.
+func OverallPhase(candidates map[string]cosmosv1.PruningCandidate) cosmosv1.CosmosPruningPhase {
+	if len(candidates) == 0 {
+		return cosmosv1.CosmosPruningPhaseFindingCandidate
+	}
+	least := len(phaseOrder) - 1
+	for _, c := range candidates {
+		if i := lo.IndexOf(phaseOrder, c.Phase); i >= 0 && i < least {
+			least = i
+		} else if c.Phase == "" {
+			least = 0
+		}
+	}
+	return phaseOrder[least]
 }
 
 func (control FullNodeControl) sourceKey(candidatePodName, namespace string) string {
+	return podKey(candidatePodName, namespace)
+}
+
+// podKey is the key of a pod within CosmosPruningStatus maps.
+func podKey(candidatePodName, namespace string) string {
 	key := strings.Join([]string{namespace, candidatePodName, cosmosv1.GroupVersion.Version, cosmosv1.GroupVersion.Group}, ".")
 	// Remove all slashes because key is used in JSONPatch where slash "/" is a reserved character.
 	return strings.ReplaceAll(key, "/", "")
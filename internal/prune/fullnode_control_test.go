@@ -3,9 +3,9 @@ package prune
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"testing"
+	"time"
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/stretchr/testify/require"
@@ -72,47 +72,45 @@ func TestFullNodeControl_SignalPodReplace(t *testing.T) {
 	crd.Name = "cosmoshub"
 
 	t.Run("happy path", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		now := time.Now()
+
+		var got cosmosv1.FullNodeStatus
 		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
 			require.Equal(t, "default/cosmoshub", key.String())
-
-			var got cosmosv1.FullNodeStatus
-
 			update(&got)
-
-			candidateKey := "default.cosmoshub-0.v1.cosmos.bharvest"
-
-			require.Equal(t,
-				cosmosv1.SelfHealingCandidate{
-					PodName:   "cosmoshub-0",
-					Namespace: "default",
-				},
-				got.SelfHealing.CosmosPruningStatus.Candidates[candidateKey])
-
 			return nil
 		})
 
-		reader := mockReader{
-			Getter: func(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-				return nil
-			},
-			Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-				return nil
-			},
+		control := NewFullNodeControl(syncer, nopReader)
+		control.now = func() time.Time { return now }
+		candidates := []*corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-0", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-1", Namespace: "default"}},
 		}
 
-		control := NewFullNodeControl(syncer, reader)
-		candidates := []*corev1.Pod{
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "cosmoshub-0",
-					Namespace: "default",
-				},
-			},
+		usage := []fullnode.PVCDiskUsage{
+			{Name: "pvc-cosmoshub-0", PodName: "cosmoshub-0", UsedBytes: 900},
 		}
 
-		err := control.SignalPodReplace(ctx, &crd, candidates)
+		err := control.SignalPodReplace(ctx, crd, candidates, usage)
 
 		require.NoError(t, err)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			pruningStatus := status.SelfHealing.CosmosPruningStatus
+			require.Len(t, pruningStatus.Candidates, 2)
+			require.Equal(t,
+				cosmosv1.PruningCandidate{
+					SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "cosmoshub-0", Namespace: "default"},
+					Phase:                cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+				},
+				pruningStatus.Candidates["default.cosmoshub-0.v1.cosmos.bharvest"])
+			require.Equal(t, now.Unix(), pruningStatus.PodPruningStatus["default.cosmoshub-1.v1.cosmos.bharvest"].LastPruneTime.Unix())
+			require.Equal(t, int64(900), *pruningStatus.PodPruningStatus["default.cosmoshub-0.v1.cosmos.bharvest"].UsedBytesBefore)
+			require.Nil(t, pruningStatus.PodPruningStatus["default.cosmoshub-1.v1.cosmos.bharvest"].UsedBytesBefore)
+			require.Equal(t, cosmosv1.CosmosPruningPhaseWaitingForPodReplaced, pruningStatus.CosmosPruningPhase)
+		}
 	})
 
 	t.Run("signal failed", func(t *testing.T) {
@@ -130,341 +128,340 @@ func TestFullNodeControl_SignalPodReplace(t *testing.T) {
 		}
 
 		control := NewFullNodeControl(syncer, nopReader)
-		err := control.SignalPodReplace(ctx, &crd, candidates)
+		err := control.SignalPodReplace(ctx, crd.DeepCopy(), candidates, nil)
 
 		require.Error(t, err)
 		require.EqualError(t, err, "boom")
 	})
 }
 
-func TestFullNodeControl_ConfirmPodReplaced(t *testing.T) {
+func TestFullNodeControl_AdvanceCandidates(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
 
-	var crd cosmosv1.CosmosFullNode
-	crd.Name = "cosmoshub"
-	crd.Namespace = "default"
+	candidate := func(podName string, phase cosmosv1.CosmosPruningPhase) (string, cosmosv1.PruningCandidate) {
+		return podKey(podName, "default"), cosmosv1.PruningCandidate{
+			SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: podName, Namespace: "default"},
+			Phase:                phase,
+		}
+	}
 
-	t.Run("happy path", func(t *testing.T) {
+	newCRD := func(phases map[string]cosmosv1.CosmosPruningPhase) *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Namespace = "default"
+		crd.Name = "cosmoshub"
+		crd.Status.SelfHealing.CosmosPruningStatus = &cosmosv1.CosmosPruningStatus{
+			Candidates: make(map[string]cosmosv1.PruningCandidate),
+		}
+		for podName, phase := range phases {
+			key, c := candidate(podName, phase)
+			crd.Status.SelfHealing.CosmosPruningStatus.Candidates[key] = c
+		}
+		return &crd
+	}
+
+	started := time.Date(2024, time.March, 1, 3, 0, 0, 0, time.UTC)
+	terminatedWith := func(exitCode int32) corev1.PodStatus {
+		return corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Terminated: ptr(corev1.ContainerStateTerminated{
+					ExitCode:   exitCode,
+					Message:    "pruning log tail",
+					StartedAt:  metav1.NewTime(started),
+					FinishedAt: metav1.NewTime(started.Add(90 * time.Second)),
+				})}},
+			},
+		}
+	}
+	terminated := terminatedWith(0)
+	running := corev1.PodStatus{
+		ContainerStatuses: []corev1.ContainerStatus{
+			{State: corev1.ContainerState{Running: ptr(corev1.ContainerStateRunning{})}},
+		},
+	}
+
+	t.Run("candidates progress independently", func(t *testing.T) {
+		crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+			"cosmoshub-0": cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+			"cosmoshub-1": cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+			"cosmoshub-2": cosmosv1.CosmosPruningPhaseWaitingForComplete,
+			"cosmoshub-3": cosmosv1.CosmosPruningPhaseWaitingForComplete,
+			"cosmoshub-4": cosmosv1.CosmosPruningPhaseRestorePod,
+			"cosmoshub-5": cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+			"cosmoshub-6": cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+		})
 
 		reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			var listOpt client.ListOptions
+			for _, opt := range opts {
+				opt.ApplyToList(&listOpt)
+			}
+			require.Equal(t, "default", listOpt.Namespace)
+			require.Equal(t, ".metadata.controller=cosmoshub", listOpt.FieldSelector.String())
+
 			list.(*corev1.PodList).Items = []corev1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: fullnode.GetPrunerPodName("cosmoshub-0"),
-					},
-				},
+				{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-0"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: fullnode.GetPrunerPodName("cosmoshub-1")}},
+				{ObjectMeta: metav1.ObjectMeta{Name: fullnode.GetPrunerPodName("cosmoshub-2")}, Status: terminated},
+				{ObjectMeta: metav1.ObjectMeta{Name: fullnode.GetPrunerPodName("cosmoshub-3")}, Status: running},
+				{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-5"}},
 			}
 			return nil
 		}}
 
-		fullNodeControl := NewFullNodeControl(nopStatusSyncer, reader)
-
-		podName := "cosmoshub-0"
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{
-				fullNodeControl.sourceKey(podName, crd.Namespace): {PodName: podName, Namespace: crd.Namespace},
-			},
+		var got cosmosv1.FullNodeStatus
+		got.SelfHealing.CosmosPruningStatus = crd.Status.SelfHealing.CosmosPruningStatus.DeepCopy()
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, "default/cosmoshub", key.String())
+			update(&got)
+			return nil
 		})
 
-		err := fullNodeControl.ConfirmPodReplaced(ctx, ptr(crd))
+		control := NewFullNodeControl(syncer, reader)
+		results, err := control.AdvanceCandidates(ctx, crd)
 
 		require.NoError(t, err)
-	})
-
-	t.Run("failed path", func(t *testing.T) {
-		podName := "cosmoshub-0"
+		require.Equal(t, []PruningResult{{PodName: "cosmoshub-2", Duration: 90 * time.Second}}, results)
 
-		reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			list.(*corev1.PodList).Items = []corev1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: podName,
-					},
-				},
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			pruningStatus := status.SelfHealing.CosmosPruningStatus
+			phases := make(map[string]cosmosv1.CosmosPruningPhase)
+			for _, c := range pruningStatus.Candidates {
+				phases[c.PodName] = c.Phase
 			}
-			return nil
-		}}
+			want := map[string]cosmosv1.CosmosPruningPhase{
+				"cosmoshub-0": cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+				"cosmoshub-1": cosmosv1.CosmosPruningPhaseWaitingForComplete,
+				"cosmoshub-2": cosmosv1.CosmosPruningPhaseRestorePod,
+				"cosmoshub-3": cosmosv1.CosmosPruningPhaseWaitingForComplete,
+				"cosmoshub-4": cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+				"cosmoshub-6": cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+			}
+			require.Equal(t, want, phases)
+			require.Equal(t, cosmosv1.CosmosPruningPhaseWaitingForPodReplaced, pruningStatus.CosmosPruningPhase)
+
+			podStatus := pruningStatus.PodPruningStatus[podKey("cosmoshub-2", "default")]
+			require.Equal(t, started.Add(90*time.Second), podStatus.FinishedAt.Time.UTC())
+			require.Equal(t, 90*time.Second, podStatus.Duration.Duration)
+			require.Equal(t, int32(0), *podStatus.ExitCode)
+			require.Equal(t, "pruning log tail", podStatus.Logs)
+		}
+	})
 
-		fullNodeControl := NewFullNodeControl(nopStatusSyncer, reader)
+	t.Run("pruning failed", func(t *testing.T) {
+		for _, tt := range []struct {
+			RestoreOnFailure bool
+			WantPhase        cosmosv1.CosmosPruningPhase
+		}{
+			{false, cosmosv1.CosmosPruningPhasePruningFailed},
+			{true, cosmosv1.CosmosPruningPhaseRestorePod},
+		} {
+			crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+				"cosmoshub-0": cosmosv1.CosmosPruningPhaseWaitingForComplete,
+			})
+			crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+				PruningSpec: &cosmosv1.PruningSpec{RestoreOnFailure: tt.RestoreOnFailure},
+			}
+			reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				list.(*corev1.PodList).Items = []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: fullnode.GetPrunerPodName("cosmoshub-0")}, Status: terminatedWith(2)},
+				}
+				return nil
+			}}
 
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{
-				fullNodeControl.sourceKey(podName, crd.Namespace): {PodName: podName, Namespace: crd.Namespace},
-			},
-		})
+			control := NewFullNodeControl(nopStatusSyncer, reader)
+			results, err := control.AdvanceCandidates(ctx, crd)
 
-		err := fullNodeControl.ConfirmPodReplaced(ctx, ptr(crd))
+			require.NoError(t, err, tt)
+			require.Len(t, results, 1, tt)
+			require.False(t, results[0].Succeeded(), tt)
+			require.Equal(t, int32(2), results[0].ExitCode, tt)
 
-		require.Error(t, err, fmt.Sprintf("pod %s not replaced yet", podName))
+			pruningStatus := crd.Status.SelfHealing.CosmosPruningStatus
+			require.Equal(t, tt.WantPhase, pruningStatus.Candidates[podKey("cosmoshub-0", "default")].Phase, tt)
+			require.Equal(t, int32(2), *pruningStatus.PodPruningStatus[podKey("cosmoshub-0", "default")].ExitCode, tt)
+		}
 	})
 
-	t.Run("empty candidates path", func(t *testing.T) {
-		podName := "cosmoshub-0"
-
-		reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			list.(*corev1.PodList).Items = []corev1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: podName,
-					},
-				},
-			}
-			return nil
-		}}
-
-		fullNodeControl := NewFullNodeControl(nopStatusSyncer, reader)
-
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{},
+	t.Run("restore after failure", func(t *testing.T) {
+		crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+			"cosmoshub-0": cosmosv1.CosmosPruningPhasePruningFailed,
 		})
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{PruningSpec: &cosmosv1.PruningSpec{}}
 
-		err := fullNodeControl.ConfirmPodReplaced(ctx, ptr(crd))
+		control := NewFullNodeControl(nopStatusSyncer, nopReader)
+		_, err := control.AdvanceCandidates(ctx, crd)
+		require.NoError(t, err)
+		require.Equal(t, cosmosv1.CosmosPruningPhasePruningFailed, crd.Status.SelfHealing.CosmosPruningStatus.Candidates[podKey("cosmoshub-0", "default")].Phase)
 
-		require.Error(t, err, errors.New(NO_CANDIDATES_ERR))
+		crd.Spec.SelfHeal.PruningSpec.RestoreOnFailure = true
+		_, err = control.AdvanceCandidates(ctx, crd)
+		require.NoError(t, err)
+		require.Equal(t, cosmosv1.CosmosPruningPhaseRestorePod, crd.Status.SelfHealing.CosmosPruningStatus.Candidates[podKey("cosmoshub-0", "default")].Phase)
 	})
 
-	t.Run("get error", func(t *testing.T) {
-		var reader mockReader
-		reader.Lister = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			return errors.New("boom")
-		}
+	t.Run("all finished", func(t *testing.T) {
+		crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+			"cosmoshub-0": cosmosv1.CosmosPruningPhaseConfirmPodRestoration,
+		})
+		reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*corev1.PodList).Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-0"}}}
+			return nil
+		}}
 
 		control := NewFullNodeControl(nopStatusSyncer, reader)
-		err := control.ConfirmPodReplaced(ctx, &crd)
+		results, err := control.AdvanceCandidates(ctx, crd)
 
-		require.Error(t, err)
-		require.EqualError(t, err, "list pods: boom")
+		require.NoError(t, err)
+		require.Empty(t, results)
+		require.Empty(t, crd.Status.SelfHealing.CosmosPruningStatus.Candidates)
+		require.Equal(t, cosmosv1.CosmosPruningPhaseFindingCandidate, crd.Status.SelfHealing.CosmosPruningStatus.CosmosPruningPhase)
 	})
-}
-
-func TestFullNodeControl_CheckPruningComplete(t *testing.T) {
-	t.Parallel()
-
-	ctx := context.Background()
-
-	var crd cosmosv1.CosmosFullNode
-	crd.Namespace = "default"
-	crd.Name = "cosmoshub"
-	candidatePodName := "cosmoshub-0"
 
-	t.Run("happy path", func(t *testing.T) {
-
-		read := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			list.(*corev1.PodList).Items = []corev1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: fullnode.GetPrunerPodName(candidatePodName),
-					},
-					Status: corev1.PodStatus{
-						ContainerStatuses: []corev1.ContainerStatus{
-							{
-								State: corev1.ContainerState{
-									Terminated: ptr(corev1.ContainerStateTerminated{}),
-								},
-							},
-						},
-					},
-				},
-			}
+	t.Run("no changes", func(t *testing.T) {
+		crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+			"cosmoshub-0": cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
+		})
+		reader := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*corev1.PodList).Items = []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "cosmoshub-0"}}}
 			return nil
 		}}
-
-		fullNodeControl := NewFullNodeControl(nopStatusSyncer, read)
-
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{
-				fullNodeControl.sourceKey(candidatePodName, crd.Namespace): {
-					PodName:   candidatePodName,
-					Namespace: crd.Namespace,
-				},
-			},
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
 		})
 
-		ok, err := fullNodeControl.CheckPruningComplete(ctx, ptr(crd))
-		require.Equal(t, true, ok)
+		control := NewFullNodeControl(syncer, reader)
+		results, err := control.AdvanceCandidates(ctx, crd)
 
 		require.NoError(t, err)
+		require.Empty(t, results)
 	})
 
-	t.Run("failed path", func(t *testing.T) {
+	t.Run("no candidates", func(t *testing.T) {
+		control := NewFullNodeControl(nopStatusSyncer, mockReader{})
+		results, err := control.AdvanceCandidates(ctx, newCRD(nil))
 
-		read := mockReader{Lister: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			list.(*corev1.PodList).Items = []corev1.Pod{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      fullnode.GetPrunerPodName(candidatePodName),
-						Namespace: "default",
-					},
-					Spec: corev1.PodSpec{
-						Volumes: []corev1.Volume{
-							{
-								Name: "vol-chain-home",
-								VolumeSource: corev1.VolumeSource{
-									PersistentVolumeClaim: ptr(corev1.PersistentVolumeClaimVolumeSource{
-										ClaimName: "pvc-cosmoshub",
-									}),
-								},
-							},
-						},
-					},
-					Status: corev1.PodStatus{
-						ContainerStatuses: []corev1.ContainerStatus{
-							{
-								State: corev1.ContainerState{
-									Waiting:    nil,
-									Running:    nil,
-									Terminated: nil,
-								},
-							},
-						},
-					},
-				},
-			}
-
-			return nil
-		}}
-
-		fullNodeControl := NewFullNodeControl(nopStatusSyncer, read)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
 
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{
-				fullNodeControl.sourceKey(candidatePodName, crd.Namespace): {
-					PodName:   candidatePodName,
-					Namespace: crd.Namespace,
-				},
-			},
+	t.Run("list error", func(t *testing.T) {
+		crd := newCRD(map[string]cosmosv1.CosmosPruningPhase{
+			"cosmoshub-0": cosmosv1.CosmosPruningPhaseWaitingForPodReplaced,
 		})
+		var reader mockReader
+		reader.Lister = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			return errors.New("boom")
+		}
 
-		ok, err := fullNodeControl.CheckPruningComplete(ctx, &crd)
-
-		require.NoError(t, err)
-		require.Equal(t, false, ok)
+		control := NewFullNodeControl(nopStatusSyncer, reader)
+		_, err := control.AdvanceCandidates(ctx, crd)
 
+		require.Error(t, err)
+		require.EqualError(t, err, "list pods: boom")
 	})
-
 }
 
-func TestFullNodeControl_SignalPodRestoration(t *testing.T) {
+func TestFullNodeControl_RecordUsageAfter(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	now := metav1.Now()
+
+	newCRD := func() *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Namespace = "default"
+		crd.Name = "cosmoshub"
+		crd.Status.SelfHealing.CosmosPruningStatus = &cosmosv1.CosmosPruningStatus{
+			Candidates: map[string]cosmosv1.PruningCandidate{
+				podKey("cosmoshub-1", "default"): {SelfHealingCandidate: cosmosv1.SelfHealingCandidate{PodName: "cosmoshub-1"}},
+			},
+			PodPruningStatus: map[string]cosmosv1.PodPruningStatus{
+				// Restored after pruning.
+				podKey("cosmoshub-0", "default"): {LastPruneTime: &now, FinishedAt: &now, UsedBytesBefore: ptr(int64(1000))},
+				// Still pruning.
+				podKey("cosmoshub-1", "default"): {LastPruneTime: &now, FinishedAt: &now, UsedBytesBefore: ptr(int64(1000))},
+				// Already recorded.
+				podKey("cosmoshub-2", "default"): {LastPruneTime: &now, FinishedAt: &now, UsedBytesBefore: ptr(int64(1000)), UsedBytesAfter: ptr(int64(500))},
+				// Pruner did not finish.
+				podKey("cosmoshub-3", "default"): {LastPruneTime: &now},
+			},
+		}
+		return &crd
+	}
 
-	var crd cosmosv1.CosmosFullNode
-	crd.Namespace = "default"
-	crd.Name = "cosmoshub"
+	usage := []fullnode.PVCDiskUsage{
+		{PodName: "cosmoshub-0", UsedBytes: 400},
+		{PodName: "cosmoshub-1", UsedBytes: 400},
+		{PodName: "cosmoshub-2", UsedBytes: 400},
+		{PodName: "cosmoshub-3", UsedBytes: 400},
+	}
 
 	t.Run("happy path", func(t *testing.T) {
+		crd := newCRD()
+		var got cosmosv1.FullNodeStatus
+		got.SelfHealing.CosmosPruningStatus = crd.Status.SelfHealing.CosmosPruningStatus.DeepCopy()
 		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
-			require.Equal(t, "default/cosmoshub", key.String())
-
-			var got cosmosv1.FullNodeStatus
-
 			update(&got)
-
-			candidateKey := "default.cosmoshub-0.v1.cosmos.bharvest"
-
-			require.NotNil(t, got.SelfHealing.CosmosPruningStatus.Candidates[candidateKey])
-
 			return nil
 		})
 
 		control := NewFullNodeControl(syncer, nopReader)
-		candidates := []*corev1.Pod{
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "cosmoshub-0",
-					Namespace: "default",
-				},
-			},
-		}
-
-		err := control.SignalPodReplace(ctx, &crd, candidates)
+		reclaimed, err := control.RecordUsageAfter(ctx, crd, usage)
 
 		require.NoError(t, err)
+		require.Equal(t, []ReclaimedBytes{{PodName: "cosmoshub-0", Bytes: 600}}, reclaimed)
+
+		for _, status := range []cosmosv1.FullNodeStatus{got, crd.Status} {
+			podStatus := status.SelfHealing.CosmosPruningStatus.PodPruningStatus
+			require.Equal(t, int64(400), *podStatus[podKey("cosmoshub-0", "default")].UsedBytesAfter)
+			require.Equal(t, int64(600), *podStatus[podKey("cosmoshub-0", "default")].ReclaimedBytes)
+			require.Nil(t, podStatus[podKey("cosmoshub-1", "default")].UsedBytesAfter)
+			require.Equal(t, int64(500), *podStatus[podKey("cosmoshub-2", "default")].UsedBytesAfter)
+			require.Nil(t, podStatus[podKey("cosmoshub-3", "default")].UsedBytesAfter)
+		}
 	})
 
-	t.Run("candidates failed", func(t *testing.T) {
+	t.Run("nothing to record", func(t *testing.T) {
+		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		})
 
-		control := NewFullNodeControl(nopStatusSyncer, nopReader)
-		err := control.SignalPodRestoration(ctx, &crd)
+		control := NewFullNodeControl(syncer, nopReader)
+		reclaimed, err := control.RecordUsageAfter(ctx, newCRD(), usage[1:])
 
-		require.Error(t, err)
-		require.EqualError(t, err, NO_CANDIDATES_ERR)
+		require.NoError(t, err)
+		require.Empty(t, reclaimed)
+
+		reclaimed, err = control.RecordUsageAfter(ctx, &cosmosv1.CosmosFullNode{}, usage)
+		require.NoError(t, err)
+		require.Empty(t, reclaimed)
 	})
 
-	t.Run("signal failed", func(t *testing.T) {
+	t.Run("update error", func(t *testing.T) {
 		syncer := mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
 			return errors.New("boom")
 		})
 
-		podName := "cosmoshub-0"
-		candidateName := "default." + crd.Name + "-0.v1.cosmos.bharvest"
-
-		crd.Status.SelfHealing.CosmosPruningStatus = ptr(cosmosv1.CosmosPruningStatus{
-			Candidates: map[string]cosmosv1.SelfHealingCandidate{
-				candidateName: {
-					PodName:   podName,
-					Namespace: "default",
-				},
-			},
-		})
-
 		control := NewFullNodeControl(syncer, nopReader)
-		err := control.SignalPodRestoration(ctx, &crd)
+		_, err := control.RecordUsageAfter(ctx, newCRD(), usage)
 
-		require.Error(t, err)
 		require.EqualError(t, err, "boom")
 	})
 }
 
-func TestFullNodeControl_ConfirmPodRestoration(t *testing.T) {
+func TestOverallPhase(t *testing.T) {
 	t.Parallel()
 
-	ctx := context.Background()
-
-	var crd cosmosv1.CosmosFullNode
-	crd.Namespace = "default"
-	crd.Name = "cosmoshub"
-	crd.Status.SelfHealing.CosmosPruningStatus = new(cosmosv1.CosmosPruningStatus)
-	crd.Status.SelfHealing.CosmosPruningStatus.Candidates = make(map[string]cosmosv1.SelfHealingCandidate)
+	require.Equal(t, cosmosv1.CosmosPruningPhaseFindingCandidate, OverallPhase(nil))
 
-	t.Run("happy path", func(t *testing.T) {
-		var reader mockReader
-
-		candidateKey := "default." + crd.Name + "-0.v1.cosmos.bharvest"
-		crd.Status.SelfHealing.CosmosPruningStatus.Candidates[candidateKey] = cosmosv1.SelfHealingCandidate{PodName: fullnode.GetPrunerPodName(crd.Name + "-0"), Namespace: crd.Namespace}
-
-		reader.Lister = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
-			list.(*corev1.PodList).Items = []corev1.Pod{
-				{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}},
-			}
-
-			require.Len(t, opts, 2)
-			var listOpt client.ListOptions
-			for _, opt := range opts {
-				opt.ApplyToList(&listOpt)
-			}
-			require.Equal(t, "default", listOpt.Namespace)
-			require.Zero(t, listOpt.Limit)
-			require.Equal(t, ".metadata.controller=cosmoshub", listOpt.FieldSelector.String())
-			return nil
-		}
-
-		control := NewFullNodeControl(nopStatusSyncer, reader)
-
-		err := control.ConfirmPodRestoration(ctx, &crd)
-		require.NoError(t, err)
-	})
-
-	t.Run("happy path - no items", func(t *testing.T) {
-		control := NewFullNodeControl(nopStatusSyncer, nopReader)
-		err := control.ConfirmPodRestoration(ctx, &crd)
-
-		require.Error(t, err)
-		require.EqualError(t, err, NO_CANDIDATES_ERR)
-	})
+	candidates := map[string]cosmosv1.PruningCandidate{
+		"a": {Phase: cosmosv1.CosmosPruningPhaseConfirmPodRestoration},
+		"b": {Phase: cosmosv1.CosmosPruningPhaseWaitingForComplete},
+	}
+	require.Equal(t, cosmosv1.CosmosPruningPhaseWaitingForComplete, OverallPhase(candidates))
 
+	candidates["c"] = cosmosv1.PruningCandidate{}
+	require.Equal(t, cosmosv1.CosmosPruningPhaseWaitingForPodReplaced, OverallPhase(candidates))
 }
@@ -2,11 +2,14 @@ package prune
 
 import (
 	"context"
+	"sort"
+	"time"
+
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"time"
 )
 
 type CandidateCollector interface {
@@ -15,19 +18,39 @@ type CandidateCollector interface {
 
 type Pruner struct {
 	candidateCollector CandidateCollector
+	now                func() time.Time
 }
 
 func NewPruner(candidateCollector CandidateCollector) *Pruner {
 	return &Pruner{
 		candidateCollector: candidateCollector,
+		now:                time.Now,
 	}
 }
 
-func (p *Pruner) FindCandidate(ctx context.Context, crd *cosmosv1.CosmosFullNode, results []fullnode.PVCDiskUsage) *corev1.Pod {
+// FindCandidates returns pods to prune, or nil if no pod should be pruned now.
+// A pod is a candidate if its PVC exceeds the used space percentage, in bytes or inodes, or the pruning schedule is due.
+// Candidates must be within a maintenance window and outside the minimum interval since their last prune.
+// The number of candidates is bounded by maxConcurrent less the pods already pruning, and by the number of synced
+// pods less minAvailable.
+// A non-nil error indicates an invalid spec.
+func (p *Pruner) FindCandidates(ctx context.Context, crd *cosmosv1.CosmosFullNode, results []fullnode.PVCDiskUsage) ([]*corev1.Pod, error) {
 	var spec = crd.Spec.SelfHeal.PruningSpec
 	if spec == nil {
 		// Pruning not work
-		return nil
+		return nil, nil
+	}
+
+	// Without a valid strategy, candidates would be replaced by pruner pods that can never be built.
+	if spec.PruningCommand == "" {
+		if _, err := fullnode.NewPruningStrategy(crd); err != nil {
+			return nil, err
+		}
+	}
+
+	now := p.now()
+	if ok, err := InMaintenanceWindow(spec, now); !ok || err != nil {
+		return nil, err
 	}
 
 	var trigger = int(spec.UsedSpacePercentage)
@@ -42,32 +65,76 @@ func (p *Pruner) FindCandidate(ctx context.Context, crd *cosmosv1.CosmosFullNode
 	defer cancel()
 
 	var (
-		synced     = p.candidateCollector.SyncedPods(cctx, client.ObjectKey{Namespace: crd.Namespace, Name: crd.Name})
-		availCount = int32(len(synced))
-		minAvail   = spec.MinAvailable
+		synced        = p.candidateCollector.SyncedPods(cctx, client.ObjectKey{Namespace: crd.Namespace, Name: crd.Name})
+		availCount    = int32(len(synced))
+		minAvail      = spec.MinAvailable
+		maxConcurrent = spec.MaxConcurrent
 	)
 
 	if minAvail <= 0 {
 		minAvail = 2
 	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
 
-	if availCount <= minAvail {
-		return nil
+	budget := lo.Min([]int32{maxConcurrent - int32(len(status.Candidates)), availCount - minAvail})
+	if budget <= 0 {
+		return nil, nil
 	}
 
-	for _, pvc := range results {
-		if pvc.PercentUsed < trigger {
-			// no need to prune
-			continue
+	lastPruned := func(pod *corev1.Pod) time.Time {
+		if t := status.PodPruningStatus[podKey(pod.Name, pod.Namespace)].LastPruneTime; t != nil {
+			return t.Time
+		}
+		return time.Time{}
+	}
+	synced = lo.Filter(synced, func(pod *corev1.Pod, _ int) bool {
+		if _, pruning := status.Candidates[podKey(pod.Name, pod.Namespace)]; pruning {
+			return false
 		}
+		return !PrunedWithinInterval(spec, lastPruned(pod), now)
+	})
 
-		// Finding candidate
-		for _, pod := range synced {
-			if fullnode.PVCName(pod) != pvc.Name {
+	var candidates []*corev1.Pod
+	if trigger > 0 {
+		for _, pvc := range results {
+			if pvc.PercentUsed < trigger && pvc.PercentInodesUsed < trigger {
+				// no need to prune
 				continue
 			}
-			return pod
+
+			// Finding candidate
+			for _, pod := range synced {
+				if fullnode.PVCName(pod) != pvc.Name {
+					continue
+				}
+				candidates = append(candidates, pod)
+			}
+		}
+	}
+
+	// Least recently pruned first, so scheduled pruning rotates predictably across instances.
+	sort.SliceStable(synced, func(i, j int) bool {
+		return lastPruned(synced[i]).Before(lastPruned(synced[j]))
+	})
+	for _, pod := range synced {
+		ref := lastPruned(pod)
+		if ref.IsZero() {
+			ref = crd.CreationTimestamp.Time
+		}
+		due, err := ScheduleDue(spec, ref, now)
+		if err != nil {
+			return nil, err
+		}
+		if due {
+			candidates = append(candidates, pod)
 		}
 	}
-	return nil
+
+	candidates = lo.Uniq(candidates)
+	if int32(len(candidates)) > budget {
+		candidates = candidates[:budget]
+	}
+	return candidates, nil
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"testing"
+	"time"
 )
 
 type MockCandidateCollector func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod
@@ -25,7 +27,17 @@ var nopCollector = MockCandidateCollector(func(ctx context.Context, controller c
 	return nil
 })
 
-func TestPruneControl_FindCandidate(t *testing.T) {
+// findOne returns the only candidate, or nil if there are none.
+func findOne(t *testing.T, pruner *Pruner, crd *cosmosv1.CosmosFullNode, results []fullnode.PVCDiskUsage) (*corev1.Pod, error) {
+	pods, err := pruner.FindCandidates(context.Background(), crd, results)
+	if len(pods) == 0 {
+		return nil, err
+	}
+	require.Len(t, pods, 1)
+	return pods[0], err
+}
+
+func TestPruneControl_FindCandidates(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 
@@ -97,9 +109,19 @@ func TestPruneControl_FindCandidate(t *testing.T) {
 
 		pruner := NewPruner(cacheController)
 
-		pod := pruner.FindCandidate(ctx, ptr(crd), defaultResults)
+		pod, err := findOne(t, pruner, ptr(crd), defaultResults)
 
+		require.NoError(t, err)
 		require.Equal(t, "cosmoshub-1", pod.Name)
+
+		// Running out of inodes also triggers pruning.
+		pod, err = findOne(t, pruner, ptr(crd), []fullnode.PVCDiskUsage{
+			{Name: "pvc-cosmoshub-0", PercentUsed: 10, PercentInodesUsed: 95},
+			{Name: "pvc-cosmoshub-1", PercentUsed: 10, PercentInodesUsed: 20},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "cosmoshub-0", pod.Name)
 	})
 
 	t.Run("find failed", func(t *testing.T) {
@@ -137,9 +159,193 @@ func TestPruneControl_FindCandidate(t *testing.T) {
 
 		pruner := NewPruner(cacheController)
 
-		pod := pruner.FindCandidate(ctx, ptr(crd), noExceededDiskUsages)
+		pod, err := findOne(t, pruner, ptr(crd), noExceededDiskUsages)
+
+		require.NoError(t, err)
+		require.Nil(t, pod)
+	})
+
+	syncedPods := func(names ...string) MockCandidateCollector {
+		return func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod {
+			var pods []*corev1.Pod
+			for _, name := range names {
+				pods = append(pods, &corev1.Pod{
+					ObjectMeta: v1.ObjectMeta{Name: name},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{{
+							Name: "vol-chain-home",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: ptr(corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-" + name}),
+							},
+						}},
+					},
+				})
+			}
+			return pods
+		}
+	}
+
+	now := time.Date(2024, time.March, 10, 3, 30, 0, 0, time.UTC)
+	pruned := func(crd *cosmosv1.CosmosFullNode, name string, at time.Time) {
+		if crd.Status.SelfHealing.CosmosPruningStatus == nil {
+			crd.Status.SelfHealing.CosmosPruningStatus = &cosmosv1.CosmosPruningStatus{
+				PodPruningStatus: make(map[string]cosmosv1.PodPruningStatus),
+			}
+		}
+		crd.Status.SelfHealing.CosmosPruningStatus.PodPruningStatus[podKey(name, "")] = cosmosv1.PodPruningStatus{
+			LastPruneTime: ptr(v1.NewTime(at)),
+		}
+	}
+
+	t.Run("min interval", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.SelfHeal.PruningSpec.MinInterval = &v1.Duration{Duration: 24 * time.Hour}
+		pruned(crd, "cosmoshub-1", now.Add(-time.Hour))
+
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2"))
+		pruner.now = func() time.Time { return now }
+
+		pod, err := findOne(t, pruner, crd, defaultResults)
+
+		require.NoError(t, err)
+		require.Nil(t, pod)
+
+		pruned(crd, "cosmoshub-1", now.Add(-25*time.Hour))
+		pod, err = findOne(t, pruner, crd, defaultResults)
+
+		require.NoError(t, err)
+		require.Equal(t, "cosmoshub-1", pod.Name)
+	})
+
+	t.Run("schedule", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.CreationTimestamp = v1.NewTime(now.Add(-30 * 24 * time.Hour))
+		crd.Spec.SelfHeal.PruningSpec.UsedSpacePercentage = 0
+		crd.Spec.SelfHeal.PruningSpec.Schedule = "0 3 * * *"
+		pruned(crd, "cosmoshub-0", now.Add(-20*time.Minute))
+		pruned(crd, "cosmoshub-1", now.Add(-48*time.Hour))
+
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2"))
+		pruner.now = func() time.Time { return now }
+
+		// Never pruned is least recent.
+		pod, err := findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
+		require.Equal(t, "cosmoshub-2", pod.Name)
+
+		pruned(crd, "cosmoshub-2", now.Add(-10*time.Minute))
+		pod, err = findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
+		require.Equal(t, "cosmoshub-1", pod.Name)
+
+		pruned(crd, "cosmoshub-1", now.Add(-5*time.Minute))
+		pod, err = findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
+		require.Nil(t, pod)
+	})
+
+	t.Run("maintenance window", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.SelfHeal.PruningSpec.MaintenanceWindows = []cosmosv1.PruningWindow{
+			{Start: "0 2 * * *", Duration: v1.Duration{Duration: time.Hour}},
+		}
 
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1"))
+		pruner.now = func() time.Time { return now }
+
+		pod, err := findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
 		require.Nil(t, pod)
+
+		pruner.now = func() time.Time { return now.Add(-time.Hour) }
+		pod, err = findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
+		require.Equal(t, "cosmoshub-1", pod.Name)
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.SelfHeal.PruningSpec.UsedSpacePercentage = 0
+		crd.Spec.SelfHeal.PruningSpec.Schedule = "bogus"
+
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1"))
+
+		_, err := findOne(t, pruner, crd, defaultResults)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid schedule")
+	})
+
+	t.Run("unsupported strategy", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.ChainSpec.ChainType = "namada"
+		crd.Spec.SelfHeal.PruningSpec.Strategy = cosmosv1.PrunerStrategyPruneAppState
+
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1"))
+
+		_, err := findOne(t, pruner, crd, defaultResults)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported")
+
+		// A custom command bypasses the strategy.
+		crd.Spec.SelfHeal.PruningSpec.PruningCommand = "custom-prune"
+		_, err = findOne(t, pruner, crd, defaultResults)
+		require.NoError(t, err)
 	})
 
+	t.Run("budget", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.SelfHeal.PruningSpec.MaxConcurrent = 3
+		crd.Spec.SelfHeal.PruningSpec.MinAvailable = 2
+		crd.Status.SelfHealing.CosmosPruningStatus = &cosmosv1.CosmosPruningStatus{
+			Candidates: map[string]cosmosv1.PruningCandidate{
+				podKey("cosmoshub-9", ""): {},
+			},
+		}
+		usage := []fullnode.PVCDiskUsage{
+			{Name: "pvc-cosmoshub-0", PercentUsed: 90},
+			{Name: "pvc-cosmoshub-1", PercentUsed: 90},
+			{Name: "pvc-cosmoshub-2", PercentUsed: 90},
+			{Name: "pvc-cosmoshub-3", PercentUsed: 90},
+			{Name: "pvc-cosmoshub-4", PercentUsed: 10},
+		}
+
+		// Bounded by maxConcurrent less the pod already pruning.
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2", "cosmoshub-3", "cosmoshub-4", "cosmoshub-5"))
+		pods, err := pruner.FindCandidates(ctx, crd, usage)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"cosmoshub-0", "cosmoshub-1"}, lo.Map(pods, func(p *corev1.Pod, _ int) string { return p.Name }))
+
+		// Bounded by synced pods less minAvailable.
+		pruner = NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2"))
+		pods, err = pruner.FindCandidates(ctx, crd, usage)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"cosmoshub-0"}, lo.Map(pods, func(p *corev1.Pod, _ int) string { return p.Name }))
+
+		// No budget left.
+		crd.Spec.SelfHeal.PruningSpec.MaxConcurrent = 1
+		pruner = NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2", "cosmoshub-3"))
+		pods, err = pruner.FindCandidates(ctx, crd, usage)
+
+		require.NoError(t, err)
+		require.Empty(t, pods)
+	})
+
+	t.Run("excludes pods already pruning", func(t *testing.T) {
+		crd := crd.DeepCopy()
+		crd.Spec.SelfHeal.PruningSpec.MaxConcurrent = 2
+		crd.Status.SelfHealing.CosmosPruningStatus = &cosmosv1.CosmosPruningStatus{
+			Candidates: map[string]cosmosv1.PruningCandidate{
+				// Restored pods may be synced before the candidate is removed.
+				podKey("cosmoshub-1", ""): {Phase: cosmosv1.CosmosPruningPhaseConfirmPodRestoration},
+			},
+		}
+
+		pruner := NewPruner(syncedPods("cosmoshub-0", "cosmoshub-1", "cosmoshub-2"))
+		pods, err := pruner.FindCandidates(ctx, crd, defaultResults)
+
+		require.NoError(t, err)
+		require.Empty(t, pods)
+	})
 }
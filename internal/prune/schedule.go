@@ -0,0 +1,50 @@
+package prune
+
+import (
+	"fmt"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/robfig/cron/v3"
+)
+
+// InMaintenanceWindow returns true if pruning may start at now.
+// Always true if the spec has no maintenance windows.
+func InMaintenanceWindow(spec *cosmosv1.PruningSpec, now time.Time) (bool, error) {
+	if len(spec.MaintenanceWindows) == 0 {
+		return true, nil
+	}
+	now = now.UTC()
+	for i, window := range spec.MaintenanceWindows {
+		sched, err := cron.ParseStandard(window.Start)
+		if err != nil {
+			return false, fmt.Errorf("invalid maintenanceWindows[%d].start: %w", i, err)
+		}
+		// The window is open if it started within the last duration.
+		if !sched.Next(now.Add(-window.Duration.Duration)).After(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ScheduleDue returns true if the spec's schedule fired after lastPruned.
+// Always false if the spec has no schedule.
+func ScheduleDue(spec *cosmosv1.PruningSpec, lastPruned, now time.Time) (bool, error) {
+	if spec.Schedule == "" {
+		return false, nil
+	}
+	sched, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule: %w", err)
+	}
+	return !sched.Next(lastPruned.UTC()).After(now.UTC()), nil
+}
+
+// PrunedWithinInterval returns true if the instance was pruned more recently than the spec's minimum interval.
+func PrunedWithinInterval(spec *cosmosv1.PruningSpec, lastPruned, now time.Time) bool {
+	if spec.MinInterval == nil || lastPruned.IsZero() {
+		return false
+	}
+	return now.Sub(lastPruned) < spec.MinInterval.Duration
+}
@@ -0,0 +1,98 @@
+package prune
+
+import (
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	t.Parallel()
+
+	var spec cosmosv1.PruningSpec
+	ok, err := InMaintenanceWindow(&spec, time.Now())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	spec.MaintenanceWindows = []cosmosv1.PruningWindow{
+		{Start: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		{Start: "30 12 * * 6", Duration: metav1.Duration{Duration: 30 * time.Minute}},
+	}
+
+	// A Saturday.
+	day := time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		At   time.Duration
+		Want bool
+	}{
+		{time.Hour, false},
+		{2 * time.Hour, true},
+		{3*time.Hour + 59*time.Minute, true},
+		{4 * time.Hour, false},
+		{12*time.Hour + 45*time.Minute, true},
+		{13*time.Hour + time.Minute, false},
+		{24*time.Hour + 12*time.Hour + 45*time.Minute, false}, // Sunday
+	} {
+		got, err := InMaintenanceWindow(&spec, day.Add(tt.At))
+
+		require.NoError(t, err)
+		require.Equal(t, tt.Want, got, tt)
+	}
+
+	// Evaluated in UTC regardless of the time's location.
+	seoul := time.FixedZone("KST", 9*60*60)
+	got, err := InMaintenanceWindow(&spec, day.Add(3*time.Hour).In(seoul))
+	require.NoError(t, err)
+	require.True(t, got)
+
+	spec.MaintenanceWindows = []cosmosv1.PruningWindow{{Start: "bogus"}}
+	_, err = InMaintenanceWindow(&spec, day)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maintenanceWindows[0].start")
+}
+
+func TestScheduleDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 10, 3, 30, 0, 0, time.UTC)
+
+	var spec cosmosv1.PruningSpec
+	got, err := ScheduleDue(&spec, time.Time{}, now)
+	require.NoError(t, err)
+	require.False(t, got)
+
+	spec.Schedule = "0 3 * * *"
+	for _, tt := range []struct {
+		LastPruned time.Time
+		Want       bool
+	}{
+		{now.Add(-time.Hour), true},
+		{now.Add(-29 * time.Minute), false},
+		{now.Add(-24 * time.Hour), true},
+	} {
+		got, err = ScheduleDue(&spec, tt.LastPruned, now)
+
+		require.NoError(t, err)
+		require.Equal(t, tt.Want, got, tt)
+	}
+
+	spec.Schedule = "nope"
+	_, err = ScheduleDue(&spec, now, now)
+	require.Error(t, err)
+}
+
+func TestPrunedWithinInterval(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	var spec cosmosv1.PruningSpec
+	require.False(t, PrunedWithinInterval(&spec, now, now))
+
+	spec.MinInterval = &metav1.Duration{Duration: time.Hour}
+	require.False(t, PrunedWithinInterval(&spec, time.Time{}, now))
+	require.True(t, PrunedWithinInterval(&spec, now.Add(-59*time.Minute), now))
+	require.False(t, PrunedWithinInterval(&spec, now.Add(-time.Hour), now))
+}
@@ -0,0 +1,214 @@
+package volsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	exportImageDefault = "ghcr.io/bharvest-devops/compressor:latest"
+	exportContainer    = "export"
+)
+
+// ExportControl exports VolumeSnapshots to object storage.
+type ExportControl struct {
+	client ScratchClient
+	now    func() time.Time
+}
+
+func NewExportControl(client ScratchClient) *ExportControl {
+	return &ExportControl{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// CreateExport restores the crd's last VolumeSnapshot into a temporary PVC and creates a Job to archive and
+// upload it. Updates crd.status.lastExport.
+// Any error returned is considered transient and can be retried.
+func (control ExportControl) CreateExport(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) error {
+	vs, err := getVolumeSnapshot(ctx, control.client, crd)
+	if err != nil {
+		return err
+	}
+
+	pvc, err := BuildExportPVC(crd, &vs)
+	if err != nil {
+		return err
+	}
+	job := BuildExportJob(crd, &vs)
+
+	if err = createScratchResources(ctx, control.client, crd, pvc, job); err != nil {
+		return err
+	}
+
+	height, _ := kube.VolumeSnapshotHeight(vs)
+	crd.Status.LastExport = &cosmosalpha.SnapshotExportStatus{
+		VolumeSnapshotName: vs.Name,
+		JobName:            job.Name,
+		StartedAt:          metav1.NewTime(control.now()),
+		Height:             height,
+	}
+	return nil
+}
+
+// exportResult is written by the export container to its termination message.
+type exportResult struct {
+	SizeBytes int64  `json:"sizeBytes"`
+	Checksum  string `json:"checksum"`
+}
+
+// ExportFinished returns true if the export Job has finished and updates crd.status.lastExport with the result.
+// Once finished, the temporary PVC and Job are deleted.
+// If the returned bool is false, any error can be treated as transient.
+// If the returned bool is true, a non-nil error indicates the export did not succeed.
+func (control ExportControl) ExportFinished(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) (bool, error) {
+	status := crd.Status.LastExport
+	if status == nil {
+		return true, errors.New("missing status.lastExport")
+	}
+
+	var job batchv1.Job
+	key := client.ObjectKey{Namespace: crd.Namespace, Name: status.JobName}
+	err := control.client.Get(ctx, key, &job)
+	switch {
+	case kube.IsNotFound(err):
+		status.FinishedAt = ptr(metav1.NewTime(control.now()))
+		return true, fmt.Errorf("export job %s not found", status.JobName)
+	case err != nil:
+		return false, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	if !kube.IsJobFinished(&job) {
+		return false, nil
+	}
+
+	if job.Status.Succeeded == 0 {
+		if err = deleteScratchResources(ctx, control.client, crd.Namespace, job.Name); err != nil {
+			return false, err
+		}
+		status.FinishedAt = ptr(metav1.NewTime(control.now()))
+		return true, fmt.Errorf("export job %s failed", job.Name)
+	}
+
+	var result exportResult
+	if err = scratchJobResult(ctx, control.client, &job, exportContainer, &result); err != nil {
+		return false, err
+	}
+	if err = deleteScratchResources(ctx, control.client, crd.Namespace, job.Name); err != nil {
+		return false, err
+	}
+
+	status.FinishedAt = ptr(metav1.NewTime(control.now()))
+	status.Succeeded = true
+	status.URL = ExportURL(crd.Spec.Export, status.VolumeSnapshotName)
+	status.SizeBytes = result.SizeBytes
+	status.Checksum = result.Checksum
+	return true, nil
+}
+
+func exportResourceName(vsName string) string {
+	return scratchResourceName(vsName, "export")
+}
+
+// ExportKey returns the object key of the archive within the bucket.
+func ExportKey(spec *cosmosalpha.SnapshotExportSpec, vsName string) string {
+	compression := spec.Compression
+	if compression == "" {
+		compression = cosmosalpha.SnapshotCompressionLZ4
+	}
+	ext := ".tar.lz4"
+	if compression == cosmosalpha.SnapshotCompressionZstd {
+		ext = ".tar.zst"
+	}
+	return path.Join(spec.Destination.Prefix, vsName+ext)
+}
+
+// ExportURL returns the location of the archive.
+func ExportURL(spec *cosmosalpha.SnapshotExportSpec, vsName string) string {
+	base := spec.Destination.PublicURL
+	if base == "" {
+		base = strings.TrimSuffix(spec.Destination.Endpoint, "/") + "/" + spec.Destination.Bucket
+	}
+	return strings.TrimSuffix(base, "/") + "/" + ExportKey(spec, vsName)
+}
+
+// BuildExportPVC builds a temporary PVC restored from the VolumeSnapshot.
+func BuildExportPVC(crd *cosmosalpha.ScheduledVolumeSnapshot, vs *snapshotv1.VolumeSnapshot) (*corev1.PersistentVolumeClaim, error) {
+	return buildScratchPVC(crd, vs, exportResourceName(vs.Name), crd.Spec.Export.StorageClassName)
+}
+
+// BuildExportJob builds a Job which archives, compresses, and uploads the temporary PVC to object storage.
+// The container writes the archive's size and checksum to its termination message.
+func BuildExportJob(crd *cosmosalpha.ScheduledVolumeSnapshot, vs *snapshotv1.VolumeSnapshot) *batchv1.Job {
+	spec := crd.Spec.Export
+
+	image := spec.Image
+	if image == "" {
+		image = exportImageDefault
+	}
+	compression := spec.Compression
+	if compression == "" {
+		compression = cosmosalpha.SnapshotCompressionLZ4
+	}
+
+	const remote = "RCLONE_CONFIG_EXPORT_"
+	env := []corev1.EnvVar{
+		{Name: "SOURCE_DIR", Value: scratchMountPath},
+		{Name: "COMPRESSION", Value: string(compression)},
+		{Name: "DESTINATION", Value: "export:" + path.Join(spec.Destination.Bucket, ExportKey(spec, vs.Name))},
+		{Name: remote + "TYPE", Value: "s3"},
+		{Name: remote + "PROVIDER", Value: "Other"},
+		{Name: remote + "ENV_AUTH", Value: "true"},
+		{Name: remote + "ENDPOINT", Value: spec.Destination.Endpoint},
+		{Name: remote + "FORCE_PATH_STYLE", Value: "true"},
+	}
+	if spec.Destination.Region != "" {
+		env = append(env, corev1.EnvVar{Name: remote + "REGION", Value: spec.Destination.Region})
+	}
+
+	container := corev1.Container{
+		Name:    exportContainer,
+		Image:   image,
+		Command: []string{"sh", "-c"},
+		Args:    []string{exportScript},
+		Env:     env,
+		EnvFrom: []corev1.EnvFromSource{
+			{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: spec.Destination.CredentialsSecretRef}},
+		},
+		Resources: spec.Resources,
+	}
+
+	return buildScratchJob(crd, exportResourceName(vs.Name), spec.JobTemplate, container, true)
+}
+
+// exportScript streams the archive of $SOURCE_DIR to object storage without staging it on disk.
+// Size and checksum are computed from the compressed stream using named pipes.
+// The result is written to $TERMINATION_LOG, defaulting to the container's termination message path.
+const exportScript = `set -euo pipefail
+case "$COMPRESSION" in
+zstd) compress="zstd -T0 -c" ;;
+*) compress="lz4 -c" ;;
+esac
+work="$(mktemp -d)"
+mkfifo "$work/checksum.fifo" "$work/size.fifo"
+sha256sum < "$work/checksum.fifo" > "$work/checksum.txt" &
+wc -c < "$work/size.fifo" > "$work/size.txt" &
+tar -C "$SOURCE_DIR" --exclude=./config -cf - . | $compress | tee "$work/checksum.fifo" "$work/size.fifo" | rclone rcat "$DESTINATION"
+wait
+read -r checksum _ < "$work/checksum.txt"
+read -r size < "$work/size.txt"
+printf '{"sizeBytes":%s,"checksum":"%s"}' "$size" "$checksum" > "${TERMINATION_LOG:-/dev/termination-log}"
+`
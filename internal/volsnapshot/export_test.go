@@ -0,0 +1,516 @@
+package volsnapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockScratchClient struct {
+	VolumeSnapshot snapshotv1.VolumeSnapshot
+	FullNode       cosmosv1.CosmosFullNode
+	Job            *batchv1.Job
+	GetErr         error
+
+	Pods    []corev1.Pod
+	GotList []client.ListOption
+
+	GotCreated []client.Object
+	CreateErr  error
+
+	GotDeleted []client.Object
+	DeleteErr  error
+
+	GotPatched []client.Object
+	GotPatch   []client.Patch
+	PatchErr   error
+}
+
+func (m *mockScratchClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	switch obj := obj.(type) {
+	case *snapshotv1.VolumeSnapshot:
+		*obj = m.VolumeSnapshot
+	case *cosmosv1.CosmosFullNode:
+		*obj = m.FullNode
+	case *batchv1.Job:
+		if m.Job == nil {
+			return apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, key.Name)
+		}
+		*obj = *m.Job
+	default:
+		panic(fmt.Errorf("unexpected type %T", obj))
+	}
+	return m.GetErr
+}
+
+func (m *mockScratchClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotList = opts
+	list.(*corev1.PodList).Items = m.Pods
+	return nil
+}
+
+func (m *mockScratchClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotCreated = append(m.GotCreated, obj)
+	return m.CreateErr
+}
+
+func (m *mockScratchClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotDeleted = append(m.GotDeleted, obj)
+	return m.DeleteErr
+}
+
+func (m *mockScratchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotPatched = append(m.GotPatched, obj)
+	m.GotPatch = append(m.GotPatch, patch)
+	return m.PatchErr
+}
+
+func (m *mockScratchClient) Scheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := cosmosalpha.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+func exportCRD() cosmosalpha.ScheduledVolumeSnapshot {
+	var crd cosmosalpha.ScheduledVolumeSnapshot
+	crd.Name = "cosmoshub"
+	crd.Namespace = "strangelove"
+	crd.Spec.Export = &cosmosalpha.SnapshotExportSpec{
+		StorageClassName: "premium-rwo",
+		Destination: cosmosalpha.ObjectStorageDestination{
+			Endpoint:             "http://minio.minio.svc:9000/",
+			Bucket:               "snapshots",
+			Prefix:               "cosmoshub-4",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "minio-creds"},
+		},
+	}
+	return crd
+}
+
+func readyVolumeSnapshot() snapshotv1.VolumeSnapshot {
+	var vs snapshotv1.VolumeSnapshot
+	vs.Name = "cosmoshub-202209010203"
+	vs.Namespace = "strangelove"
+	vs.Annotations = map[string]string{kube.SnapshotHeightAnnotation: "12345"}
+	vs.Status = &snapshotv1.VolumeSnapshotStatus{
+		ReadyToUse:  ptr(true),
+		RestoreSize: ptr(resource.MustParse("100Gi")),
+	}
+	return vs
+}
+
+func TestExportURL(t *testing.T) {
+	t.Parallel()
+
+	crd := exportCRD()
+	spec := crd.Spec.Export
+
+	require.Equal(t, "cosmoshub-4/snap.tar.lz4", ExportKey(spec, "snap"))
+	require.Equal(t, "http://minio.minio.svc:9000/snapshots/cosmoshub-4/snap.tar.lz4", ExportURL(spec, "snap"))
+
+	spec.Compression = cosmosalpha.SnapshotCompressionZstd
+	spec.Destination.Prefix = ""
+	spec.Destination.PublicURL = "https://snapshots.example.com/"
+	require.Equal(t, "snap.tar.zst", ExportKey(spec, "snap"))
+	require.Equal(t, "https://snapshots.example.com/snap.tar.zst", ExportURL(spec, "snap"))
+}
+
+func TestBuildExportPVC(t *testing.T) {
+	t.Parallel()
+
+	crd := exportCRD()
+	vs := readyVolumeSnapshot()
+
+	pvc, err := BuildExportPVC(&crd, &vs)
+	require.NoError(t, err)
+
+	require.Equal(t, "strangelove", pvc.Namespace)
+	require.Equal(t, "cosmoshub-202209010203-export", pvc.Name)
+	require.Equal(t, "premium-rwo", *pvc.Spec.StorageClassName)
+	require.Equal(t, "snapshot.storage.k8s.io", *pvc.Spec.DataSource.APIGroup)
+	require.Equal(t, "VolumeSnapshot", pvc.Spec.DataSource.Kind)
+	require.Equal(t, vs.Name, pvc.Spec.DataSource.Name)
+	require.Equal(t, resource.MustParse("100Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	require.Equal(t, "cosmoshub", pvc.Labels[cosmosSourceLabel])
+
+	vs.Status = nil
+	_, err = BuildExportPVC(&crd, &vs)
+	require.Error(t, err)
+	require.EqualError(t, err, "VolumeSnapshot cosmoshub-202209010203: missing status.restoreSize")
+}
+
+func TestBuildExportJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		crd := exportCRD()
+		vs := readyVolumeSnapshot()
+
+		job := BuildExportJob(&crd, &vs)
+
+		require.Equal(t, "strangelove", job.Namespace)
+		require.Equal(t, "cosmoshub-202209010203-export", job.Name)
+		require.EqualValues(t, 24*time.Hour.Seconds(), *job.Spec.ActiveDeadlineSeconds)
+		require.EqualValues(t, 2, *job.Spec.BackoffLimit)
+		require.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+		require.Len(t, job.Spec.Template.Spec.Containers, 1)
+		c := job.Spec.Template.Spec.Containers[0]
+		require.Equal(t, "export", c.Name)
+		require.Equal(t, "ghcr.io/bharvest-devops/compressor:latest", c.Image)
+		require.Equal(t, []string{"sh", "-c"}, c.Command)
+		require.Equal(t, "minio-creds", c.EnvFrom[0].SecretRef.Name)
+
+		env := make(map[string]string)
+		for _, e := range c.Env {
+			env[e.Name] = e.Value
+		}
+		require.Equal(t, "/home/operator/cosmos", env["SOURCE_DIR"])
+		require.Equal(t, "lz4", env["COMPRESSION"])
+		require.Equal(t, "export:snapshots/cosmoshub-4/cosmoshub-202209010203.tar.lz4", env["DESTINATION"])
+		require.Equal(t, "http://minio.minio.svc:9000/", env["RCLONE_CONFIG_EXPORT_ENDPOINT"])
+		require.NotContains(t, env, "RCLONE_CONFIG_EXPORT_REGION")
+
+		require.Equal(t, "/home/operator/cosmos", c.VolumeMounts[0].MountPath)
+		require.True(t, c.VolumeMounts[0].ReadOnly)
+		vol := job.Spec.Template.Spec.Volumes[0]
+		require.Equal(t, "cosmoshub-202209010203-export", vol.PersistentVolumeClaim.ClaimName)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		crd := exportCRD()
+		crd.Spec.Export.Image = "compressor:test"
+		crd.Spec.Export.Compression = cosmosalpha.SnapshotCompressionZstd
+		crd.Spec.Export.Destination.Region = "us-east-1"
+		crd.Spec.Export.JobTemplate = cosmosalpha.JobTemplateSpec{
+			ActiveDeadlineSeconds:   ptr(int64(10)),
+			BackoffLimit:            ptr(int32(0)),
+			TTLSecondsAfterFinished: ptr(int32(30)),
+		}
+		vs := readyVolumeSnapshot()
+
+		job := BuildExportJob(&crd, &vs)
+
+		require.EqualValues(t, 10, *job.Spec.ActiveDeadlineSeconds)
+		require.EqualValues(t, 0, *job.Spec.BackoffLimit)
+		require.EqualValues(t, 30, *job.Spec.TTLSecondsAfterFinished)
+
+		c := job.Spec.Template.Spec.Containers[0]
+		require.Equal(t, "compressor:test", c.Image)
+		require.Contains(t, c.Env, corev1.EnvVar{Name: "COMPRESSION", Value: "zstd"})
+		require.Contains(t, c.Env, corev1.EnvVar{Name: "RCLONE_CONFIG_EXPORT_REGION", Value: "us-east-1"})
+	})
+}
+
+func TestExportScript(t *testing.T) {
+	t.Parallel()
+
+	for _, bin := range []string{"bash", "rclone", "tar", "sha256sum", "mkfifo"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "data", "application.db"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "data", "application.db", "000001.log"), []byte("application"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config", "node_key.json"), []byte("secret"), 0644))
+
+	for _, tt := range []struct {
+		Compression cosmosalpha.SnapshotCompression
+		Decompress  string
+	}{
+		{cosmosalpha.SnapshotCompressionLZ4, "lz4"},
+		{cosmosalpha.SnapshotCompressionZstd, "zstd"},
+	} {
+		t.Run(string(tt.Compression), func(t *testing.T) {
+			if _, err := exec.LookPath(tt.Decompress); err != nil {
+				t.Skipf("%s not installed", tt.Decompress)
+			}
+
+			crd := exportCRD()
+			crd.Spec.Export.Compression = tt.Compression
+			vs := readyVolumeSnapshot()
+			c := BuildExportJob(&crd, &vs).Spec.Template.Spec.Containers[0]
+
+			// An rclone local remote stands in for the bucket. DESTINATION is relative, so it resolves against the
+			// working directory.
+			remote := t.TempDir()
+			termLog := filepath.Join(t.TempDir(), "termination-log")
+			env := os.Environ()
+			for _, e := range c.Env {
+				env = append(env, e.Name+"="+e.Value)
+			}
+			env = append(env,
+				"SOURCE_DIR="+src,
+				"TERMINATION_LOG="+termLog,
+				"RCLONE_CONFIG_EXPORT_TYPE=local",
+				"RCLONE_CONFIG="+filepath.Join(remote, "rclone.conf"),
+			)
+
+			// The compressor image's sh supports pipefail. Locally, sh may not.
+			cmd := exec.Command("bash", "-c", c.Args[0])
+			cmd.Dir = remote
+			cmd.Env = env
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+
+			object, err := os.ReadFile(filepath.Join(remote, crd.Spec.Export.Destination.Bucket, ExportKey(crd.Spec.Export, vs.Name)))
+			require.NoError(t, err)
+
+			msg, err := os.ReadFile(termLog)
+			require.NoError(t, err)
+			dec := json.NewDecoder(strings.NewReader(string(msg)))
+			dec.DisallowUnknownFields()
+			var result exportResult
+			require.NoError(t, dec.Decode(&result), string(msg))
+
+			sum := sha256.Sum256(object)
+			require.Equal(t, hex.EncodeToString(sum[:]), result.Checksum)
+			require.EqualValues(t, len(object), result.SizeBytes)
+
+			list := exec.Command("sh", "-c", tt.Decompress+" -d -c | tar -tf -")
+			list.Stdin = strings.NewReader(string(object))
+			out, err = list.CombinedOutput()
+			require.NoError(t, err, string(out))
+			require.Contains(t, string(out), "./data/application.db/000001.log")
+			require.NotContains(t, string(out), "config")
+		})
+	}
+}
+
+func TestExportControl_CreateExport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("happy path", func(t *testing.T) {
+		crd := exportCRD()
+		crd.Status.LastSnapshot = &cosmosalpha.VolumeSnapshotStatus{Name: "cosmoshub-202209010203"}
+
+		var mClient mockScratchClient
+		mClient.VolumeSnapshot = readyVolumeSnapshot()
+		control := NewExportControl(&mClient)
+		control.now = func() time.Time { return now }
+
+		err := control.CreateExport(ctx, &crd)
+		require.NoError(t, err)
+
+		require.Len(t, mClient.GotCreated, 2)
+		require.IsType(t, &corev1.PersistentVolumeClaim{}, mClient.GotCreated[0])
+		require.IsType(t, &batchv1.Job{}, mClient.GotCreated[1])
+		for _, obj := range mClient.GotCreated {
+			require.Equal(t, "cosmoshub", obj.GetOwnerReferences()[0].Name)
+			require.Equal(t, "ScheduledVolumeSnapshot", obj.GetOwnerReferences()[0].Kind)
+		}
+
+		want := &cosmosalpha.SnapshotExportStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-export",
+			StartedAt:          metav1.NewTime(now),
+			Height:             12345,
+		}
+		require.Equal(t, want, crd.Status.LastExport)
+	})
+
+	t.Run("missing last snapshot", func(t *testing.T) {
+		crd := exportCRD()
+		control := NewExportControl(&mockScratchClient{})
+
+		err := control.CreateExport(ctx, &crd)
+		require.Error(t, err)
+		require.EqualError(t, err, "missing status.lastSnapshot")
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		crd := exportCRD()
+		crd.Status.LastSnapshot = &cosmosalpha.VolumeSnapshotStatus{Name: "cosmoshub-202209010203"}
+
+		var mClient mockScratchClient
+		mClient.VolumeSnapshot = readyVolumeSnapshot()
+		mClient.CreateErr = errors.New("boom")
+		control := NewExportControl(&mClient)
+
+		err := control.CreateExport(ctx, &crd)
+		require.Error(t, err)
+		require.EqualError(t, err, "create cosmoshub-202209010203-export: boom")
+		require.Nil(t, crd.Status.LastExport)
+	})
+}
+
+func TestExportControl_ExportFinished(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	startedCRD := func() cosmosalpha.ScheduledVolumeSnapshot {
+		crd := exportCRD()
+		crd.Status.LastExport = &cosmosalpha.SnapshotExportStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-export",
+			Height:             12345,
+		}
+		return crd
+	}
+
+	finishedJob := func(condition batchv1.JobConditionType, succeeded int32) *batchv1.Job {
+		var job batchv1.Job
+		job.Name = "cosmoshub-202209010203-export"
+		job.Namespace = "strangelove"
+		job.Status.Succeeded = succeeded
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		return &job
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		crd := startedCRD()
+
+		var pod corev1.Pod
+		pod.Name = "export-pod"
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: "export",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `{"sizeBytes":1024,"checksum":"abc123"}`,
+			}},
+		}}
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobComplete, 1)
+		mClient.Pods = []corev1.Pod{pod}
+		control := NewExportControl(&mClient)
+		control.now = func() time.Time { return now }
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.NoError(t, err)
+		require.True(t, done)
+
+		require.Len(t, mClient.GotList, 2)
+		require.Len(t, mClient.GotDeleted, 2)
+		require.Equal(t, "cosmoshub-202209010203-export", mClient.GotDeleted[0].GetName())
+		require.Equal(t, "cosmoshub-202209010203-export", mClient.GotDeleted[1].GetName())
+
+		want := &cosmosalpha.SnapshotExportStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-export",
+			FinishedAt:         ptr(metav1.NewTime(now)),
+			Succeeded:          true,
+			URL:                "http://minio.minio.svc:9000/snapshots/cosmoshub-4/cosmoshub-202209010203.tar.lz4",
+			SizeBytes:          1024,
+			Checksum:           "abc123",
+			Height:             12345,
+		}
+		require.Equal(t, want, crd.Status.LastExport)
+	})
+
+	t.Run("job running", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = new(batchv1.Job)
+		control := NewExportControl(&mClient)
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.NoError(t, err)
+		require.False(t, done)
+		require.Empty(t, mClient.GotDeleted)
+		require.Nil(t, crd.Status.LastExport.FinishedAt)
+	})
+
+	t.Run("job failed", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobFailed, 0)
+		control := NewExportControl(&mClient)
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.True(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "export job cosmoshub-202209010203-export failed")
+		require.Len(t, mClient.GotDeleted, 2)
+		require.False(t, crd.Status.LastExport.Succeeded)
+		require.NotNil(t, crd.Status.LastExport.FinishedAt)
+	})
+
+	t.Run("missing termination message", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobComplete, 1)
+		control := NewExportControl(&mClient)
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.False(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "job cosmoshub-202209010203-export: no successful pod found")
+		require.Empty(t, mClient.GotDeleted)
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		crd := startedCRD()
+		control := NewExportControl(&mockScratchClient{})
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.True(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "export job cosmoshub-202209010203-export not found")
+		require.NotNil(t, crd.Status.LastExport.FinishedAt)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = new(batchv1.Job)
+		mClient.GetErr = errors.New("boom")
+		control := NewExportControl(&mClient)
+
+		done, err := control.ExportFinished(ctx, &crd)
+		require.False(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "get strangelove/cosmoshub-202209010203-export: boom")
+	})
+}
@@ -0,0 +1,64 @@
+package volsnapshot
+
+import (
+	"fmt"
+	"time"
+
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+)
+
+// RetainedSnapshots returns the names of VolumeSnapshots to keep given the crd's spec.limit and spec.retention.
+// The snapshots must be sorted by creation time descending and have a non-nil status.creationTime.
+func RetainedSnapshots(crd *cosmosalpha.ScheduledVolumeSnapshot, snapshots []snapshotv1.VolumeSnapshot, now time.Time) map[string]bool {
+	var (
+		keep      = make(map[string]bool)
+		retention = crd.Spec.Retention
+	)
+
+	if retention != nil && (retention.Hourly > 0 || retention.Daily > 0 || retention.Weekly > 0) {
+		keepPeriods(keep, snapshots, retention.Hourly, func(t time.Time) string { return t.Format("2006-01-02T15") })
+		keepPeriods(keep, snapshots, retention.Daily, func(t time.Time) string { return t.Format("2006-01-02") })
+		keepPeriods(keep, snapshots, retention.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%d", year, week)
+		})
+	} else {
+		limit := int(crd.Spec.Limit)
+		if limit <= 0 {
+			limit = 3
+		}
+		for i := 0; i < limit && i < len(snapshots); i++ {
+			keep[snapshots[i].Name] = true
+		}
+	}
+
+	if retention != nil && retention.MaxAge != nil {
+		for _, vs := range snapshots {
+			if now.Sub(vs.Status.CreationTime.Time) > retention.MaxAge.Duration {
+				delete(keep, vs.Name)
+			}
+		}
+	}
+
+	return keep
+}
+
+// keepPeriods keeps the most recent snapshot within each of the n most recent periods.
+func keepPeriods(keep map[string]bool, snapshots []snapshotv1.VolumeSnapshot, n int32, period func(time.Time) string) {
+	if n <= 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, vs := range snapshots {
+		key := period(vs.Status.CreationTime.UTC())
+		if seen[key] {
+			continue
+		}
+		if len(seen) >= int(n) {
+			return
+		}
+		seen[key] = true
+		keep[vs.Name] = true
+	}
+}
@@ -0,0 +1,100 @@
+package volsnapshot
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRetainedSnapshots(t *testing.T) {
+	t.Parallel()
+
+	// A Sunday.
+	now := time.Date(2024, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	// Builds snapshots sorted descending, one every interval.
+	build := func(count int, interval time.Duration) []snapshotv1.VolumeSnapshot {
+		snapshots := make([]snapshotv1.VolumeSnapshot, count)
+		for i := range snapshots {
+			creation := metav1.NewTime(now.Add(-time.Duration(i) * interval))
+			snapshots[i].Name = strconv.Itoa(i)
+			snapshots[i].Status = &snapshotv1.VolumeSnapshotStatus{CreationTime: &creation}
+		}
+		return snapshots
+	}
+
+	keys := func(m map[string]bool) []string {
+		return lo.Keys(m)
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		got := RetainedSnapshots(&crd, build(5, time.Hour), now)
+
+		require.ElementsMatch(t, []string{"0", "1", "2"}, keys(got))
+	})
+
+	t.Run("custom limit", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Limit = 10
+		got := RetainedSnapshots(&crd, build(5, time.Hour), now)
+
+		require.Len(t, got, 5)
+	})
+
+	t.Run("hourly", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Limit = 100 // Ignored
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{Hourly: 3}
+		// Two snapshots per hour.
+		got := RetainedSnapshots(&crd, build(10, 30*time.Minute), now)
+
+		// 12:30, 11:30, 10:30
+		require.ElementsMatch(t, []string{"0", "2", "4"}, keys(got))
+	})
+
+	t.Run("daily and weekly", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{Daily: 2, Weekly: 3}
+		got := RetainedSnapshots(&crd, build(20, 24*time.Hour), now)
+
+		// Daily: Mar 10, Mar 9. Weekly: Mar 10 (week 10), Mar 3 (week 9), Feb 25 (week 8).
+		require.ElementsMatch(t, []string{"0", "1", "7", "14"}, keys(got))
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{
+			Daily:  10,
+			MaxAge: &metav1.Duration{Duration: 72 * time.Hour},
+		}
+		got := RetainedSnapshots(&crd, build(10, 24*time.Hour), now)
+
+		require.ElementsMatch(t, []string{"0", "1", "2", "3"}, keys(got))
+	})
+
+	t.Run("max age with limit", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Limit = 5
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{
+			MaxAge: &metav1.Duration{Duration: 90 * time.Minute},
+		}
+		got := RetainedSnapshots(&crd, build(10, time.Hour), now)
+
+		require.ElementsMatch(t, []string{"0", "1"}, keys(got))
+	})
+
+	t.Run("no snapshots", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{Hourly: 1, Daily: 1, Weekly: 1}
+		got := RetainedSnapshots(&crd, nil, now)
+
+		require.Empty(t, got)
+	})
+}
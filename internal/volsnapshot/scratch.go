@@ -0,0 +1,203 @@
+package volsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Scratch resources are a temporary PVC restored from a VolumeSnapshot and a Job which mounts it.
+// The Job's container reports results via its termination message.
+
+const scratchMountPath = "/home/operator/cosmos"
+
+// ScratchClient is a subset of client.Client.
+type ScratchClient interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
+	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
+	Scheme() *runtime.Scheme
+}
+
+func scratchResourceName(vsName, suffix string) string {
+	return kube.ToName(vsName + "-" + suffix)
+}
+
+func scratchLabels(crd *cosmosalpha.ScheduledVolumeSnapshot) map[string]string {
+	return map[string]string{
+		kube.ControllerLabel: "cosmos-operator",
+		kube.ComponentLabel:  cosmosalpha.ScheduledVolumeSnapshotController,
+		cosmosSourceLabel:    crd.Name,
+	}
+}
+
+func getVolumeSnapshot(ctx context.Context, getter Getter, crd *cosmosalpha.ScheduledVolumeSnapshot) (snapshotv1.VolumeSnapshot, error) {
+	var vs snapshotv1.VolumeSnapshot
+	if crd.Status.LastSnapshot == nil {
+		return vs, errors.New("missing status.lastSnapshot")
+	}
+	key := client.ObjectKey{Namespace: crd.Namespace, Name: crd.Status.LastSnapshot.Name}
+	if err := getter.Get(ctx, key, &vs); err != nil {
+		return vs, fmt.Errorf("get %s: %w", key, err)
+	}
+	return vs, nil
+}
+
+// buildScratchPVC builds a temporary PVC restored from the VolumeSnapshot.
+func buildScratchPVC(crd *cosmosalpha.ScheduledVolumeSnapshot, vs *snapshotv1.VolumeSnapshot, name, storageClassName string) (*corev1.PersistentVolumeClaim, error) {
+	if vs.Status == nil || vs.Status.RestoreSize == nil {
+		return nil, fmt.Errorf("VolumeSnapshot %s: missing status.restoreSize", vs.Name)
+	}
+
+	pvc := corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr(storageClassName),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr(snapshotv1.GroupName),
+				Kind:     "VolumeSnapshot",
+				Name:     vs.Name,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: *vs.Status.RestoreSize},
+			},
+		},
+	}
+	pvc.Namespace = crd.Namespace
+	pvc.Name = name
+	pvc.Labels = scratchLabels(crd)
+
+	return &pvc, nil
+}
+
+// buildScratchJob builds a Job running container with the scratch PVC mounted at scratchMountPath.
+func buildScratchJob(crd *cosmosalpha.ScheduledVolumeSnapshot, name string, tpl cosmosalpha.JobTemplateSpec, container corev1.Container, readOnly bool) *batchv1.Job {
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name: "snapshot", MountPath: scratchMountPath, ReadOnly: readOnly,
+	})
+
+	job := batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+		},
+		Spec: batchv1.JobSpec{
+			// Set defaults
+			ActiveDeadlineSeconds:   ptr(int64(24 * time.Hour.Seconds())),
+			BackoffLimit:            ptr(int32(2)),
+			TTLSecondsAfterFinished: ptr(int32(24 * time.Hour.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsUser:           ptr(int64(1025)),
+						RunAsGroup:          ptr(int64(1025)),
+						RunAsNonRoot:        ptr(true),
+						FSGroup:             ptr(int64(1025)),
+						FSGroupChangePolicy: ptr(corev1.FSGroupChangeOnRootMismatch),
+						SeccompProfile:      &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+					},
+					Containers: []corev1.Container{container},
+					Volumes: []corev1.Volume{{
+						Name: "snapshot",
+						VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: name,
+							ReadOnly:  readOnly,
+						}},
+					}},
+				},
+			},
+		},
+	}
+	job.Namespace = crd.Namespace
+	job.Name = name
+	job.Labels = scratchLabels(crd)
+	job.Spec.Template.Labels = scratchLabels(crd)
+
+	if v := tpl.ActiveDeadlineSeconds; v != nil {
+		job.Spec.ActiveDeadlineSeconds = v
+	}
+	if v := tpl.BackoffLimit; v != nil {
+		job.Spec.BackoffLimit = v
+	}
+	if v := tpl.TTLSecondsAfterFinished; v != nil {
+		job.Spec.TTLSecondsAfterFinished = v
+	}
+
+	return &job
+}
+
+// createScratchResources sets the crd as owner and creates the objects.
+func createScratchResources(ctx context.Context, c ScratchClient, crd *cosmosalpha.ScheduledVolumeSnapshot, objs ...client.Object) error {
+	for _, obj := range objs {
+		if err := ctrl.SetControllerReference(crd, obj, c.Scheme()); err != nil {
+			return fmt.Errorf("set controller reference on %s: %w", obj.GetName(), err)
+		}
+		// Ignore already exists in case a previous attempt failed after creating a resource.
+		if err := kube.IgnoreAlreadyExists(c.Create(ctx, obj)); err != nil {
+			return fmt.Errorf("create %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// deleteScratchResources deletes the Job and PVC with the given name.
+func deleteScratchResources(ctx context.Context, c ScratchClient, namespace, name string) error {
+	var job batchv1.Job
+	job.Namespace = namespace
+	job.Name = name
+	if err := c.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); kube.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("delete job %s: %w", name, err)
+	}
+
+	var pvc corev1.PersistentVolumeClaim
+	pvc.Namespace = namespace
+	pvc.Name = name
+	if err := c.Delete(ctx, &pvc); kube.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("delete pvc %s: %w", name, err)
+	}
+	return nil
+}
+
+// scratchJobResult unmarshals the termination message of the job's successful container into result.
+func scratchJobResult(ctx context.Context, c ScratchClient, job *batchv1.Job, containerName string, result any) error {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return fmt.Errorf("list pods for job %s: %w", job.Name, err)
+	}
+
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			term := cs.State.Terminated
+			if cs.Name != containerName || term == nil || term.ExitCode != 0 {
+				continue
+			}
+			if err := json.Unmarshal([]byte(term.Message), result); err != nil {
+				return fmt.Errorf("pod %s: malformed termination message: %w", pod.Name, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("job %s: no successful pod found", job.Name)
+}
@@ -15,6 +15,9 @@ func ResetStatus(crd *cosmosalpha.ScheduledVolumeSnapshot) {
 		crd.Status.CreatedAt = metav1.NewTime(time.Now())
 	}
 	switch {
+	case crd.Spec.Suspend && (crd.Status.Phase == cosmosalpha.SnapshotPhaseWaitingForVerification ||
+		crd.Status.Phase == cosmosalpha.SnapshotPhaseWaitingForExport):
+		// Allow an in-progress verification or export to finish. The controller suspends afterward.
 	case crd.Spec.Suspend:
 		// Restore any temporarily deleted pod and suspend
 		crd.Status.Phase = cosmosalpha.SnapshotPhaseRestorePod
@@ -51,4 +51,21 @@ func TestResetStatus(t *testing.T) {
 		ResetStatus(&crd)
 		require.Equal(t, cosmosalpha.SnapshotPhaseDeletingPod, crd.Status.Phase)
 	})
+
+	t.Run("suspended while exporting", func(t *testing.T) {
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Status.Phase = cosmosalpha.SnapshotPhaseWaitingForExport
+		crd.Spec.Suspend = true
+
+		ResetStatus(&crd)
+		require.Equal(t, cosmosalpha.SnapshotPhaseWaitingForExport, crd.Status.Phase)
+
+		crd.Status.Phase = cosmosalpha.SnapshotPhaseWaitingForVerification
+		ResetStatus(&crd)
+		require.Equal(t, cosmosalpha.SnapshotPhaseWaitingForVerification, crd.Status.Phase)
+
+		crd.Status.Phase = cosmosalpha.SnapshotPhaseCreatingExport
+		ResetStatus(&crd)
+		require.Equal(t, cosmosalpha.SnapshotPhaseRestorePod, crd.Status.Phase)
+	})
 }
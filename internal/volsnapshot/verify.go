@@ -0,0 +1,172 @@
+package volsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/bharvest-devops/cosmos-operator/internal/version"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const verifyContainer = "verify"
+
+// VerifyClient is a subset of client.Client.
+type VerifyClient interface {
+	ScratchClient
+	Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+}
+
+// VerifyControl verifies the chain data within VolumeSnapshots.
+type VerifyControl struct {
+	client VerifyClient
+	now    func() time.Time
+}
+
+func NewVerifyControl(client VerifyClient) *VerifyControl {
+	return &VerifyControl{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// CreateVerification restores the crd's last VolumeSnapshot into a temporary PVC and creates a Job to verify it.
+// Updates crd.status.lastVerification.
+// Any error returned is considered transient and can be retried.
+func (control VerifyControl) CreateVerification(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) error {
+	vs, err := getVolumeSnapshot(ctx, control.client, crd)
+	if err != nil {
+		return err
+	}
+
+	// The source CosmosFullNode's chain type determines where the CometBFT databases are within the snapshot.
+	var fullNode cosmosv1.CosmosFullNode
+	key := client.ObjectKey{Namespace: crd.Namespace, Name: crd.Spec.FullNodeRef.Name}
+	if err = control.client.Get(ctx, key, &fullNode); err != nil {
+		return fmt.Errorf("get %s: %w", key, err)
+	}
+
+	pvc, err := buildScratchPVC(crd, &vs, verifyResourceName(vs.Name), crd.Spec.Verify.StorageClassName)
+	if err != nil {
+		return err
+	}
+	job := BuildVerifyJob(crd, &vs, &fullNode)
+
+	if err = createScratchResources(ctx, control.client, crd, pvc, job); err != nil {
+		return err
+	}
+
+	crd.Status.LastVerification = &cosmosalpha.SnapshotVerificationStatus{
+		VolumeSnapshotName: vs.Name,
+		JobName:            job.Name,
+		StartedAt:          metav1.NewTime(control.now()),
+	}
+	return nil
+}
+
+// verifyResult is written by the verify container to its termination message.
+type verifyResult struct {
+	Height uint64 `json:"height"`
+}
+
+// VerificationFinished returns true if the verification Job has finished. Once finished, labels the VolumeSnapshot
+// with the result, updates crd.status.lastVerification, and deletes the temporary PVC and Job.
+// If the returned bool is false, any error can be treated as transient.
+// If the returned bool is true, a non-nil error indicates the VolumeSnapshot failed verification.
+func (control VerifyControl) VerificationFinished(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) (bool, error) {
+	status := crd.Status.LastVerification
+	if status == nil {
+		return true, errors.New("missing status.lastVerification")
+	}
+
+	var job batchv1.Job
+	key := client.ObjectKey{Namespace: crd.Namespace, Name: status.JobName}
+	err := control.client.Get(ctx, key, &job)
+	switch {
+	case kube.IsNotFound(err):
+		status.FinishedAt = ptr(metav1.NewTime(control.now()))
+		return true, fmt.Errorf("verification job %s not found", status.JobName)
+	case err != nil:
+		return false, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	if !kube.IsJobFinished(&job) {
+		return false, nil
+	}
+
+	var (
+		result    verifyResult
+		verifyErr error
+	)
+	if job.Status.Succeeded == 0 {
+		verifyErr = fmt.Errorf("verification job %s failed", job.Name)
+	} else if err = scratchJobResult(ctx, control.client, &job, verifyContainer, &result); err != nil {
+		return false, err
+	}
+
+	if err = control.labelSnapshot(ctx, crd.Namespace, status.VolumeSnapshotName, verifyErr == nil); err != nil {
+		return false, err
+	}
+	if err = deleteScratchResources(ctx, control.client, crd.Namespace, job.Name); err != nil {
+		return false, err
+	}
+
+	status.FinishedAt = ptr(metav1.NewTime(control.now()))
+	status.Verified = verifyErr == nil
+	status.Height = result.Height
+	return true, verifyErr
+}
+
+func (control VerifyControl) labelSnapshot(ctx context.Context, namespace, name string, verified bool) error {
+	var vs snapshotv1.VolumeSnapshot
+	vs.Namespace = namespace
+	vs.Name = name
+	patch := client.RawPatch(client.Merge.Type(),
+		[]byte(fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, kube.SnapshotVerifiedLabel, strconv.FormatBool(verified))))
+	if err := control.client.Patch(ctx, &vs, patch); kube.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("label %s: %w", name, err)
+	}
+	return nil
+}
+
+func verifyResourceName(vsName string) string {
+	return scratchResourceName(vsName, "verify")
+}
+
+// BuildVerifyJob builds a Job which opens the CometBFT databases within the temporary PVC.
+// The databases are found using the chain type of fullNode, the snapshot's source.
+// The container writes the database's height to its termination message.
+func BuildVerifyJob(crd *cosmosalpha.ScheduledVolumeSnapshot, vs *snapshotv1.VolumeSnapshot, fullNode *cosmosv1.CosmosFullNode) *batchv1.Job {
+	spec := crd.Spec.Verify
+
+	cmd := []string{"/manager", "snapshotverify"}
+	if backend := vs.Labels[kube.SnapshotDatabaseBackendLabel]; backend != "" {
+		cmd = append(cmd, "-b", backend)
+	}
+	// The recorded height is a lower bound, so the restored data must be at least this height.
+	if height, ok := kube.VolumeSnapshotHeight(*vs); ok && height > 0 {
+		cmd = append(cmd, "--min-height", strconv.FormatUint(height, 10))
+	}
+
+	container := corev1.Container{
+		Name:      verifyContainer,
+		Image:     "ghcr.io/bharvest-devops/cosmos-operator:" + version.DockerTag(),
+		Command:   cmd,
+		Env:       []corev1.EnvVar{{Name: "DATA_DIR", Value: path.Join(scratchMountPath, fullnode.CometDataDir(fullNode))}},
+		Resources: spec.Resources,
+	}
+
+	// Opening the database requires write access for lock files. The PVC is temporary so writes are harmless.
+	return buildScratchJob(crd, verifyResourceName(vs.Name), spec.JobTemplate, container, false)
+}
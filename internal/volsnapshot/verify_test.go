@@ -0,0 +1,239 @@
+package volsnapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
+	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/kube"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func verifyCRD() cosmosalpha.ScheduledVolumeSnapshot {
+	var crd cosmosalpha.ScheduledVolumeSnapshot
+	crd.Name = "cosmoshub"
+	crd.Namespace = "strangelove"
+	crd.Spec.Verify = &cosmosalpha.SnapshotVerifySpec{StorageClassName: "premium-rwo"}
+	return crd
+}
+
+func TestBuildVerifyJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		crd := verifyCRD()
+		vs := readyVolumeSnapshot()
+		vs.Annotations = nil
+
+		job := BuildVerifyJob(&crd, &vs, &cosmosv1.CosmosFullNode{})
+
+		require.Equal(t, "strangelove", job.Namespace)
+		require.Equal(t, "cosmoshub-202209010203-verify", job.Name)
+
+		c := job.Spec.Template.Spec.Containers[0]
+		require.Equal(t, "verify", c.Name)
+		require.Contains(t, c.Image, "ghcr.io/bharvest-devops/cosmos-operator:")
+		require.Equal(t, []string{"/manager", "snapshotverify"}, c.Command)
+		require.Equal(t, []corev1.EnvVar{{Name: "DATA_DIR", Value: "/home/operator/cosmos/data"}}, c.Env)
+		require.False(t, c.VolumeMounts[0].ReadOnly)
+		require.Equal(t, corev1.TerminationMessageReadFile, c.TerminationMessagePolicy)
+		require.EqualValues(t, 1025, *job.Spec.Template.Spec.SecurityContext.RunAsUser)
+	})
+
+	t.Run("snapshot metadata", func(t *testing.T) {
+		crd := verifyCRD()
+		crd.Spec.Verify.JobTemplate.BackoffLimit = ptr(int32(0))
+		vs := readyVolumeSnapshot()
+		vs.Labels = map[string]string{kube.SnapshotDatabaseBackendLabel: "pebbledb"}
+
+		job := BuildVerifyJob(&crd, &vs, &cosmosv1.CosmosFullNode{})
+
+		require.EqualValues(t, 0, *job.Spec.BackoffLimit)
+		c := job.Spec.Template.Spec.Containers[0]
+		require.Equal(t, []string{"/manager", "snapshotverify", "-b", "pebbledb", "--min-height", "12345"}, c.Command)
+	})
+
+	t.Run("namada", func(t *testing.T) {
+		crd := verifyCRD()
+		vs := readyVolumeSnapshot()
+		var fullNode cosmosv1.CosmosFullNode
+		fullNode.Spec.ChainSpec.ChainType = "namada"
+		fullNode.Spec.ChainSpec.ChainID = "namada.5f5de2dd1b88cba30586420"
+
+		job := BuildVerifyJob(&crd, &vs, &fullNode)
+
+		c := job.Spec.Template.Spec.Containers[0]
+		require.Equal(t, []corev1.EnvVar{{Name: "DATA_DIR", Value: "/home/operator/cosmos/namada.5f5de2dd1b88cba30586420/cometbft/data"}}, c.Env)
+	})
+}
+
+func TestVerifyControl_CreateVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("happy path", func(t *testing.T) {
+		crd := verifyCRD()
+		crd.Status.LastSnapshot = &cosmosalpha.VolumeSnapshotStatus{Name: "cosmoshub-202209010203"}
+
+		var mClient mockScratchClient
+		mClient.VolumeSnapshot = readyVolumeSnapshot()
+		mClient.FullNode.Spec.ChainSpec.ChainType = "namada"
+		mClient.FullNode.Spec.ChainSpec.ChainID = "namada.5f5de2dd1b88cba30586420"
+		control := NewVerifyControl(&mClient)
+		control.now = func() time.Time { return now }
+
+		err := control.CreateVerification(ctx, &crd)
+		require.NoError(t, err)
+
+		require.Len(t, mClient.GotCreated, 2)
+		pvc := mClient.GotCreated[0].(*corev1.PersistentVolumeClaim)
+		require.Equal(t, "cosmoshub-202209010203-verify", pvc.Name)
+		require.Equal(t, "premium-rwo", *pvc.Spec.StorageClassName)
+		job := mClient.GotCreated[1].(*batchv1.Job)
+		require.Equal(t, "/home/operator/cosmos/namada.5f5de2dd1b88cba30586420/cometbft/data", job.Spec.Template.Spec.Containers[0].Env[0].Value)
+
+		want := &cosmosalpha.SnapshotVerificationStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-verify",
+			StartedAt:          metav1.NewTime(now),
+		}
+		require.Equal(t, want, crd.Status.LastVerification)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		crd := verifyCRD()
+		crd.Status.LastSnapshot = &cosmosalpha.VolumeSnapshotStatus{Name: "cosmoshub-202209010203"}
+
+		var mClient mockScratchClient
+		mClient.GetErr = errors.New("boom")
+		control := NewVerifyControl(&mClient)
+
+		err := control.CreateVerification(ctx, &crd)
+		require.Error(t, err)
+		require.EqualError(t, err, "get strangelove/cosmoshub-202209010203: boom")
+		require.Empty(t, mClient.GotCreated)
+	})
+}
+
+func TestVerifyControl_VerificationFinished(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	startedCRD := func() cosmosalpha.ScheduledVolumeSnapshot {
+		crd := verifyCRD()
+		crd.Status.LastVerification = &cosmosalpha.SnapshotVerificationStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-verify",
+		}
+		return crd
+	}
+
+	finishedJob := func(condition batchv1.JobConditionType, succeeded int32) *batchv1.Job {
+		var job batchv1.Job
+		job.Name = "cosmoshub-202209010203-verify"
+		job.Namespace = "strangelove"
+		job.Status.Succeeded = succeeded
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		return &job
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		crd := startedCRD()
+
+		var pod corev1.Pod
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: "verify",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `{"height":12346}`,
+			}},
+		}}
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobComplete, 1)
+		mClient.Pods = []corev1.Pod{pod}
+		control := NewVerifyControl(&mClient)
+		control.now = func() time.Time { return now }
+
+		done, err := control.VerificationFinished(ctx, &crd)
+		require.NoError(t, err)
+		require.True(t, done)
+
+		require.Len(t, mClient.GotPatched, 1)
+		require.Equal(t, "cosmoshub-202209010203", mClient.GotPatched[0].GetName())
+		patch, err := mClient.GotPatch[0].Data(mClient.GotPatched[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"metadata":{"labels":{"cosmos.bharvest/verified":"true"}}}`, string(patch))
+
+		require.Len(t, mClient.GotDeleted, 2)
+
+		want := &cosmosalpha.SnapshotVerificationStatus{
+			VolumeSnapshotName: "cosmoshub-202209010203",
+			JobName:            "cosmoshub-202209010203-verify",
+			FinishedAt:         ptr(metav1.NewTime(now)),
+			Verified:           true,
+			Height:             12346,
+		}
+		require.Equal(t, want, crd.Status.LastVerification)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobFailed, 0)
+		control := NewVerifyControl(&mClient)
+
+		done, err := control.VerificationFinished(ctx, &crd)
+		require.True(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "verification job cosmoshub-202209010203-verify failed")
+
+		patch, err := mClient.GotPatch[0].Data(mClient.GotPatched[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"metadata":{"labels":{"cosmos.bharvest/verified":"false"}}}`, string(patch))
+
+		require.Len(t, mClient.GotDeleted, 2)
+		require.False(t, crd.Status.LastVerification.Verified)
+		require.NotNil(t, crd.Status.LastVerification.FinishedAt)
+	})
+
+	t.Run("running", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = new(batchv1.Job)
+		control := NewVerifyControl(&mClient)
+
+		done, err := control.VerificationFinished(ctx, &crd)
+		require.NoError(t, err)
+		require.False(t, done)
+		require.Empty(t, mClient.GotPatched)
+		require.Empty(t, mClient.GotDeleted)
+	})
+
+	t.Run("patch error", func(t *testing.T) {
+		crd := startedCRD()
+
+		var mClient mockScratchClient
+		mClient.Job = finishedJob(batchv1.JobFailed, 0)
+		mClient.PatchErr = errors.New("boom")
+		control := NewVerifyControl(&mClient)
+
+		done, err := control.VerificationFinished(ctx, &crd)
+		require.False(t, done)
+		require.Error(t, err)
+		require.EqualError(t, err, "label cosmoshub-202209010203: boom")
+		require.Empty(t, mClient.GotDeleted)
+		require.Nil(t, crd.Status.LastVerification.FinishedAt)
+	})
+}
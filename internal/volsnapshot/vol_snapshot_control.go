@@ -5,9 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
 	"github.com/go-logr/logr"
@@ -22,6 +26,7 @@ const cosmosSourceLabel = "cosmos.bharvest/source"
 
 // Client is a subset of client.Client.
 type Client interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
 	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
 	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
 	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
@@ -29,6 +34,7 @@ type Client interface {
 
 type PodFilter interface {
 	SyncedPods(ctx context.Context, controller client.ObjectKey) []*corev1.Pod
+	Collect(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection
 }
 
 // VolumeSnapshotControl manages VolumeSnapshots
@@ -50,12 +56,14 @@ type Candidate = cosmosalpha.SnapshotCandidate
 
 // FindCandidate finds a suitable candidate for creating a volume snapshot.
 // Only selects a pod that is in-sync.
+// The candidate includes chain metadata such as block height and image, so it can be recorded on the VolumeSnapshot.
 // Any errors returned can be treated as transient; worth a retry.
 func (control VolumeSnapshotControl) FindCandidate(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) (Candidate, error) {
 	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	var (
-		synced     = control.podFilter.SyncedPods(cctx, client.ObjectKey{Namespace: crd.Namespace, Name: crd.Spec.FullNodeRef.Name})
+		key        = client.ObjectKey{Namespace: crd.Namespace, Name: crd.Spec.FullNodeRef.Name}
+		synced     = control.podFilter.SyncedPods(cctx, key)
 		availCount = int32(len(synced))
 		minAvail   = crd.Spec.MinAvailable
 	)
@@ -85,11 +93,31 @@ func (control VolumeSnapshotControl) FindCandidate(ctx context.Context, crd *cos
 		pod = synced[0]
 	}
 
-	return Candidate{
-		PodLabels: pod.Labels,
-		PodName:   pod.Name,
-		PVCName:   fullnode.PVCName(pod),
-	}, nil
+	var fullNode cosmosv1.CosmosFullNode
+	if err := control.client.Get(cctx, key, &fullNode); err != nil {
+		return Candidate{}, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	candidate := Candidate{
+		PodLabels:       pod.Labels,
+		PodName:         pod.Name,
+		PVCName:         fullnode.PVCName(pod),
+		Image:           fullnode.MainImage(pod),
+		DatabaseBackend: lo.FromPtr(fullNode.Spec.ChainSpec.DatabaseBackend),
+	}
+
+	for _, item := range control.podFilter.Collect(cctx, key) {
+		if item.GetPod().Name != pod.Name {
+			continue
+		}
+		if status, err := item.GetStatus(); err == nil {
+			candidate.Height = status.LatestBlockHeight()
+			candidate.AppVersion = status.Result.NodeInfo.ProtocolVersion.App
+		}
+		break
+	}
+
+	return candidate, nil
 }
 
 // CreateSnapshot creates VolumeSnapshot from the Candidate.PVCName and updates crd.status to reflect the created VolumeSnapshot.
@@ -113,6 +141,23 @@ func (control VolumeSnapshotControl) CreateSnapshot(ctx context.Context, crd *co
 	snapshot.Labels[kube.ComponentLabel] = cosmosalpha.ScheduledVolumeSnapshotController
 	snapshot.Labels[kube.ControllerLabel] = "cosmos-operator"
 	snapshot.Labels[cosmosSourceLabel] = crd.Name
+	if candidate.DatabaseBackend != "" {
+		snapshot.Labels[kube.SnapshotDatabaseBackendLabel] = candidate.DatabaseBackend
+	}
+
+	snapshot.Annotations = make(map[string]string)
+	if candidate.Height > 0 {
+		snapshot.Annotations[kube.SnapshotHeightAnnotation] = strconv.FormatUint(candidate.Height, 10)
+	}
+	if candidate.Image != "" {
+		snapshot.Annotations[kube.SnapshotImageAnnotation] = candidate.Image
+	}
+	if candidate.AppVersion != "" {
+		snapshot.Annotations[kube.SnapshotAppVersionAnnotation] = candidate.AppVersion
+	}
+	if len(crd.Spec.AllowedNamespaces) > 0 {
+		snapshot.Annotations[kube.SnapshotAllowedNamespacesAnnotation] = strings.Join(crd.Spec.AllowedNamespaces, ",")
+	}
 
 	if err := control.client.Create(ctx, &snapshot); err != nil {
 		return err
@@ -126,13 +171,10 @@ func (control VolumeSnapshotControl) CreateSnapshot(ctx context.Context, crd *co
 	return nil
 }
 
-// DeleteOldSnapshots deletes old VolumeSnapshots given crd's spec.limit.
-// If limit not set, defaults to keeping the 3 most recent.
+// DeleteOldSnapshots deletes old VolumeSnapshots given crd's spec.limit and spec.retention.
+// If neither set, defaults to keeping the 3 most recent.
+// VolumeSnapshots referenced as a PVC dataSource are never deleted.
 func (control VolumeSnapshotControl) DeleteOldSnapshots(ctx context.Context, log logr.Logger, crd *cosmosalpha.ScheduledVolumeSnapshot) error {
-	limit := int(crd.Spec.Limit)
-	if limit <= 0 {
-		limit = 3
-	}
 	var snapshots snapshotv1.VolumeSnapshotList
 	err := control.client.List(ctx,
 		&snapshots,
@@ -147,10 +189,6 @@ func (control VolumeSnapshotControl) DeleteOldSnapshots(ctx context.Context, log
 		return item.Status != nil && item.Status.CreationTime != nil
 	})
 
-	if len(filtered) <= limit {
-		return nil
-	}
-
 	// Sort by time descending
 	sort.Slice(filtered, func(i, j int) bool {
 		lhs := filtered[i].Status.CreationTime
@@ -158,15 +196,67 @@ func (control VolumeSnapshotControl) DeleteOldSnapshots(ctx context.Context, log
 		return !lhs.Before(rhs)
 	})
 
-	toDelete := filtered[limit:]
+	keep := RetainedSnapshots(crd, filtered, control.now())
+	toDelete := lo.Filter(filtered, func(item snapshotv1.VolumeSnapshot, _ int) bool {
+		return !keep[item.Name]
+	})
+	if len(toDelete) == 0 {
+		return nil
+	}
+
+	inUse, err := control.snapshotsInUse(ctx, crd)
+	if err != nil {
+		return err
+	}
 
 	var merr error
 	for _, vs := range toDelete {
 		vs := vs
-		log.Info("Deleting volume snapshot", "volumeSnapshotName", vs.Name, "limit", limit)
+		if inUse[vs.Name] {
+			log.Info("Skipping deletion of volume snapshot in use as a PVC data source", "volumeSnapshotName", vs.Name)
+			continue
+		}
+		log.Info("Deleting volume snapshot", "volumeSnapshotName", vs.Name)
 		if err := control.client.Delete(ctx, &vs); kube.IgnoreNotFound(err) != nil {
 			merr = errors.Join(merr, fmt.Errorf("delete %s: %w", vs.Name, err))
 		}
 	}
 	return merr
 }
+
+// snapshotsInUse returns the names of VolumeSnapshots referenced as a dataSource by any PVC in the namespace or
+// in the crd's allowed namespaces.
+// This includes PVCs created by StatefulJobs, exports, and CosmosFullNodes restoring from a VolumeSnapshot.
+func (control VolumeSnapshotControl) snapshotsInUse(ctx context.Context, crd *cosmosalpha.ScheduledVolumeSnapshot) (map[string]bool, error) {
+	var listOpts [][]client.ListOption
+	if lo.Contains(crd.Spec.AllowedNamespaces, "*") {
+		listOpts = append(listOpts, nil)
+	} else {
+		for _, ns := range lo.Uniq(append([]string{crd.Namespace}, crd.Spec.AllowedNamespaces...)) {
+			listOpts = append(listOpts, []client.ListOption{client.InNamespace(ns)})
+		}
+	}
+
+	inUse := make(map[string]bool)
+	for _, opts := range listOpts {
+		var pvcs corev1.PersistentVolumeClaimList
+		if err := control.client.List(ctx, &pvcs, opts...); err != nil {
+			return nil, fmt.Errorf("list pvcs: %w", err)
+		}
+		for _, pvc := range pvcs.Items {
+			if ds := pvc.Spec.DataSource; ds != nil && ds.Kind == "VolumeSnapshot" && pvc.Namespace == crd.Namespace {
+				inUse[ds.Name] = true
+			}
+			if ds := pvc.Spec.DataSourceRef; ds != nil && ds.Kind == "VolumeSnapshot" {
+				ns := pvc.Namespace
+				if ds.Namespace != nil && *ds.Namespace != "" {
+					ns = *ds.Namespace
+				}
+				if ns == crd.Namespace {
+					inUse[ds.Name] = true
+				}
+			}
+		}
+	}
+	return inUse, nil
+}
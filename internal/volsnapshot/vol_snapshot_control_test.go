@@ -10,6 +10,7 @@ import (
 
 	cosmosv1 "github.com/bharvest-devops/cosmos-operator/api/v1"
 	cosmosalpha "github.com/bharvest-devops/cosmos-operator/api/v1alpha1"
+	"github.com/bharvest-devops/cosmos-operator/internal/cosmos"
 	"github.com/bharvest-devops/cosmos-operator/internal/fullnode"
 	"github.com/bharvest-devops/cosmos-operator/internal/kube"
 	"github.com/go-logr/logr"
@@ -22,6 +23,10 @@ import (
 )
 
 type mockPodClient struct {
+	GotGetKey client.ObjectKey
+	GetObj    cosmosv1.CosmosFullNode
+	GetErr    error
+
 	GotListOpts []client.ListOption
 	Items       []corev1.Pod
 	ListErr     error
@@ -30,6 +35,15 @@ type mockPodClient struct {
 	CreateErr    error
 }
 
+func (m *mockPodClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotGetKey = key
+	*obj.(*cosmosv1.CosmosFullNode) = m.GetObj
+	return m.GetErr
+}
+
 func (m *mockPodClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
 	if ctx == nil {
 		panic("nil context")
@@ -56,6 +70,17 @@ func (m *mockPodClient) Delete(ctx context.Context, obj client.Object, opts ...c
 
 type mockPodFilter struct {
 	SyncedPodsFn func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod
+	CollectFn    func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection
+}
+
+func (fn mockPodFilter) Collect(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if fn.CollectFn == nil {
+		return nil
+	}
+	return fn.CollectFn(ctx, controller)
 }
 
 func (fn mockPodFilter) SyncedPods(ctx context.Context, controller client.ObjectKey) []*corev1.Pod {
@@ -123,6 +148,72 @@ func TestVolumeSnapshotControl_FindCandidate(t *testing.T) {
 		require.Equal(t, "pvc-cosmoshub-1", got.PVCName)
 		require.NotEmpty(t, got.PodLabels)
 		require.Equal(t, candidate.Labels, got.PodLabels)
+		require.Zero(t, got.Height)
+		require.Empty(t, got.AppVersion)
+	})
+
+	t.Run("happy path with chain metadata", func(t *testing.T) {
+		var fullnodeCRD cosmosv1.CosmosFullNode
+		fullnodeCRD.Name = fullNodeName
+		fullnodeCRD.Spec.ChainSpec.CosmosSDK = &cosmosv1.SDKAppConfig{}
+		fullnodeCRD.Spec.ChainSpec.DatabaseBackend = ptr("pebbledb")
+		fullnodeCRD.Spec.PodTemplate.Image = "ghcr.io/cosmos/gaia:v14.1.0"
+
+		var mClient mockPodClient
+		mClient.GetObj = fullnodeCRD
+
+		candidate, err := fullnode.NewPodBuilder(&fullnodeCRD).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		var status cosmos.CometStatus
+		status.Result.SyncInfo.LatestBlockHeight = "12345"
+		status.Result.NodeInfo.Version = "0.37.2"
+		status.Result.NodeInfo.ProtocolVersion.App = "3"
+
+		control := NewVolumeSnapshotControl(&mClient, mockPodFilter{
+			SyncedPodsFn: func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod {
+				return []*corev1.Pod{candidate, new(corev1.Pod), new(corev1.Pod)}
+			},
+			CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+				require.Equal(t, namespace, controller.Namespace)
+				require.Equal(t, fullNodeName, controller.Name)
+				return cosmos.StatusCollection{
+					{Pod: new(corev1.Pod)},
+					{Pod: candidate, Status: status},
+				}
+			},
+		})
+
+		got, err := control.FindCandidate(ctx, &crd)
+		require.NoError(t, err)
+
+		require.Equal(t, client.ObjectKey{Namespace: namespace, Name: fullNodeName}, mClient.GotGetKey)
+		require.Equal(t, "cosmoshub-1", got.PodName)
+		require.EqualValues(t, 12345, got.Height)
+		require.Equal(t, "3", got.AppVersion)
+		require.Equal(t, "ghcr.io/cosmos/gaia:v14.1.0", got.Image)
+		require.Equal(t, "pebbledb", got.DatabaseBackend)
+	})
+
+	t.Run("fullnode get error", func(t *testing.T) {
+		var fullnodeCRD cosmosv1.CosmosFullNode
+		fullnodeCRD.Name = fullNodeName
+		fullnodeCRD.Spec.ChainSpec.CosmosSDK = &cosmosv1.SDKAppConfig{}
+		candidate, err := fullnode.NewPodBuilder(&fullnodeCRD).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		var mClient mockPodClient
+		mClient.GetErr = errors.New("boom")
+
+		control := NewVolumeSnapshotControl(&mClient, mockPodFilter{
+			SyncedPodsFn: func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod {
+				return []*corev1.Pod{candidate, new(corev1.Pod), new(corev1.Pod)}
+			},
+		})
+
+		_, err = control.FindCandidate(ctx, &crd)
+		require.Error(t, err)
+		require.EqualError(t, err, "get strangelove/cosmoshub: boom")
 	})
 
 	t.Run("happy path with index", func(t *testing.T) {
@@ -296,6 +387,7 @@ func TestVolumeSnapshotControl_CreateSnapshot(t *testing.T) {
 			"cosmos.bharvest/source": "my-snapshot",
 		}
 		require.Equal(t, wantLabels, got.Labels)
+		require.Empty(t, got.Annotations)
 
 		wantStatus := &cosmosalpha.VolumeSnapshotStatus{
 			Name:      wantName,
@@ -304,6 +396,36 @@ func TestVolumeSnapshotControl_CreateSnapshot(t *testing.T) {
 		require.Equal(t, wantStatus, crd.Status.LastSnapshot)
 	})
 
+	t.Run("chain metadata", func(t *testing.T) {
+		var mClient mockPodClient
+		control := NewVolumeSnapshotControl(&mClient, panicFilter)
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Name = "cosmoshub"
+		crd.Spec.AllowedNamespaces = []string{"osmosis", "juno"}
+
+		candidate := Candidate{
+			PodName:         "chain-1",
+			PVCName:         "pvc-chain-1",
+			Height:          12345,
+			Image:           "ghcr.io/cosmos/gaia:v14.1.0",
+			AppVersion:      "3",
+			DatabaseBackend: "pebbledb",
+		}
+		err := control.CreateSnapshot(ctx, &crd, candidate)
+		require.NoError(t, err)
+
+		got := mClient.GotCreateObj.(*snapshotv1.VolumeSnapshot)
+		require.Equal(t, "pebbledb", got.Labels["cosmos.bharvest/database-backend"])
+
+		wantAnnotations := map[string]string{
+			"cosmos.bharvest/height":             "12345",
+			"cosmos.bharvest/image":              "ghcr.io/cosmos/gaia:v14.1.0",
+			"cosmos.bharvest/app-version":        "3",
+			"cosmos.bharvest/allowed-namespaces": "osmosis,juno",
+		}
+		require.Equal(t, wantAnnotations, got.Annotations)
+	})
+
 	t.Run("nil pod labels", func(t *testing.T) {
 		var mClient mockPodClient
 		control := NewVolumeSnapshotControl(&mClient, panicFilter)
@@ -342,10 +464,19 @@ type mockVolumeSnapshotClient struct {
 	Items       []snapshotv1.VolumeSnapshot
 	ListErr     error
 
+	GotPVCListOpts []client.ListOption
+	PVCListCount   int
+	PVCs           []corev1.PersistentVolumeClaim
+	PVCListErr     error
+
 	DeletedObjs []*snapshotv1.VolumeSnapshot
 	DeleteErr   error
 }
 
+func (m *mockVolumeSnapshotClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	panic("get should not be called")
+}
+
 func (m *mockVolumeSnapshotClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
 	panic("create should not be called")
 }
@@ -354,9 +485,18 @@ func (m *mockVolumeSnapshotClient) List(ctx context.Context, list client.ObjectL
 	if ctx == nil {
 		panic("nil context")
 	}
-	m.GotListOpts = opts
-	list.(*snapshotv1.VolumeSnapshotList).Items = m.Items
-	return m.ListErr
+	switch list := list.(type) {
+	case *snapshotv1.VolumeSnapshotList:
+		m.GotListOpts = opts
+		list.Items = m.Items
+		return m.ListErr
+	case *corev1.PersistentVolumeClaimList:
+		m.GotPVCListOpts = opts
+		m.PVCListCount++
+		list.Items = m.PVCs
+		return m.PVCListErr
+	}
+	panic(fmt.Errorf("unexpected list type %T", list))
 }
 
 func (m *mockVolumeSnapshotClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
@@ -528,4 +668,156 @@ func TestVolumeSnapshotControl_DeleteOldSnapshots(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "delete 1: oops\ndelete 0: oops")
 	})
+
+	t.Run("snapshots in use", func(t *testing.T) {
+		now := time.Now()
+		const total = 5
+
+		var mClient mockVolumeSnapshotClient
+		for i := 0; i < total; i++ {
+			creation := metav1.NewTime(now.Add(time.Duration(i) * time.Second))
+			mClient.Items = append(mClient.Items, snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: strconv.Itoa(i)},
+				Status: &snapshotv1.VolumeSnapshotStatus{
+					CreationTime: &creation,
+				},
+			})
+		}
+
+		var statefulJobPVC corev1.PersistentVolumeClaim
+		statefulJobPVC.Namespace = "default"
+		statefulJobPVC.Spec.DataSource = &corev1.TypedLocalObjectReference{Kind: "VolumeSnapshot", Name: "0"}
+		var restoringPVC corev1.PersistentVolumeClaim
+		restoringPVC.Namespace = "default"
+		restoringPVC.Spec.DataSourceRef = &corev1.TypedObjectReference{Kind: "VolumeSnapshot", Name: "1"}
+		var otherPVC corev1.PersistentVolumeClaim
+		otherPVC.Spec.DataSource = &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "2"}
+		mClient.PVCs = []corev1.PersistentVolumeClaim{statefulJobPVC, restoringPVC, otherPVC}
+
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Namespace = "default"
+		crd.Spec.Limit = 2
+		control := NewVolumeSnapshotControl(&mClient, panicFilter)
+		err := control.DeleteOldSnapshots(ctx, nopLogger, &crd)
+
+		require.NoError(t, err)
+
+		var listOpt client.ListOptions
+		for _, opt := range mClient.GotPVCListOpts {
+			opt.ApplyToList(&listOpt)
+		}
+		require.Equal(t, "default", listOpt.Namespace)
+
+		got := lo.Map(mClient.DeletedObjs, func(item *snapshotv1.VolumeSnapshot, _ int) string {
+			return item.Name
+		})
+		require.Equal(t, []string{"2"}, got)
+	})
+
+	t.Run("snapshots in use by allowed namespaces", func(t *testing.T) {
+		now := time.Now()
+		const total = 5
+
+		var mClient mockVolumeSnapshotClient
+		for i := 0; i < total; i++ {
+			creation := metav1.NewTime(now.Add(time.Duration(i) * time.Second))
+			mClient.Items = append(mClient.Items, snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: strconv.Itoa(i)},
+				Status: &snapshotv1.VolumeSnapshotStatus{
+					CreationTime: &creation,
+				},
+			})
+		}
+
+		var crossPVC corev1.PersistentVolumeClaim
+		crossPVC.Namespace = "consumer"
+		crossPVC.Spec.DataSourceRef = &corev1.TypedObjectReference{Kind: "VolumeSnapshot", Name: "0", Namespace: ptr("default")}
+		// Same name but refers to a VolumeSnapshot in the consumer's own namespace.
+		var localPVC corev1.PersistentVolumeClaim
+		localPVC.Namespace = "consumer"
+		localPVC.Spec.DataSource = &corev1.TypedLocalObjectReference{Kind: "VolumeSnapshot", Name: "1"}
+		mClient.PVCs = []corev1.PersistentVolumeClaim{crossPVC, localPVC}
+
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Namespace = "default"
+		crd.Spec.Limit = 2
+		crd.Spec.AllowedNamespaces = []string{"consumer", "default"}
+		control := NewVolumeSnapshotControl(&mClient, panicFilter)
+		err := control.DeleteOldSnapshots(ctx, nopLogger, &crd)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, mClient.PVCListCount)
+
+		got := lo.Map(mClient.DeletedObjs, func(item *snapshotv1.VolumeSnapshot, _ int) string {
+			return item.Name
+		})
+		require.Equal(t, []string{"2", "1"}, got)
+
+		mClient = mockVolumeSnapshotClient{Items: mClient.Items, PVCs: mClient.PVCs}
+		crd.Spec.AllowedNamespaces = []string{"*"}
+		err = control.DeleteOldSnapshots(ctx, nopLogger, &crd)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, mClient.PVCListCount)
+		var listOpt client.ListOptions
+		for _, opt := range mClient.GotPVCListOpts {
+			opt.ApplyToList(&listOpt)
+		}
+		require.Empty(t, listOpt.Namespace)
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+		var mClient mockVolumeSnapshotClient
+		// One snapshot every 6 hours for 3 days.
+		for i := 0; i < 12; i++ {
+			creation := metav1.NewTime(now.Add(-time.Duration(i) * 6 * time.Hour))
+			mClient.Items = append(mClient.Items, snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: strconv.Itoa(i)},
+				Status: &snapshotv1.VolumeSnapshotStatus{
+					CreationTime: &creation,
+				},
+			})
+		}
+		lo.Shuffle(mClient.Items)
+
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Retention = &cosmosalpha.SnapshotRetention{Hourly: 1, Daily: 2}
+		control := NewVolumeSnapshotControl(&mClient, panicFilter)
+		control.now = func() time.Time { return now }
+		err := control.DeleteOldSnapshots(ctx, nopLogger, &crd)
+
+		require.NoError(t, err)
+
+		got := lo.Map(mClient.DeletedObjs, func(item *snapshotv1.VolumeSnapshot, _ int) string {
+			return item.Name
+		})
+		// Keeps 0 (latest hour and day) and 3 (latest on the previous day).
+		require.Equal(t, []string{"1", "2", "4", "5", "6", "7", "8", "9", "10", "11"}, got)
+	})
+
+	t.Run("pvc list error", func(t *testing.T) {
+		now := metav1.Now()
+
+		var mClient mockVolumeSnapshotClient
+		mClient.PVCListErr = errors.New("boom")
+		for i := 0; i < 2; i++ {
+			mClient.Items = append(mClient.Items, snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: strconv.Itoa(i)},
+				Status: &snapshotv1.VolumeSnapshotStatus{
+					CreationTime: &now,
+				},
+			})
+		}
+
+		var crd cosmosalpha.ScheduledVolumeSnapshot
+		crd.Spec.Limit = 1
+		control := NewVolumeSnapshotControl(&mClient, panicFilter)
+		err := control.DeleteOldSnapshots(ctx, nopLogger, &crd)
+
+		require.Error(t, err)
+		require.EqualError(t, err, "list pvcs: boom")
+		require.Empty(t, mClient.DeletedObjs)
+	})
 }
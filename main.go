@@ -83,6 +83,7 @@ var (
 	profileMode          string
 	logLevel             string
 	logFormat            string
+	cometWebsocket       bool
 )
 
 func rootCmd() *cobra.Command {
@@ -102,14 +103,19 @@ func rootCmd() *cobra.Command {
 	root.Flags().StringVar(&profileMode, "profile", "", "Enable profiling and save profile to working dir. (Must be one of 'cpu', or 'mem'.)")
 	root.Flags().StringVar(&logLevel, "log-level", "info", "Logging level one of 'error', 'info', 'debug'")
 	root.Flags().StringVar(&logFormat, "log-format", "console", "Logging format one of 'console' or 'json'")
+	root.Flags().BoolVar(&cometWebsocket, "comet-websocket", false,
+		"Subscribe to new blocks of each pod over the CometBFT websocket instead of polling the status endpoint. "+
+			"Pods fall back to polling while their subscription is broken.")
 
 	if err := viper.BindPFlags(root.Flags()); err != nil {
 		panic(err)
 	}
 
 	// Add subcommands here
-	root.AddCommand(opcmd.HealthCheckCmd())
+	root.AddCommand(opcmd.HealthCheckCmd(scheme))
 	root.AddCommand(opcmd.VersionCheckCmd(scheme))
+	root.AddCommand(opcmd.SnapshotVerifyCmd())
+	root.AddCommand(opcmd.DriftRemediateCmd(scheme))
 	root.AddCommand(&cobra.Command{
 		Short: "Print the version",
 		Use:   "version",
@@ -168,8 +174,13 @@ func startManager(cmd *cobra.Command, args []string) error {
 	httpClient := &http.Client{Timeout: 30 * time.Second}
 	statusClient := fullnode.NewStatusClient(mgr.GetClient())
 	cometClient := cosmos.NewCometClient(httpClient)
+	var blockSubscriber cosmos.BlockSubscriber
+	if cometWebsocket {
+		blockSubscriber = cosmos.NewCometSubscriber(5*time.Second, time.Minute)
+	}
 	cacheController := cosmos.NewCacheController(
 		cosmos.NewStatusCollector(cometClient, 5*time.Second),
+		blockSubscriber,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(cosmos.CacheControllerName),
 	)
@@ -184,6 +195,7 @@ func startManager(cmd *cobra.Command, args []string) error {
 		mgr.GetEventRecorderFor(cosmosv1.CosmosFullNodeController),
 		statusClient,
 		cacheController,
+		httpClient,
 	).SetupWithManager(ctx, mgr); err != nil {
 		return fmt.Errorf("unable to create CosmosFullNode controller: %w", err)
 	}